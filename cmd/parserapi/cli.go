@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain/client"
+	"trust_wallet_homework/internal/core/domain/repository"
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// Supported subcommands.
+const (
+	cmdServe        = "serve"
+	cmdCurrentBlock = "current-block"
+	cmdTransactions = "transactions"
+)
+
+// configEnvVar names the environment variable consulted for the config file path
+// when the -config flag is not given.
+const configEnvVar = "PARSER_CONFIG_FILE"
+
+// command describes the operation requested on the command line.
+type command struct {
+	name       string
+	address    string
+	configPath string
+}
+
+// currentBlockOutput is the JSON printed by the current-block subcommand.
+type currentBlockOutput struct {
+	CurrentBlock int64  `json:"current_block"`
+	Source       string `json:"source"`
+}
+
+// parseArgs parses the command-line arguments (without the program name) into a command.
+// When no subcommand is given, serve is assumed.
+func parseArgs(args []string, output io.Writer) (command, error) {
+	fs := flag.NewFlagSet("parserapi", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "Usage: parserapi [flags] [serve | current-block | transactions <address>]")
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", configFilePath, "path to the YAML configuration file")
+	if err := fs.Parse(args); err != nil {
+		return command{}, err
+	}
+	configFlagSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "config" {
+			configFlagSet = true
+		}
+	})
+	*configPath = resolveConfigPath(*configPath, configFlagSet, os.LookupEnv)
+
+	rest := fs.Args()
+	if len(rest) == 0 {
+		return command{name: cmdServe, configPath: *configPath}, nil
+	}
+
+	switch rest[0] {
+	case cmdServe, cmdCurrentBlock:
+		if len(rest) != 1 {
+			return command{}, fmt.Errorf("%s takes no arguments", rest[0])
+		}
+		return command{name: rest[0], configPath: *configPath}, nil
+	case cmdTransactions:
+		if len(rest) != 2 || rest[1] == "" {
+			return command{}, errors.New("transactions requires exactly one address argument")
+		}
+		return command{name: cmdTransactions, address: rest[1], configPath: *configPath}, nil
+	default:
+		return command{}, fmt.Errorf("unknown command %q", rest[0])
+	}
+}
+
+// resolveConfigPath picks the config file path: an explicit -config flag wins, then a non-empty
+// PARSER_CONFIG_FILE environment variable, then the built-in default.
+func resolveConfigPath(flagValue string, flagSet bool, lookupEnv func(string) (string, bool)) string {
+	if flagSet {
+		return flagValue
+	}
+	if v, ok := lookupEnv(configEnvVar); ok && v != "" {
+		return v
+	}
+	return configFilePath
+}
+
+// checkStorage reports whether the command can run on the given storage backend. The transactions command
+// reads what a running parser stored, which the in-memory backend loses when that process exits.
+func checkStorage(cmd command, backend string) error {
+	if cmd.name == cmdTransactions && backend == config.StorageBackendMemory {
+		return fmt.Errorf("%s needs a persistent storage.backend (%s or %s), not %s",
+			cmdTransactions, config.StorageBackendSQLite, config.StorageBackendPostgres, backend)
+	}
+	return nil
+}
+
+// runOneShot performs a single query against the configured node/storage and prints the result as JSON.
+func runOneShot(
+	ctx context.Context,
+	cmd command,
+	parserService ethparser.Parser,
+	ethClient client.EthereumClient,
+	out io.Writer,
+) error {
+	var result any
+	switch cmd.name {
+	case cmdCurrentBlock:
+		blockNum, err := parserService.GetCurrentBlock(ctx)
+		switch {
+		case err == nil:
+			result = currentBlockOutput{CurrentBlock: blockNum, Source: "parser_state"}
+		case errors.Is(err, repository.ErrStateNotInitialized):
+			latest, errNode := ethClient.GetLatestBlockNumber(ctx)
+			if errNode != nil {
+				return fmt.Errorf("parser state is empty and fetching latest block failed: %w", errNode)
+			}
+			result = currentBlockOutput{CurrentBlock: latest.Value(), Source: "node"}
+		default:
+			return fmt.Errorf("failed to get current block: %w", err)
+		}
+	case cmdTransactions:
+		txs, err := parserService.GetTransactions(ctx, cmd.address)
+		if err != nil {
+			return fmt.Errorf("failed to get transactions: %w", err)
+		}
+		result = txs
+	default:
+		return fmt.Errorf("command %q is not a one-shot command", cmd.name)
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
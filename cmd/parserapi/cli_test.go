@@ -0,0 +1,149 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"trust_wallet_homework/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArgs(t *testing.T) {
+	const addr = "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    command
+		wantErr bool
+	}{
+		{
+			name: "No arguments defaults to serve",
+			args: nil,
+			want: command{name: cmdServe, configPath: configFilePath},
+		},
+		{
+			name: "Explicit serve",
+			args: []string{"serve"},
+			want: command{name: cmdServe, configPath: configFilePath},
+		},
+		{
+			name: "Current block",
+			args: []string{"current-block"},
+			want: command{name: cmdCurrentBlock, configPath: configFilePath},
+		},
+		{
+			name: "Transactions with address",
+			args: []string{"transactions", addr},
+			want: command{name: cmdTransactions, address: addr, configPath: configFilePath},
+		},
+		{
+			name: "Config flag before subcommand",
+			args: []string{"-config=/etc/parser.yml", "current-block"},
+			want: command{name: cmdCurrentBlock, configPath: "/etc/parser.yml"},
+		},
+		{
+			name:    "Transactions without address",
+			args:    []string{"transactions"},
+			wantErr: true,
+		},
+		{
+			name:    "Current block with extra argument",
+			args:    []string{"current-block", "extra"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown command",
+			args:    []string{"frobnicate"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown flag",
+			args:    []string{"-nope"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseArgs(tt.args, io.Discard)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckStorage(t *testing.T) {
+	transactions := command{name: cmdTransactions, address: testAddress}
+
+	assert.Error(t, checkStorage(transactions, config.StorageBackendMemory),
+		"an in-memory store is empty in a new process")
+	assert.NoError(t, checkStorage(transactions, config.StorageBackendSQLite))
+	assert.NoError(t, checkStorage(transactions, config.StorageBackendPostgres))
+	assert.NoError(t, checkStorage(command{name: cmdCurrentBlock}, config.StorageBackendMemory))
+	assert.NoError(t, checkStorage(command{name: cmdServe}, config.StorageBackendMemory))
+}
+
+func TestResolveConfigPath(t *testing.T) {
+	env := func(value string, ok bool) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			if key != configEnvVar {
+				return "", false
+			}
+			return value, ok
+		}
+	}
+
+	tests := []struct {
+		name      string
+		flagValue string
+		flagSet   bool
+		lookupEnv func(string) (string, bool)
+		want      string
+	}{
+		{
+			name:      "Default when neither flag nor env is set",
+			flagValue: configFilePath,
+			lookupEnv: env("", false),
+			want:      configFilePath,
+		},
+		{
+			name:      "Env overrides default",
+			flagValue: configFilePath,
+			lookupEnv: env("/etc/env.yml", true),
+			want:      "/etc/env.yml",
+		},
+		{
+			name:      "Empty env is ignored",
+			flagValue: configFilePath,
+			lookupEnv: env("", true),
+			want:      configFilePath,
+		},
+		{
+			name:      "Flag overrides env",
+			flagValue: "/etc/flag.yml",
+			flagSet:   true,
+			lookupEnv: env("/etc/env.yml", true),
+			want:      "/etc/flag.yml",
+		},
+		{
+			name:      "Flag explicitly set to default still overrides env",
+			flagValue: configFilePath,
+			flagSet:   true,
+			lookupEnv: env("/etc/env.yml", true),
+			want:      configFilePath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveConfigPath(tt.flagValue, tt.flagSet, tt.lookupEnv))
+		})
+	}
+}
@@ -4,16 +4,19 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
-	"trust_wallet_homework/internal/adapters/storage/memory/address"
-	"trust_wallet_homework/internal/adapters/storage/memory/parser_state"
-	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
+	"trust_wallet_homework/internal/adapters/cache"
+	"trust_wallet_homework/internal/adapters/storage"
+	"trust_wallet_homework/internal/adapters/storage/memory/dead_letter"
 
 	"trust_wallet_homework/internal/adapters/restapi"
 	"trust_wallet_homework/internal/adapters/rpc"
@@ -29,70 +32,254 @@ const configFilePath = "config/config.yml"
 
 // main is the entry point of the application.
 func main() {
-	cfg, err := config.LoadConfig(configFilePath)
+	cmd, err := parseArgs(os.Args[1:], os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		log.Fatalf("Invalid arguments: %v\n", err)
+	}
+
+	cfg, err := config.LoadConfig(cmd.configPath)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v\n", err)
 	}
+	if err := checkStorage(cmd, cfg.Storage.Backend); err != nil {
+		log.Fatalf("Invalid arguments: %v\n", err)
+	}
 
-	appLogger, err := applogger.NewAppLogger(cfg.Logger)
+	// One-shot commands print JSON to stdout, so their logs go to stderr.
+	logOutput := os.Stdout
+	if cmd.name != cmdServe {
+		logOutput = os.Stderr
+	}
+	appLogger, err := applogger.NewAppLoggerWithWriter(cfg.Logger, logOutput)
 	if err != nil {
 		log.Fatalf("Failed to initialize logger: %v\n", err)
 	}
 	appLogger.Info("Logger initialized", "level", cfg.Logger.Level, "format", cfg.Logger.Format)
 
-	if err := run(cfg, appLogger); err != nil {
-		appLogger.Error("Application run failed", "error", err)
-		os.Exit(1)
+	result := run(cmd, cfg, appLogger)
+	result.log(appLogger)
+	if code := result.exitCode(); code != 0 {
+		os.Exit(code)
 	}
+}
 
-	appLogger.Info("Application shut down gracefully.")
+// components holds the wired application dependencies shared by all commands.
+type components struct {
+	ethClient      *rpc.EthereumNodeAdapter
+	parserService  *application.ParserServiceImpl
+	storageClosers []io.Closer
 }
 
-// run initializes and starts the application components.
-func run(cfg *config.Config, logger applogger.AppLogger) error {
-	baseCtx := context.Background()
-	ctx, stop := signal.NotifyContext(baseCtx, syscall.SIGINT, syscall.SIGTERM)
-	defer stop()
-	httpClient := &http.Client{Timeout: time.Duration(cfg.ETHClient.ClientTimeoutSeconds) * time.Second}
+// buildComponents constructs the node client, storage and parser service from configuration.
+func buildComponents(cfg *config.Config, logger applogger.AppLogger) (*components, error) {
+	httpClient, err := newHTTPClient(cfg.ETHClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create node HTTP client: %w", err)
+	}
 
-	ethNodeClient := rpc.NewEthereumNodeAdapter(cfg.ETHClient.NodeURL, httpClient)
+	rpcOpts := []rpc.Option{
+		rpc.WithCallTimeout(time.Duration(cfg.ETHClient.RPCCallTimeoutSeconds) * time.Second),
+		rpc.WithBearerToken(cfg.ETHClient.BearerToken),
+		rpc.WithLogger(logger.With("component", "rpc")),
+		rpc.WithStrictParsing(cfg.ETHClient.ParseMode == config.ParseModeStrict),
+		rpc.WithMethodAllowlist(cfg.ETHClient.AllowedMethods),
+		rpc.WithLatencyReporting(logger.With("component", "rpc"),
+			time.Duration(cfg.ETHClient.LatencyReportSeconds)*time.Second),
+	}
+	if cfg.ETHClient.DebugLogPayloads {
+		rpcOpts = append(rpcOpts, rpc.WithDebugLogging(logger.With("component", "rpc"), cfg.ETHClient.DebugLogMaxBytes))
+	}
+	ethNodeClient := rpc.NewEthereumNodeAdapter(cfg.ETHClient.NodeURLs(), httpClient, rpcOpts...)
 
-	stateRepo := parser_state.NewInMemoryParserStateRepo()
-	addrRepo := address.NewInMemoryAddressRepo()
-	txRepo := transaction.NewInMemoryTransactionRepo()
+	repos, err := storage.NewRepositories(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	serviceOpts := []application.ServiceOption{
+		application.WithDeadLetterStore(
+			dead_letter.NewInMemoryDeadLetterStore(dead_letter.WithMaxLetters(cfg.AppService.MaxDeadLetters)),
+		),
+		application.WithExpectedChainID(cfg.ETHClient.ExpectedChainID),
+	}
+	if cfg.Storage.AtomicBlockWrites {
+		serviceOpts = append(serviceOpts, application.WithScanRepository(repos.Scan))
+	}
 
 	parserService, err := application.NewParserService(
-		stateRepo,
-		addrRepo,
-		txRepo,
-		ethNodeClient,
+		repos.State,
+		repos.Addresses,
+		repos.Transactions,
+		cache.NewCachingClient(ethNodeClient, cfg.ETHClient.BlockCacheSize,
+			cache.WithConfirmationDepth(cfg.AppService.RescanTailBlocks)),
 		logger,
 		cfg.AppService,
+		serviceOpts...,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create parser service: %w", err)
+		return nil, errors.Join(
+			fmt.Errorf("failed to create parser service: %w", err),
+			closeStorage(logger, repos.Closers()),
+		)
+	}
+
+	return &components{
+		ethClient:      ethNodeClient,
+		parserService:  parserService,
+		storageClosers: repos.Closers(),
+	}, nil
+}
+
+// newHTTPClient builds the HTTP client used for node requests. Requests go through the configured
+// proxy, or else through the proxy given by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func newHTTPClient(cfg config.ETHClientConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.HTTPProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.HTTPProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(cfg.ClientTimeoutSeconds) * time.Second,
+	}, nil
+}
+
+// run initializes the application components and executes the requested command.
+func run(cmd command, cfg *config.Config, logger applogger.AppLogger) shutdownResult {
+	baseCtx := context.Background()
+	ctx, stop := signal.NotifyContext(baseCtx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	comps, err := buildComponents(cfg, logger)
+	if err != nil {
+		return shutdownResult{reason: shutdownError, err: err}
 	}
 
-	apiServer, err := restapi.NewServer(parserService, logger, &cfg.Server)
+	if cmd.name != cmdServe {
+		errRun := runOneShot(ctx, cmd, comps.parserService, comps.ethClient, os.Stdout)
+		if errRun != nil {
+			return shutdownResult{reason: shutdownError, err: errors.Join(errRun, closeStorage(logger, comps.storageClosers))}
+		}
+		return shutdownResult{reason: shutdownCompleted, err: closeStorage(logger, comps.storageClosers)}
+	}
+
+	apiServer, err := restapi.NewServer(comps.parserService, logger, &cfg.Server, restapi.WithDebugConfig(*cfg))
 	if err != nil {
-		return fmt.Errorf("failed to create API server: %w", err)
+		return shutdownResult{reason: shutdownError, err: errors.Join(
+			fmt.Errorf("failed to create API server: %w", err),
+			closeStorage(logger, comps.storageClosers),
+		)}
 	}
 
-	return gracefulShutdown(ctx, logger, parserService, apiServer)
+	if cfg.AppService.Mode == config.AppServiceModeBackfill {
+		ctx = stopWhenDone(ctx, logger, comps.parserService.Done())
+	}
+
+	timeouts := shutdownTimeouts{
+		server: time.Duration(cfg.Server.ShutdownTimeoutSeconds) * time.Second,
+		parser: time.Duration(cfg.AppService.ShutdownTimeoutSeconds) * time.Second,
+	}
+	return gracefulShutdown(ctx, logger, comps.parserService, apiServer, comps.storageClosers, timeouts)
+}
+
+// stopWhenDone returns a context that is also cancelled once done is closed, so a finished backfill
+// triggers the same graceful shutdown as a signal.
+func stopWhenDone(ctx context.Context, logger applogger.AppLogger, done <-chan struct{}) context.Context {
+	doneCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		select {
+		case <-done:
+			logger.Info("Backfill finished, shutting down...")
+		case <-doneCtx.Done():
+		}
+	}()
+	return doneCtx
+}
+
+// shutdownReason tells what ended a run of the application.
+type shutdownReason int
+
+const (
+	// shutdownCompleted means a one-shot command ran to completion.
+	shutdownCompleted shutdownReason = iota
+	// shutdownSignal means the server was asked to stop, by SIGINT/SIGTERM or by a finished backfill.
+	shutdownSignal
+	// shutdownError means a component failed, or the application could not be started.
+	shutdownError
+)
+
+// String returns the name of the reason as used in logs.
+func (r shutdownReason) String() string {
+	switch r {
+	case shutdownCompleted:
+		return "completed"
+	case shutdownSignal:
+		return "signal"
+	default:
+		return "error"
+	}
+}
+
+// shutdownResult reports why a run of the application ended and everything that went wrong on the way,
+// including failures while stopping components after a signal.
+type shutdownResult struct {
+	reason shutdownReason
+	err    error
+}
+
+// exitCode maps the result to the process exit code: 0 for a clean stop, 1 for a component failure or a
+// stop that did not complete cleanly.
+func (r shutdownResult) exitCode() int {
+	if r.reason == shutdownError || r.err != nil {
+		return 1
+	}
+	return 0
+}
+
+// log reports the result once the application has stopped.
+func (r shutdownResult) log(logger applogger.AppLogger) {
+	switch {
+	case r.err != nil:
+		logger.Error("Application run failed", "reason", r.reason.String(), "error", r.err)
+	case r.reason == shutdownSignal:
+		logger.Info("Application shut down gracefully.", "reason", r.reason.String())
+	}
+}
+
+// shutdownTimeouts bounds how long each shutdown phase may take.
+type shutdownTimeouts struct {
+	server time.Duration
+	parser time.Duration
 }
 
 // gracefulShutdown manages the startup of concurrent components and their graceful shutdown.
+// Shutdown is ordered: the HTTP server stops accepting requests and drains in-flight ones,
+// then the parser is stopped, and finally the storage closers are called.
+// The result reason is shutdownSignal if ctx was cancelled and shutdownError if a component failed first.
 func gracefulShutdown(
 	ctx context.Context,
 	logger applogger.AppLogger,
 	parserService ethparser.Parser,
 	apiServer *restapi.Server,
-) error {
+	storageClosers []io.Closer,
+	timeouts shutdownTimeouts,
+) shutdownResult {
 	g, gCtx := errgroup.WithContext(ctx)
 
+	// The parser runs on its own context so it keeps serving in-flight requests until the HTTP server has drained.
+	parserCtx, cancelParser := context.WithCancel(context.Background())
+	defer cancelParser()
+
 	g.Go(func() error {
 		logger.Info("Starting parser service background process...")
-		if errSvcStart := parserService.Start(gCtx); errSvcStart != nil {
+		if errSvcStart := parserService.Start(parserCtx); errSvcStart != nil {
 			logger.Error("Parser service Start() call returned an error", "error", errSvcStart)
 			return fmt.Errorf("parser service Start() failed: %w", errSvcStart)
 		}
@@ -116,7 +303,7 @@ func gracefulShutdown(
 		select {
 		case <-gCtx.Done():
 			logger.Info("API server: context cancelled, initiating shutdown...")
-			shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 15*time.Second)
+			shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), timeouts.server)
 			defer cancelShutdown()
 			if err := apiServer.Shutdown(shutdownCtx); err != nil {
 				logger.Error("API server graceful shutdown error", "error", err)
@@ -138,31 +325,41 @@ func gracefulShutdown(
 		}
 	})
 
-	waitErr := g.Wait()
-
-	if waitErr != nil {
-		if errors.Is(waitErr, context.Canceled) {
-			logger.Info("Errgroup context cancelled (likely SIGINT/SIGTERM), proceeding with final cleanup.")
-		} else {
-			logger.Error("A service within errgroup failed", "error", waitErr)
-		}
+	result := shutdownResult{reason: shutdownSignal, err: g.Wait()}
+	// ctx is only cancelled from outside; a failing component cancels gCtx alone.
+	if ctx.Err() == nil {
+		result.reason = shutdownError
+		logger.Error("A service within errgroup failed", "error", result.err)
+	} else {
+		logger.Info("Shutdown requested, proceeding with final cleanup.")
 	}
 
-	parserShutdownCtx, cancelParserShutdown := context.WithTimeout(context.Background(), 10*time.Second)
+	cancelParser()
+	parserShutdownCtx, cancelParserShutdown := context.WithTimeout(context.Background(), timeouts.parser)
 	defer cancelParserShutdown()
 	if err := parserService.Stop(parserShutdownCtx); err != nil {
 		logger.Error("Parser service graceful shutdown error (post g.Wait)", "error", err)
-		if !errors.Is(waitErr, context.Canceled) {
-			if waitErr == nil {
-				waitErr = fmt.Errorf("parser service stop failed: %w", err)
-			} else {
-				waitErr = fmt.Errorf("parser service stop failed (%w) after initial error (%w)", err, waitErr)
-			}
-		}
+		result.err = errors.Join(result.err, fmt.Errorf("parser service stop failed: %w", err))
 	}
 
-	if errors.Is(waitErr, context.Canceled) {
-		return nil
+	if err := closeStorage(logger, storageClosers); err != nil {
+		result.err = errors.Join(result.err, err)
+	}
+
+	return result
+}
+
+// closeStorage closes the storage backends after all their users have stopped.
+func closeStorage(logger applogger.AppLogger, closers []io.Closer) error {
+	var errs []error
+	for _, c := range closers {
+		if err := c.Close(); err != nil {
+			logger.Error("Failed to close storage", "storage", fmt.Sprintf("%T", c), "error", err)
+			errs = append(errs, fmt.Errorf("failed to close %T: %w", c, err))
+		}
+	}
+	if len(errs) == 0 && len(closers) > 0 {
+		logger.Info("Storage closed.", "count", len(closers))
 	}
-	return waitErr
+	return errors.Join(errs...)
 }
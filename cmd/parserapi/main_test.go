@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/adapters/restapi"
+	"trust_wallet_homework/internal/adapters/restapi/mocks/mock_ethparser"
+	"trust_wallet_homework/internal/config"
+	applogger "trust_wallet_homework/internal/logger"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testAddress = "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+
+// shutdownRecorder records the order in which shutdown steps happen.
+type shutdownRecorder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (r *shutdownRecorder) record(step string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+func (r *shutdownRecorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.steps...)
+}
+
+// recordingCloser is an io.Closer that records when it is closed.
+type recordingCloser struct {
+	recorder *shutdownRecorder
+}
+
+func (c recordingCloser) Close() error {
+	c.recorder.record("close")
+	return nil
+}
+
+func TestGracefulShutdown_DrainsInFlightRequestBeforeStoppingParser(t *testing.T) {
+	logger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	recorder := &shutdownRecorder{}
+	requestStarted := make(chan struct{})
+	releaseRequest := make(chan struct{})
+
+	mockParser := mock_ethparser.NewParser(t)
+	mockParser.On("Start", mock.Anything).Return(nil)
+	mockParser.On("GetTransactions", mock.Anything, testAddress).
+		Run(func(mock.Arguments) {
+			close(requestStarted)
+			<-releaseRequest
+		}).
+		Return([]ethparser.Transaction{}, nil)
+	mockParser.On("Stop", mock.Anything).
+		Run(func(mock.Arguments) { recorder.record("stop") }).
+		Return(nil)
+
+	addr := freeLocalAddr(t)
+	apiServer, err := restapi.NewServer(mockParser, logger, &config.ServerConfig{Port: addr})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shutdown := make(chan shutdownResult, 1)
+	go func() {
+		shutdown <- gracefulShutdown(ctx, logger, mockParser, apiServer, []io.Closer{recordingCloser{recorder}},
+			shutdownTimeouts{server: 5 * time.Second, parser: 5 * time.Second})
+	}()
+	waitForListener(t, addr)
+
+	respCode := make(chan int, 1)
+	go func() {
+		resp, errGet := http.Get("http://" + addr + "/transactions/" + testAddress)
+		if errGet != nil {
+			respCode <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		respCode <- resp.StatusCode
+	}()
+
+	<-requestStarted
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, recorder.snapshot(), "parser and storage must not stop while a request is in flight")
+
+	close(releaseRequest)
+	assert.Equal(t, http.StatusOK, <-respCode)
+
+	select {
+	case result := <-shutdown:
+		require.NoError(t, result.err)
+		assert.Equal(t, shutdownSignal, result.reason)
+	case <-time.After(5 * time.Second):
+		t.Fatal("graceful shutdown did not complete")
+	}
+	assert.Equal(t, []string{"stop", "close"}, recorder.snapshot())
+}
+
+func TestGracefulShutdown_SignalWithFailedStop(t *testing.T) {
+	logger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	errStop := errors.New("parser did not stop in time")
+
+	mockParser := mock_ethparser.NewParser(t)
+	mockParser.On("Start", mock.Anything).Return(nil)
+	mockParser.On("Stop", mock.Anything).Return(errStop)
+
+	addr := freeLocalAddr(t)
+	apiServer, err := restapi.NewServer(mockParser, logger, &config.ServerConfig{Port: addr})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdown := make(chan shutdownResult, 1)
+	go func() {
+		shutdown <- gracefulShutdown(ctx, logger, mockParser, apiServer, nil,
+			shutdownTimeouts{server: 5 * time.Second, parser: 5 * time.Second})
+	}()
+	waitForListener(t, addr)
+	cancel()
+
+	select {
+	case result := <-shutdown:
+		assert.Equal(t, shutdownSignal, result.reason)
+		assert.ErrorIs(t, result.err, errStop)
+		assert.Equal(t, 1, result.exitCode())
+	case <-time.After(5 * time.Second):
+		t.Fatal("graceful shutdown did not complete")
+	}
+}
+
+func TestGracefulShutdown_ComponentError(t *testing.T) {
+	logger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	recorder := &shutdownRecorder{}
+
+	mockParser := mock_ethparser.NewParser(t)
+	mockParser.On("Start", mock.Anything).Return(nil).Maybe()
+	mockParser.On("Stop", mock.Anything).
+		Run(func(mock.Arguments) { recorder.record("stop") }).
+		Return(nil)
+
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = occupied.Close() }()
+	apiServer, err := restapi.NewServer(mockParser, logger, &config.ServerConfig{Port: occupied.Addr().String()})
+	require.NoError(t, err)
+
+	shutdown := make(chan shutdownResult, 1)
+	go func() {
+		shutdown <- gracefulShutdown(context.Background(), logger, mockParser, apiServer,
+			[]io.Closer{recordingCloser{recorder}}, shutdownTimeouts{server: 5 * time.Second, parser: 5 * time.Second})
+	}()
+
+	select {
+	case result := <-shutdown:
+		assert.Equal(t, shutdownError, result.reason)
+		require.Error(t, result.err)
+		assert.Contains(t, result.err.Error(), "http server critical error")
+		assert.Equal(t, 1, result.exitCode())
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown after a component error did not complete")
+	}
+	assert.Equal(t, []string{"stop", "close"}, recorder.snapshot(),
+		"a failed component still stops the parser and storage")
+}
+
+func TestShutdownResult_ExitCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		result shutdownResult
+		want   int
+	}{
+		{name: "Completed command", result: shutdownResult{reason: shutdownCompleted}, want: 0},
+		{name: "Clean signal shutdown", result: shutdownResult{reason: shutdownSignal}, want: 0},
+		{
+			name:   "Signal shutdown with cleanup failure",
+			result: shutdownResult{reason: shutdownSignal, err: errors.New("close")},
+			want:   1,
+		},
+		{name: "Component error", result: shutdownResult{reason: shutdownError, err: errors.New("crash")}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.result.exitCode())
+		})
+	}
+}
+
+// freeLocalAddr returns a loopback address with a currently unused port.
+func freeLocalAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+	return addr
+}
+
+// waitForListener blocks until the address accepts TCP connections.
+func waitForListener(t *testing.T, addr string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestNewHTTPClient_ConfiguredProxy(t *testing.T) {
+	client, err := newHTTPClient(config.ETHClientConfig{
+		ClientTimeoutSeconds: 7,
+		HTTPProxyURL:         "http://proxy.example.com:3128",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7*time.Second, client.Timeout)
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.NotNil(t, transport.Proxy)
+
+	req, err := http.NewRequest(http.MethodPost, "https://node.example.com", http.NoBody)
+	require.NoError(t, err)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	require.NotNil(t, proxyURL)
+	assert.Equal(t, "http://proxy.example.com:3128", proxyURL.String())
+}
+
+func TestNewHTTPClient_EnvironmentProxy(t *testing.T) {
+	client, err := newHTTPClient(config.ETHClientConfig{ClientTimeoutSeconds: 7})
+	require.NoError(t, err)
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.NotNil(t, transport.Proxy, "without a configured proxy the environment settings apply")
+}
+
+func TestNewHTTPClient_InvalidProxy(t *testing.T) {
+	_, err := newHTTPClient(config.ETHClientConfig{HTTPProxyURL: "http://[::1"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,217 @@
+// Package cache provides caching decorators for outbound clients.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/client"
+)
+
+// CachingClient decorates a client.EthereumClient with an LRU cache of fetched blocks, keyed by block number.
+// Head lookups are always forwarded to the inner client.
+// Cached blocks are never refreshed, so with a confirmation depth only blocks deep enough below the chain head
+// not to be reorganized are cached (see WithConfirmationDepth).
+type CachingClient struct {
+	inner    client.EthereumClient
+	capacity int
+	depth    int64
+	// head is the last chain head returned by GetLatestBlockNumber or GetBlockNumberByTag,
+	// or -1 before the first one.
+	head atomic.Int64
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[int64]*list.Element
+}
+
+// cacheEntry is a single cached block stored in the LRU list.
+type cacheEntry struct {
+	blockNumber int64
+	block       *domain.Block
+}
+
+// Compile-time check to ensure CachingClient implements client.EthereumClient and client.BlockStreamer
+var (
+	_ client.EthereumClient = (*CachingClient)(nil)
+	_ client.BlockStreamer  = (*CachingClient)(nil)
+)
+
+// Option configures optional behavior of the CachingClient.
+type Option func(*CachingClient)
+
+// WithConfirmationDepth caches only blocks at least depth blocks below the last seen chain head, so blocks that
+// may still be reorganized, such as those re-scanned to heal reorgs, are always fetched from the node again.
+// Blocks are not cached until a head, latest or tagged, was looked up once. A non-positive depth caches every
+// fetched block.
+func WithConfirmationDepth(depth int64) Option {
+	return func(c *CachingClient) {
+		c.depth = max(depth, 0)
+	}
+}
+
+// NewCachingClient creates a caching decorator holding at most size blocks.
+// A non-positive size disables caching and the inner client is returned unchanged.
+func NewCachingClient(inner client.EthereumClient, size int, opts ...Option) client.EthereumClient {
+	if size <= 0 {
+		return inner
+	}
+	c := &CachingClient{
+		inner:    inner,
+		capacity: size,
+		order:    list.New(),
+		entries:  make(map[int64]*list.Element, size),
+	}
+	c.head.Store(-1)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetChainID forwards to the inner client.
+func (c *CachingClient) GetChainID(ctx context.Context) (int64, error) {
+	return c.inner.GetChainID(ctx)
+}
+
+// GetLatestBlockNumber forwards to the inner client; the chain head is never cached, only remembered to
+// apply the confirmation depth.
+func (c *CachingClient) GetLatestBlockNumber(ctx context.Context) (domain.BlockNumber, error) {
+	head, err := c.inner.GetLatestBlockNumber(ctx)
+	if err == nil {
+		c.head.Store(head.Value())
+	}
+	return head, err
+}
+
+// GetBlockNumberByTag forwards to the inner client; tagged heads are never cached, only remembered as the chain
+// head: a safe or finalized head is below the latest one, so blocks deep enough below it are deep enough below
+// the latest head too.
+func (c *CachingClient) GetBlockNumberByTag(ctx context.Context, tag string) (domain.BlockNumber, error) {
+	head, err := c.inner.GetBlockNumberByTag(ctx, tag)
+	if err == nil {
+		c.head.Store(head.Value())
+	}
+	return head, err
+}
+
+// GetBlockWithTransactions returns the cached block if present, otherwise fetches it from the inner client.
+// Null results (blocks not yet available at the chain tip), errors and blocks within the confirmation depth
+// are not cached.
+func (c *CachingClient) GetBlockWithTransactions(
+	ctx context.Context,
+	blockNumber domain.BlockNumber,
+) (*domain.Block, error) {
+	if block, ok := c.get(blockNumber.Value()); ok {
+		return block, nil
+	}
+
+	block, err := c.inner.GetBlockWithTransactions(ctx, blockNumber)
+	if err != nil || block == nil {
+		return block, err
+	}
+
+	if c.confirmed(blockNumber.Value()) {
+		c.put(blockNumber.Value(), block)
+	}
+	return block, nil
+}
+
+// confirmed reports whether a block is deep enough below the last seen chain head to be cached.
+func (c *CachingClient) confirmed(blockNumber int64) bool {
+	if c.depth == 0 {
+		return true
+	}
+	head := c.head.Load()
+	return head >= 0 && head-blockNumber >= c.depth
+}
+
+// StreamBlockTransactions passes the transactions of a cached block to fn. A block that is not cached is
+// streamed from the inner client without being cached, as holding it whole is what streaming avoids;
+// if the inner client cannot stream, the block is fetched and cached as in GetBlockWithTransactions.
+func (c *CachingClient) StreamBlockTransactions(
+	ctx context.Context,
+	blockNumber domain.BlockNumber,
+	fn func(domain.Transaction) error,
+) (*domain.Block, error) {
+	block, ok := c.get(blockNumber.Value())
+	if !ok {
+		if streamer, canStream := c.inner.(client.BlockStreamer); canStream {
+			return streamer.StreamBlockTransactions(ctx, blockNumber, fn)
+		}
+		var err error
+		if block, err = c.GetBlockWithTransactions(ctx, blockNumber); err != nil || block == nil {
+			return block, err
+		}
+	}
+
+	for _, tx := range block.Transactions {
+		if err := fn(tx); err != nil {
+			return nil, err
+		}
+	}
+	header := *block
+	header.Transactions = nil
+	return &header, nil
+}
+
+// GetBlockByHash forwards to the inner client; blocks are cached by number only.
+func (c *CachingClient) GetBlockByHash(ctx context.Context, hash domain.BlockHash) (*domain.Block, error) {
+	return c.inner.GetBlockByHash(ctx, hash)
+}
+
+// GetBlockByTag forwards to the inner client; the block a tag points to changes, so it is never cached.
+func (c *CachingClient) GetBlockByTag(ctx context.Context, tag string, full bool) (*domain.Block, error) {
+	return c.inner.GetBlockByTag(ctx, tag, full)
+}
+
+// GetTransactionByHash forwards to the inner client; transactions are not cached.
+func (c *CachingClient) GetTransactionByHash(
+	ctx context.Context,
+	hash domain.TransactionHash,
+) (*domain.Transaction, error) {
+	return c.inner.GetTransactionByHash(ctx, hash)
+}
+
+// GetTransactionStatuses forwards to the inner client; receipts are not cached.
+func (c *CachingClient) GetTransactionStatuses(
+	ctx context.Context,
+	hashes []domain.TransactionHash,
+) (map[domain.TransactionHash]domain.TransactionStatus, error) {
+	return c.inner.GetTransactionStatuses(ctx, hashes)
+}
+
+// get looks up a block and marks it as most recently used.
+func (c *CachingClient) get(blockNumber int64) (*domain.Block, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[blockNumber]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).block, true
+}
+
+// put stores a block, evicting the least recently used entry when the cache is full.
+func (c *CachingClient) put(blockNumber int64, block *domain.Block) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[blockNumber]; ok {
+		elem.Value.(*cacheEntry).block = block
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[blockNumber] = c.order.PushFront(&cacheEntry{blockNumber: blockNumber, block: block})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).blockNumber)
+	}
+}
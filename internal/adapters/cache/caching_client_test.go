@@ -0,0 +1,213 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/cache"
+	"trust_wallet_homework/internal/adapters/cache/mocks/mock_client"
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBlock(t *testing.T, number int64) (domain.BlockNumber, *domain.Block) {
+	t.Helper()
+	blockNum, err := domain.NewBlockNumber(number)
+	require.NoError(t, err)
+	hash, err := domain.NewBlockHash("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	block := domain.NewBlock(blockNum, hash, 1700000000, nil)
+	return blockNum, &block
+}
+
+func TestCachingClient_CacheHitAvoidsSecondCall(t *testing.T) {
+	inner := mock_client.NewEthereumClient(t)
+	blockNum, block := newTestBlock(t, 10)
+	inner.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(block, nil).Once()
+
+	client := cache.NewCachingClient(inner, 4)
+
+	first, err := client.GetBlockWithTransactions(context.Background(), blockNum)
+	require.NoError(t, err)
+	second, err := client.GetBlockWithTransactions(context.Background(), blockNum)
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	inner.AssertNumberOfCalls(t, "GetBlockWithTransactions", 1)
+}
+
+func TestCachingClient_DoesNotCacheNullOrFailedResults(t *testing.T) {
+	inner := mock_client.NewEthereumClient(t)
+	tipNum, tipBlock := newTestBlock(t, 20)
+	failedNum, _ := newTestBlock(t, 21)
+	inner.On("GetBlockWithTransactions", mock.Anything, tipNum).Return(nil, nil).Once()
+	inner.On("GetBlockWithTransactions", mock.Anything, tipNum).Return(tipBlock, nil).Once()
+	inner.On("GetBlockWithTransactions", mock.Anything, failedNum).Return(nil, errors.New("node down")).Twice()
+
+	client := cache.NewCachingClient(inner, 4)
+
+	block, err := client.GetBlockWithTransactions(context.Background(), tipNum)
+	require.NoError(t, err)
+	assert.Nil(t, block)
+	block, err = client.GetBlockWithTransactions(context.Background(), tipNum)
+	require.NoError(t, err)
+	assert.Equal(t, tipBlock, block)
+
+	_, err = client.GetBlockWithTransactions(context.Background(), failedNum)
+	assert.Error(t, err)
+	_, err = client.GetBlockWithTransactions(context.Background(), failedNum)
+	assert.Error(t, err)
+}
+
+func TestCachingClient_EvictsLeastRecentlyUsed(t *testing.T) {
+	inner := mock_client.NewEthereumClient(t)
+	num1, block1 := newTestBlock(t, 1)
+	num2, block2 := newTestBlock(t, 2)
+	num3, block3 := newTestBlock(t, 3)
+	inner.On("GetBlockWithTransactions", mock.Anything, num1).Return(block1, nil).Once()
+	inner.On("GetBlockWithTransactions", mock.Anything, num2).Return(block2, nil).Twice()
+	inner.On("GetBlockWithTransactions", mock.Anything, num3).Return(block3, nil).Once()
+
+	client := cache.NewCachingClient(inner, 2)
+	ctx := context.Background()
+
+	for _, n := range []domain.BlockNumber{num1, num2, num1, num3, num1, num2} {
+		_, err := client.GetBlockWithTransactions(ctx, n)
+		require.NoError(t, err)
+	}
+}
+
+func TestCachingClient_ForwardsHeadLookups(t *testing.T) {
+	inner := mock_client.NewEthereumClient(t)
+	latest, _ := newTestBlock(t, 100)
+	finalized, _ := newTestBlock(t, 90)
+	inner.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil).Twice()
+	inner.On("GetBlockNumberByTag", mock.Anything, "finalized").Return(finalized, nil).Once()
+
+	client := cache.NewCachingClient(inner, 4)
+
+	for i := 0; i < 2; i++ {
+		got, err := client.GetLatestBlockNumber(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), got.Value())
+	}
+	got, err := client.GetBlockNumberByTag(context.Background(), "finalized")
+	require.NoError(t, err)
+	assert.Equal(t, int64(90), got.Value())
+}
+
+func TestCachingClient_ForwardsBlockByHash(t *testing.T) {
+	inner := mock_client.NewEthereumClient(t)
+	_, block := newTestBlock(t, 10)
+	inner.On("GetBlockByHash", mock.Anything, block.Hash).Return(block, nil).Twice()
+
+	client := cache.NewCachingClient(inner, 4)
+
+	for i := 0; i < 2; i++ {
+		got, err := client.GetBlockByHash(context.Background(), block.Hash)
+		require.NoError(t, err)
+		assert.Equal(t, block, got)
+	}
+}
+
+func TestNewCachingClient_DisabledReturnsInner(t *testing.T) {
+	inner := mock_client.NewEthereumClient(t)
+	assert.Same(t, inner, cache.NewCachingClient(inner, 0))
+}
+
+func TestCachingClient_StreamBlockTransactions_FallsBackToCachedFetch(t *testing.T) {
+	inner := mock_client.NewEthereumClient(t)
+	blockNum, block := newTestBlock(t, 10)
+	hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	block.Transactions = []domain.Transaction{{Hash: hash, BlockNumber: blockNum}}
+	block.BaseFeePerGas, err = domain.NewWeiValue("0x2540be400")
+	require.NoError(t, err)
+	inner.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(block, nil).Once()
+
+	client, ok := cache.NewCachingClient(inner, 4).(*cache.CachingClient)
+	require.True(t, ok)
+
+	for range 2 {
+		var streamed []domain.Transaction
+		header, err := client.StreamBlockTransactions(context.Background(), blockNum, func(tx domain.Transaction) error {
+			streamed = append(streamed, tx)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, block.Transactions, streamed)
+		assert.Equal(t, block.Hash, header.Hash)
+		assert.Equal(t, block.BaseFeePerGas, header.BaseFeePerGas)
+		assert.Empty(t, header.Transactions)
+	}
+	assert.Len(t, block.Transactions, 1, "the cached block must not be modified")
+	inner.AssertNumberOfCalls(t, "GetBlockWithTransactions", 1)
+}
+
+func TestCachingClient_ConfirmationDepth(t *testing.T) {
+	ctx := context.Background()
+	inner := mock_client.NewEthereumClient(t)
+	headNum, _ := newTestBlock(t, 10)
+	tipNum, preReorg := newTestBlock(t, 9)
+	reorgHash, err := domain.NewBlockHash("0x0000000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(t, err)
+	postReorg := domain.NewBlock(tipNum, reorgHash, preReorg.Timestamp, nil)
+	deepNum, deepBlock := newTestBlock(t, 8)
+
+	inner.On("GetLatestBlockNumber", mock.Anything).Return(headNum, nil).Once()
+	inner.On("GetBlockWithTransactions", mock.Anything, tipNum).Return(preReorg, nil).Once()
+	inner.On("GetBlockWithTransactions", mock.Anything, tipNum).Return(&postReorg, nil).Once()
+	inner.On("GetBlockWithTransactions", mock.Anything, deepNum).Return(deepBlock, nil).Twice()
+
+	client := cache.NewCachingClient(inner, 4, cache.WithConfirmationDepth(2))
+
+	// Without a known head no block is cached.
+	_, err = client.GetBlockWithTransactions(ctx, deepNum)
+	require.NoError(t, err)
+
+	_, err = client.GetLatestBlockNumber(ctx)
+	require.NoError(t, err)
+
+	first, err := client.GetBlockWithTransactions(ctx, tipNum)
+	require.NoError(t, err)
+	assert.Equal(t, preReorg.Hash, first.Hash)
+	second, err := client.GetBlockWithTransactions(ctx, tipNum)
+	require.NoError(t, err)
+	assert.Equal(t, reorgHash, second.Hash, "a block within the confirmation depth must be fetched again")
+
+	for range 2 {
+		block, err := client.GetBlockWithTransactions(ctx, deepNum)
+		require.NoError(t, err)
+		assert.Same(t, deepBlock, block)
+	}
+	inner.AssertNumberOfCalls(t, "GetBlockWithTransactions", 4)
+}
+
+func TestCachingClient_ConfirmationDepthWithTaggedHead(t *testing.T) {
+	ctx := context.Background()
+	inner := mock_client.NewEthereumClient(t)
+	finalizedNum, _ := newTestBlock(t, 10)
+	tipNum, tipBlock := newTestBlock(t, 9)
+	deepNum, deepBlock := newTestBlock(t, 8)
+
+	inner.On("GetBlockNumberByTag", mock.Anything, "finalized").Return(finalizedNum, nil).Once()
+	inner.On("GetBlockWithTransactions", mock.Anything, tipNum).Return(tipBlock, nil).Twice()
+	inner.On("GetBlockWithTransactions", mock.Anything, deepNum).Return(deepBlock, nil).Once()
+
+	client := cache.NewCachingClient(inner, 4, cache.WithConfirmationDepth(2))
+
+	_, err := client.GetBlockNumberByTag(ctx, "finalized")
+	require.NoError(t, err)
+	for range 2 {
+		_, err = client.GetBlockWithTransactions(ctx, tipNum)
+		require.NoError(t, err)
+		block, err := client.GetBlockWithTransactions(ctx, deepNum)
+		require.NoError(t, err)
+		assert.Same(t, deepBlock, block, "a block deep enough below a tagged head is cached")
+	}
+	inner.AssertNumberOfCalls(t, "GetBlockWithTransactions", 3)
+}
@@ -0,0 +1,70 @@
+package restapi
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// corsMaxAgeSeconds is how long browsers may cache a preflight response.
+const corsMaxAgeSeconds = 600
+
+// corsPolicy lists the origins, methods and request headers allowed for cross-origin requests.
+// The origin "*" allows every origin.
+type corsPolicy struct {
+	origins []string
+	methods []string
+	headers []string
+}
+
+// allowsOrigin reports whether cross-origin requests from the origin are allowed.
+func (p corsPolicy) allowsOrigin(origin string) bool {
+	return slices.Contains(p.origins, "*") || slices.Contains(p.origins, origin)
+}
+
+// allowsMethod reports whether the method may be used in cross-origin requests.
+func (p corsPolicy) allowsMethod(method string) bool {
+	return slices.ContainsFunc(p.methods, func(m string) bool { return strings.EqualFold(m, method) })
+}
+
+// withCORS adds CORS headers for allowed origins and answers preflight requests with 204 No Content.
+// Requests from other origins are served without CORS headers, so browsers block them.
+func withCORS(next http.Handler, policy corsPolicy) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+
+		requestedMethod := r.Header.Get("Access-Control-Request-Method")
+		preflight := r.Method == http.MethodOptions && requestedMethod != ""
+		if !policy.allowsOrigin(origin) || (preflight && !policy.allowsMethod(requestedMethod)) {
+			if preflight {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if slices.Contains(policy.origins, "*") {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if !preflight {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(policy.methods, ", "))
+		if len(policy.headers) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(policy.headers, ", "))
+		}
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAgeSeconds))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,101 @@
+package restapi
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/restapi/mocks/mock_ethparser"
+	"trust_wallet_homework/internal/config"
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const dashboardOrigin = "https://dashboard.example.com"
+
+func newCORSTestRouter(t *testing.T, origins []string) (http.Handler, *mock_ethparser.Parser) {
+	t.Helper()
+	mockParser := mock_ethparser.NewParser(t)
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	h, err := NewHTTPHandler(mockParser, discardLogger)
+	require.NoError(t, err)
+	return setupRouter(h, &config.ServerConfig{
+		CORSAllowedOrigins: origins,
+		CORSAllowedMethods: []string{"GET", "POST"},
+		CORSAllowedHeaders: []string{"Content-Type"},
+	}), mockParser
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	tests := []struct {
+		name            string
+		origins         []string
+		origin          string
+		method          string
+		wantAllowOrigin string
+	}{
+		{name: "Allowed origin", origins: []string{dashboardOrigin}, origin: dashboardOrigin, method: "POST", wantAllowOrigin: dashboardOrigin},
+		{name: "Wildcard origin", origins: []string{"*"}, origin: dashboardOrigin, method: "POST", wantAllowOrigin: "*"},
+		{name: "Other origin", origins: []string{dashboardOrigin}, origin: "https://evil.example.com", method: "POST"},
+		{name: "Disallowed method", origins: []string{dashboardOrigin}, origin: dashboardOrigin, method: "DELETE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := newCORSTestRouter(t, tt.origins)
+
+			req := httptest.NewRequest(http.MethodOptions, "/subscribe", http.NoBody)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			req.Header.Set("Access-Control-Request-Headers", "Content-Type")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Empty(t, rec.Body.String())
+			assert.Equal(t, tt.wantAllowOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			if tt.wantAllowOrigin == "" {
+				assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+				return
+			}
+			assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, "Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+			assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+		})
+	}
+}
+
+func TestCORS_SimpleCrossOriginGet(t *testing.T) {
+	tests := []struct {
+		name            string
+		origins         []string
+		origin          string
+		wantAllowOrigin string
+	}{
+		{name: "Allowed origin", origins: []string{dashboardOrigin}, origin: dashboardOrigin, wantAllowOrigin: dashboardOrigin},
+		{name: "Other origin is served without CORS headers", origins: []string{dashboardOrigin}, origin: "https://evil.example.com"},
+		{name: "CORS disabled by default", origins: nil, origin: dashboardOrigin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockParser := newCORSTestRouter(t, tt.origins)
+			mockParser.On("GetCurrentBlock", mock.Anything).Return(int64(42), nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/current_block", http.NoBody)
+			req.Header.Set("Origin", tt.origin)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, `{"current_block":42}`, rec.Body.String())
+			assert.Equal(t, tt.wantAllowOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"), "methods are only sent on preflight")
+		})
+	}
+}
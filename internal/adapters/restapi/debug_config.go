@@ -0,0 +1,41 @@
+package restapi
+
+import (
+	"net/http"
+
+	"trust_wallet_homework/internal/config"
+)
+
+// WithDebugConfig exposes the loaded configuration, with secrets redacted, on GET /debug/config.
+func WithDebugConfig(cfg config.Config) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.debugConfig = &cfg
+	}
+}
+
+// HandleDebugConfig handles requests to GET /debug/config
+// It responds with the effective configuration, with node URL credentials, API keys and other secrets redacted.
+// It responds with 404 Not Found when the handler was not given the configuration.
+func (h *HTTPHandler) HandleDebugConfig(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for DebugConfig")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	if h.debugConfig == nil {
+		respondWithError(w, http.StatusNotFound, "Configuration is not available", requestLogger)
+		return
+	}
+
+	dump, err := h.debugConfig.Dump()
+	if err != nil {
+		requestLogger.Error("Error dumping configuration", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to dump configuration", requestLogger)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dump, requestLogger)
+}
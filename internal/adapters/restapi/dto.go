@@ -1,13 +1,27 @@
 // Package restapi implements the RESTful API layer, including DTOs and handlers.
 package restapi
 
+import "trust_wallet_homework/pkg/ethparser"
+
 // SubscribeRequest defines the expected JSON body for the POST /subscribe endpoint.
+// Either a single address or a list of addresses may be provided.
+// Direction optionally restricts indexing to "in" or "out" transactions; it defaults to "both".
 type SubscribeRequest struct {
-	Address string `json:"address"`
+	Address   string   `json:"address" validate:"required_without=Addresses,omitempty,eth_addr"`
+	Addresses []string `json:"addresses,omitempty"`
+	Direction string   `json:"direction,omitempty" validate:"omitempty,oneof=in out both"`
 }
 
 // ErrorResponse defines a standard structure for JSON error responses.
+// Fields lists the offending request fields when the request body failed validation.
 type ErrorResponse struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes why a single request field failed validation.
+type FieldError struct {
+	Field string `json:"field"`
 	Error string `json:"error"`
 }
 
@@ -16,8 +30,110 @@ type GetCurrentBlockResponse struct {
 	BlockNumber int64 `json:"current_block"`
 }
 
+// LagResponse defines the structure for the GET /lag endpoint.
+// Error is set when the node is unreachable; HeadBlock then holds the last known network head.
+type LagResponse struct {
+	CurrentBlock int64  `json:"current_block"`
+	HeadBlock    int64  `json:"head_block"`
+	Lag          int64  `json:"lag"`
+	Error        string `json:"error,omitempty"`
+}
+
+// ReadinessResponse defines the structure for the GET /readyz endpoint.
+// Error is set when the storage cannot be written.
+type ReadinessResponse struct {
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
+
 // SubscribeResponse defines the structure for the POST /subscribe endpoint response (on success).
 type SubscribeResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message,omitempty"`
 }
+
+// SubscribeManyResponse defines the structure for the POST /subscribe endpoint response for bulk requests.
+type SubscribeManyResponse struct {
+	Success bool                        `json:"success"`
+	Results []ethparser.SubscribeResult `json:"results"`
+}
+
+// TransactionsEnvelope defines the enveloped structure for the GET /transactions/{address} endpoint.
+// FromBlock and ToBlock echo the block range query parameters, if given.
+type TransactionsEnvelope struct {
+	Address      string                  `json:"address"`
+	Count        int                     `json:"count"`
+	FromBlock    *int64                  `json:"fromBlock,omitempty"`
+	ToBlock      *int64                  `json:"toBlock,omitempty"`
+	Transactions []ethparser.Transaction `json:"transactions"`
+}
+
+// TransactionsBatchRequest defines the expected JSON body for the POST /transactions/batch endpoint.
+type TransactionsBatchRequest struct {
+	Addresses []string `json:"addresses" validate:"required,min=1,dive,eth_addr"`
+}
+
+// TransactionsBatchResponse defines the structure for the POST /transactions/batch endpoint response,
+// mapping each requested address to its transactions.
+type TransactionsBatchResponse struct {
+	Transactions map[string][]ethparser.Transaction `json:"transactions"`
+}
+
+// TransactionCountResponse defines the structure for the GET /transactions/{address}/count endpoint.
+type TransactionCountResponse struct {
+	Count int `json:"count"`
+}
+
+// LastActivityResponse defines the structure for the GET /subscriptions/{address}/last_activity endpoint.
+// BlockNumber and Timestamp are null while no transaction is stored for the address.
+type LastActivityResponse struct {
+	Address     string  `json:"address"`
+	BlockNumber *int64  `json:"block_number"`
+	Timestamp   *uint64 `json:"timestamp"`
+}
+
+// TriggerScanResponse defines the structure for the POST /admin/scan endpoint response (on success).
+// CurrentBlock is the last processed block after the scan.
+type TriggerScanResponse struct {
+	Success      bool  `json:"success"`
+	CurrentBlock int64 `json:"current_block"`
+}
+
+// AddressesResponse defines the structure for the GET /addresses endpoint.
+type AddressesResponse struct {
+	Addresses []string `json:"addresses"`
+}
+
+// SubscriptionsResponse defines the structure for the GET /subscriptions endpoint.
+// Total is the number of subscribed addresses across all pages.
+type SubscriptionsResponse struct {
+	Addresses []string `json:"addresses"`
+	Total     int      `json:"total"`
+	Limit     int      `json:"limit"`
+	Offset    int      `json:"offset"`
+}
+
+// RewindRequest defines the expected JSON body for the POST /admin/rewind endpoint.
+type RewindRequest struct {
+	Block *int64 `json:"block"`
+}
+
+// ReplayRequest defines the expected JSON body for the POST /admin/replay endpoint.
+// Since defaults to 0, which replays the whole stored history of the address.
+type ReplayRequest struct {
+	Address string `json:"address"`
+	Since   int64  `json:"since"`
+}
+
+// ReplayResponse defines the structure for the POST /admin/replay endpoint response (on success).
+type ReplayResponse struct {
+	Success bool   `json:"success"`
+	Address string `json:"address"`
+	Since   int64  `json:"since"`
+}
+
+// RewindResponse defines the structure for the POST /admin/rewind endpoint response (on success).
+type RewindResponse struct {
+	Success bool  `json:"success"`
+	Block   int64 `json:"block"`
+}
@@ -0,0 +1,125 @@
+package restapi
+
+import "trust_wallet_homework/pkg/ethparser"
+
+// Version 2 of the API uses snake_case for every JSON field. Responses whose v1 shape already is
+// snake_case (current block, lag, subscribe, subscriptions, count, last activity, addresses, rewind, scan,
+// replay, errors) are shared between both versions.
+
+// TransactionV2 is the v2 representation of ethparser.Transaction.
+type TransactionV2 struct {
+	Hash        string  `json:"hash"`
+	From        string  `json:"from"`
+	To          *string `json:"to"`
+	Value       string  `json:"value"`
+	BlockNumber int64   `json:"block_number"`
+	Timestamp   uint64  `json:"timestamp"`
+	// TransactionIndex is the position of the transaction within its block.
+	TransactionIndex uint64 `json:"transaction_index"`
+	Direction        string `json:"direction,omitempty"`
+	Status           *int   `json:"status,omitempty"`
+}
+
+// BlockV2 is the v2 representation of ethparser.Block.
+type BlockV2 struct {
+	Number           int64           `json:"number"`
+	Hash             string          `json:"hash"`
+	Timestamp        uint64          `json:"timestamp"`
+	TransactionCount int             `json:"transaction_count"`
+	Transactions     []TransactionV2 `json:"transactions"`
+}
+
+// StatsV2 is the v2 representation of ethparser.ParserStats.
+type StatsV2 struct {
+	LastScannedBlock    int64 `json:"last_scanned_block"`
+	NetworkHead         int64 `json:"network_head"`
+	LagBlocks           int64 `json:"lag_blocks"`
+	SubscribedAddresses int   `json:"subscribed_addresses"`
+	TransactionsStored  int   `json:"transactions_stored"`
+	DeadLetters         int   `json:"dead_letters"`
+	SkippedScans        int64 `json:"skipped_scans"`
+	UptimeSeconds       int64 `json:"uptime_seconds"`
+}
+
+// TransactionsEnvelopeV2 is the v2 representation of TransactionsEnvelope.
+type TransactionsEnvelopeV2 struct {
+	Address      string          `json:"address"`
+	Count        int             `json:"count"`
+	FromBlock    *int64          `json:"from_block,omitempty"`
+	ToBlock      *int64          `json:"to_block,omitempty"`
+	Transactions []TransactionV2 `json:"transactions"`
+}
+
+// TransactionsBatchResponseV2 is the v2 representation of TransactionsBatchResponse.
+type TransactionsBatchResponseV2 struct {
+	Transactions map[string][]TransactionV2 `json:"transactions"`
+}
+
+// toTransactionV2 converts a transaction to its v2 representation.
+func toTransactionV2(tx ethparser.Transaction) TransactionV2 {
+	return TransactionV2{
+		Hash:             tx.Hash,
+		From:             tx.From,
+		To:               tx.To,
+		Value:            tx.Value,
+		BlockNumber:      tx.BlockNumber,
+		Timestamp:        tx.Timestamp,
+		TransactionIndex: tx.TransactionIndex,
+		Direction:        tx.Direction,
+		Status:           tx.Status,
+	}
+}
+
+// toTransactionsV2 converts a transaction list to its v2 representation, keeping an empty list non-nil.
+func toTransactionsV2(txs []ethparser.Transaction) []TransactionV2 {
+	out := make([]TransactionV2, len(txs))
+	for i, tx := range txs {
+		out[i] = toTransactionV2(tx)
+	}
+	return out
+}
+
+// toBlockV2 converts a block to its v2 representation.
+func toBlockV2(block ethparser.Block) BlockV2 {
+	return BlockV2{
+		Number:           block.Number,
+		Hash:             block.Hash,
+		Timestamp:        block.Timestamp,
+		TransactionCount: block.TransactionCount,
+		Transactions:     toTransactionsV2(block.Transactions),
+	}
+}
+
+// toStatsV2 converts parser statistics to their v2 representation.
+func toStatsV2(stats ethparser.ParserStats) StatsV2 {
+	return StatsV2{
+		LastScannedBlock:    stats.LastScannedBlock,
+		NetworkHead:         stats.NetworkHead,
+		LagBlocks:           stats.LagBlocks,
+		SubscribedAddresses: stats.SubscribedAddresses,
+		TransactionsStored:  stats.TransactionsStored,
+		DeadLetters:         stats.DeadLetters,
+		SkippedScans:        stats.SkippedScans,
+		UptimeSeconds:       stats.UptimeSeconds,
+	}
+}
+
+// toTransactionsEnvelopeV2 converts a transactions envelope to its v2 representation.
+func toTransactionsEnvelopeV2(envelope TransactionsEnvelope) TransactionsEnvelopeV2 {
+	return TransactionsEnvelopeV2{
+		Address:      envelope.Address,
+		Count:        envelope.Count,
+		FromBlock:    envelope.FromBlock,
+		ToBlock:      envelope.ToBlock,
+		Transactions: toTransactionsV2(envelope.Transactions),
+	}
+}
+
+// toTransactionsBatchResponseV2 converts a batch response to its v2 representation.
+func toTransactionsBatchResponseV2(resp TransactionsBatchResponse) TransactionsBatchResponseV2 {
+	out := make(map[string][]TransactionV2, len(resp.Transactions))
+	for address, txs := range resp.Transactions {
+		out[address] = toTransactionsV2(txs)
+	}
+	return TransactionsBatchResponseV2{Transactions: out}
+}
@@ -0,0 +1,181 @@
+package restapi
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/restapi/mocks/mock_ethparser"
+	"trust_wallet_homework/internal/config"
+	applogger "trust_wallet_homework/internal/logger"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const versionTestAddress = "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+
+func TestAPIVersions_FieldNames(t *testing.T) {
+	status := ethparser.StatusSuccess
+	to := "0x2"
+	tx := ethparser.Transaction{
+		Hash: "0x1", From: versionTestAddress, To: &to, Value: "0x1",
+		BlockNumber: 15, Timestamp: 1000, Direction: ethparser.DirectionOut, Status: &status,
+	}
+	txKeys := func(blockNumberKey, indexKey string) []string {
+		return []string{"hash", "from", "to", "value", blockNumberKey, "timestamp", indexKey, "direction", "status"}
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		accept   string
+		setup    func(p *mock_ethparser.Parser)
+		wantKeys []string
+		// itemKeys, if set, are the expected keys of the first element of the "transactions" field
+		// or of the top-level array.
+		itemKeys []string
+	}{
+		{
+			name:     "v1 stats",
+			path:     "/stats",
+			setup:    func(p *mock_ethparser.Parser) { p.On("Stats", mock.Anything).Return(ethparser.ParserStats{}, nil) },
+			wantKeys: []string{"lastScannedBlock", "networkHead", "lagBlocks", "subscribedAddresses", "transactionsStored", "deadLetters", "skippedScans", "uptimeSeconds"},
+		},
+		{
+			name:     "v2 stats by path",
+			path:     "/v2/stats",
+			setup:    func(p *mock_ethparser.Parser) { p.On("Stats", mock.Anything).Return(ethparser.ParserStats{}, nil) },
+			wantKeys: []string{"last_scanned_block", "network_head", "lag_blocks", "subscribed_addresses", "transactions_stored", "dead_letters", "skipped_scans", "uptime_seconds"},
+		},
+		{
+			name:     "v2 stats by Accept header",
+			path:     "/stats",
+			accept:   v2MediaType,
+			setup:    func(p *mock_ethparser.Parser) { p.On("Stats", mock.Anything).Return(ethparser.ParserStats{}, nil) },
+			wantKeys: []string{"last_scanned_block", "network_head", "lag_blocks", "subscribed_addresses", "transactions_stored", "dead_letters", "skipped_scans", "uptime_seconds"},
+		},
+		{
+			name: "v1 transactions",
+			path: "/transactions/" + versionTestAddress,
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetTransactions", mock.Anything, versionTestAddress).Return([]ethparser.Transaction{tx}, nil)
+			},
+			itemKeys: txKeys("blockNumber", "transactionIndex"),
+		},
+		{
+			name: "v2 transactions",
+			path: "/v2/transactions/" + versionTestAddress,
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetTransactions", mock.Anything, versionTestAddress).Return([]ethparser.Transaction{tx}, nil)
+			},
+			itemKeys: txKeys("block_number", "transaction_index"),
+		},
+		{
+			name: "v1 transactions envelope",
+			path: "/transactions/" + versionTestAddress + "?envelope=true&from_block=1",
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetTransactionsInRange", mock.Anything, versionTestAddress, int64(1), mock.Anything).
+					Return([]ethparser.Transaction{tx}, nil)
+			},
+			wantKeys: []string{"address", "count", "fromBlock", "transactions"},
+			itemKeys: txKeys("blockNumber", "transactionIndex"),
+		},
+		{
+			name: "v2 transactions envelope",
+			path: "/v2/transactions/" + versionTestAddress + "?envelope=true&from_block=1",
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetTransactionsInRange", mock.Anything, versionTestAddress, int64(1), mock.Anything).
+					Return([]ethparser.Transaction{tx}, nil)
+			},
+			wantKeys: []string{"address", "count", "from_block", "transactions"},
+			itemKeys: txKeys("block_number", "transaction_index"),
+		},
+		{
+			name: "v1 block",
+			path: "/block/15",
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetBlock", mock.Anything, int64(15)).
+					Return(&ethparser.Block{Number: 15, TransactionCount: 1, Transactions: []ethparser.Transaction{tx}}, nil)
+			},
+			wantKeys: []string{"number", "hash", "timestamp", "transactionCount", "transactions"},
+			itemKeys: txKeys("blockNumber", "transactionIndex"),
+		},
+		{
+			name: "v2 block",
+			path: "/v2/block/15",
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetBlock", mock.Anything, int64(15)).
+					Return(&ethparser.Block{Number: 15, TransactionCount: 1, Transactions: []ethparser.Transaction{tx}}, nil)
+			},
+			wantKeys: []string{"number", "hash", "timestamp", "transaction_count", "transactions"},
+			itemKeys: txKeys("block_number", "transaction_index"),
+		},
+		{
+			name:     "v2 current block keeps its snake_case shape",
+			path:     "/v2/current_block",
+			setup:    func(p *mock_ethparser.Parser) { p.On("GetCurrentBlock", mock.Anything).Return(int64(7), nil) },
+			wantKeys: []string{"current_block"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockParser := mock_ethparser.NewParser(t)
+			tt.setup(mockParser)
+			discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			h, err := NewHTTPHandler(mockParser, discardLogger)
+			require.NoError(t, err)
+			router := setupRouter(h, &config.ServerConfig{})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+			if tt.wantKeys == nil {
+				var items []map[string]any
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &items))
+				require.Len(t, items, 1)
+				assert.ElementsMatch(t, tt.itemKeys, keysOf(items[0]))
+				return
+			}
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.ElementsMatch(t, tt.wantKeys, keysOf(body))
+			if tt.itemKeys != nil {
+				items, ok := body["transactions"].([]any)
+				require.True(t, ok)
+				require.Len(t, items, 1)
+				assert.ElementsMatch(t, tt.itemKeys, keysOf(items[0].(map[string]any)))
+			}
+		})
+	}
+}
+
+func TestTransactionV2_ContractCreationHasNullRecipient(t *testing.T) {
+	payload, err := json.Marshal(toTransactionV2(ethparser.Transaction{Hash: "0x1", From: versionTestAddress}))
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(payload, &fields))
+	require.Contains(t, fields, "to")
+	assert.Nil(t, fields["to"])
+}
+
+func keysOf(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
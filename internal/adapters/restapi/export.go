@@ -0,0 +1,160 @@
+package restapi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// Supported formats of GET /export.
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+// exportCSVHeader lists the CSV columns written by GET /export.
+var exportCSVHeader = []string{"hash", "from", "to", "value", "blockNumber", "timestamp", "direction", "status"}
+
+// exportWriter encodes exported transactions one at a time.
+type exportWriter interface {
+	// begin writes anything that precedes the first transaction, such as a CSV header row.
+	begin() error
+	write(tx ethparser.Transaction) error
+	// finish flushes buffered output.
+	finish() error
+}
+
+// HandleExport handles requests to GET /export?format=csv|ndjson
+// The optional address query parameter restricts the export to the transactions of a monitored address.
+// Transactions are streamed as they are read from storage, so large exports are not buffered in memory.
+func (h *HTTPHandler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for Export")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	contentType, newWriter, err := exportEncoding(format)
+	if err != nil {
+		requestLogger.Warn("Invalid format query parameter in Export", "format", format)
+		respondWithError(w, http.StatusBadRequest, err.Error(), requestLogger)
+		return
+	}
+
+	address := canonicalAddress(r.URL.Query().Get("address"))
+	requestLogger = requestLogger.With("address_param", address, "format", format)
+
+	enc := newWriter(w)
+	started := false
+	start := func() error {
+		started = true
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			requestLogger.Debug("Could not clear write deadline for export", "error", err)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="transactions.%s"`, format))
+		w.WriteHeader(http.StatusOK)
+		return enc.begin()
+	}
+
+	exported := 0
+	err = h.parserService.ExportTransactions(r.Context(), address, func(tx ethparser.Transaction) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		exported++
+		return enc.write(tx)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = enc.finish()
+	}
+
+	switch {
+	case err == nil:
+		requestLogger.Info("Transactions exported", "count", exported)
+	case started:
+		requestLogger.Warn("Export aborted after the response was started", "exported", exported, "error", err)
+	default:
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("Export rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error exporting transactions", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to export transactions", requestLogger)
+		}
+	}
+}
+
+// exportEncoding returns the content type and writer constructor for an export format.
+func exportEncoding(format string) (string, func(io.Writer) exportWriter, error) {
+	switch format {
+	case exportFormatCSV:
+		return "text/csv", func(w io.Writer) exportWriter { return &csvExportWriter{w: csv.NewWriter(w)} }, nil
+	case exportFormatNDJSON:
+		return "application/x-ndjson", func(w io.Writer) exportWriter { return &ndjsonExportWriter{enc: json.NewEncoder(w)} }, nil
+	default:
+		return "", nil, errors.New("format must be 'csv' or 'ndjson'")
+	}
+}
+
+// csvExportWriter writes transactions as CSV rows under exportCSVHeader.
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvExportWriter) begin() error {
+	return c.w.Write(exportCSVHeader)
+}
+
+func (c *csvExportWriter) write(tx ethparser.Transaction) error {
+	var to, status string
+	if tx.To != nil {
+		to = *tx.To
+	}
+	if tx.Status != nil {
+		status = strconv.Itoa(*tx.Status)
+	}
+	return c.w.Write([]string{
+		tx.Hash,
+		tx.From,
+		to,
+		tx.Value,
+		strconv.FormatInt(tx.BlockNumber, 10),
+		strconv.FormatUint(tx.Timestamp, 10),
+		tx.Direction,
+		status,
+	})
+}
+
+func (c *csvExportWriter) finish() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ndjsonExportWriter writes each transaction as one JSON object per line.
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonExportWriter) begin() error { return nil }
+
+func (n *ndjsonExportWriter) write(tx ethparser.Transaction) error {
+	return n.enc.Encode(tx)
+}
+
+func (n *ndjsonExportWriter) finish() error { return nil }
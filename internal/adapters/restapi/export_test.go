@@ -0,0 +1,160 @@
+package restapi_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// exportTestTransactions returns a regular transfer and a contract creation with a receipt status.
+func exportTestTransactions() []ethparser.Transaction {
+	to := "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	failed := ethparser.StatusFailed
+	return []ethparser.Transaction{
+		{
+			Hash:        "0x1111111111111111111111111111111111111111111111111111111111111111",
+			From:        testAddress,
+			To:          &to,
+			Value:       "0xde0b6b3a7640000",
+			BlockNumber: 100,
+			Timestamp:   1700000000,
+			Direction:   ethparser.DirectionOut,
+		},
+		{
+			Hash:        "0x2222222222222222222222222222222222222222222222222222222222222222",
+			From:        testAddress,
+			Value:       "0x0",
+			BlockNumber: 101,
+			Timestamp:   1700000012,
+			Direction:   ethparser.DirectionOut,
+			Status:      &failed,
+		},
+	}
+}
+
+// mockExport makes the mock parser pass txs to the export callback.
+func mockExport(mockParser *mock.Mock, address string, txs []ethparser.Transaction, err error) {
+	mockParser.On("ExportTransactions", mock.Anything, address, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(ethparser.Transaction) error)
+			for _, tx := range txs {
+				if fnErr := fn(tx); fnErr != nil {
+					return
+				}
+			}
+		}).
+		Return(err)
+}
+
+func TestHTTPHandler_HandleExport_CSV(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockExport(&mockParser.Mock, testAddress, exportTestTransactions(), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/export?format=csv&address=0x"+strings.ToUpper(testAddress[2:]), http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.HandleExport(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="transactions.csv"`, rec.Header().Get("Content-Disposition"))
+
+	rows, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"hash", "from", "to", "value", "blockNumber", "timestamp", "direction", "status"}, rows[0])
+	assert.Equal(t, []string{
+		"0x1111111111111111111111111111111111111111111111111111111111111111",
+		testAddress,
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		"0xde0b6b3a7640000",
+		"100",
+		"1700000000",
+		"out",
+		"",
+	}, rows[1])
+	assert.Equal(t, "", rows[2][2], "contract creations have an empty recipient")
+	assert.Equal(t, "0", rows[2][7])
+}
+
+func TestHTTPHandler_HandleExport_NDJSON(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	txs := exportTestTransactions()
+	mockExport(&mockParser.Mock, "", txs, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/export?format=ndjson", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.HandleExport(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+	dec := json.NewDecoder(rec.Body)
+	for _, want := range txs {
+		var got ethparser.Transaction
+		require.NoError(t, dec.Decode(&got))
+		assert.Equal(t, want, got)
+	}
+	assert.False(t, dec.More())
+}
+
+func TestHTTPHandler_HandleExport_EmptyCSVHasHeader(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockExport(&mockParser.Mock, "", nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/export?format=csv", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.HandleExport(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "hash,from,to,value,blockNumber,timestamp,direction,status\n", rec.Body.String())
+}
+
+func TestHTTPHandler_HandleExport_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "Missing format", target: "/export", wantCode: http.StatusBadRequest},
+		{name: "Unknown format", target: "/export?format=xml", wantCode: http.StatusBadRequest},
+		{
+			name:       "Address not subscribed",
+			target:     "/export?format=csv&address=" + testAddress,
+			serviceErr: fmt.Errorf("%w: %s", ethparser.ErrAddressNotSubscribed, testAddress),
+			wantCode:   http.StatusNotFound,
+		},
+		{
+			name:       "Unexpected error",
+			target:     "/export?format=ndjson",
+			serviceErr: errors.New("repo error"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.serviceErr != nil {
+				mockParser.On("ExportTransactions", mock.Anything, mock.Anything, mock.Anything).Return(tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, http.NoBody)
+			rec := httptest.NewRecorder()
+			handler.HandleExport(rec, req)
+
+			assertErrorResponse(t, rec, tt.wantCode)
+		})
+	}
+}
@@ -1,34 +1,98 @@
 package restapi
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"trust_wallet_homework/internal/config"
 	"trust_wallet_homework/internal/core/domain"
 	"trust_wallet_homework/internal/logger"
 	"trust_wallet_homework/pkg/ethparser"
 )
 
+// envelopeMediaType is the Accept media type that selects the enveloped GET /transactions/{address} response.
+const envelopeMediaType = "application/vnd.ethparser.envelope+json"
+
+// v2MediaType is the Accept media type that selects the v2 (snake_case) response shapes.
+const v2MediaType = "application/vnd.ethparser.v2+json"
+
+// apiVersion identifies the JSON shape of API responses.
+type apiVersion int
+
+// Supported API versions.
+const (
+	apiV1 apiVersion = 1
+	apiV2 apiVersion = 2
+)
+
+// apiVersionKey is the request context key under which the path-selected API version is stored.
+type apiVersionKey struct{}
+
+// defaultMaxBodyBytes is the request body limit used when none is configured.
+const defaultMaxBodyBytes = 8192
+
+// Page sizes of paginated lists: the size used when the request does not set one and the largest accepted one.
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
 // HTTPHandler handles incoming HTTP requests for the parser API.
 type HTTPHandler struct {
-	parserService ethparser.Parser
-	logger        logger.AppLogger
+	parserService      ethparser.Parser
+	logger             logger.AppLogger
+	maxBodyBytes       int64
+	readinessGate      bool
+	streamWriteTimeout time.Duration
+	streamHeartbeat    time.Duration
+	streamBufferSize   int
+	debugConfig        *config.Config
+}
+
+// HandlerOption configures optional settings of HTTPHandler.
+type HandlerOption func(*HTTPHandler)
+
+// WithMaxBodyBytes limits the size of JSON request bodies; larger bodies are rejected with 413.
+// A non-positive limit keeps the default.
+func WithMaxBodyBytes(limit int64) HandlerOption {
+	return func(h *HTTPHandler) {
+		if limit > 0 {
+			h.maxBodyBytes = limit
+		}
+	}
 }
 
 // NewHTTPHandler creates a new handler with the necessary service dependency.
-func NewHTTPHandler(parserService ethparser.Parser, appLogger logger.AppLogger) (*HTTPHandler, error) {
+func NewHTTPHandler(
+	parserService ethparser.Parser,
+	appLogger logger.AppLogger,
+	opts ...HandlerOption,
+) (*HTTPHandler, error) {
 	if parserService == nil {
 		return nil, errors.New("parserService cannot be nil for HTTPHandler")
 	}
 	if appLogger == nil {
 		return nil, errors.New("logger cannot be nil for HTTPHandler")
 	}
-	return &HTTPHandler{
-		parserService: parserService,
-		logger:        appLogger,
-	}, nil
+	h := &HTTPHandler{
+		parserService:      parserService,
+		logger:             appLogger,
+		maxBodyBytes:       defaultMaxBodyBytes,
+		streamWriteTimeout: defaultStreamWriteTimeout,
+		streamHeartbeat:    defaultStreamHeartbeat,
+		streamBufferSize:   defaultStreamBufferSize,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
 }
 
 // HandleGetCurrentBlock handles requests to GET /current_block
@@ -51,6 +115,186 @@ func (h *HTTPHandler) HandleGetCurrentBlock(w http.ResponseWriter, r *http.Reque
 	respondWithJSON(w, http.StatusOK, GetCurrentBlockResponse{BlockNumber: blockNum}, requestLogger)
 }
 
+// HandleGetStats handles requests to GET /stats
+func (h *HTTPHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetStats")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	stats, err := h.parserService.Stats(r.Context())
+	if err != nil {
+		requestLogger.Error("Error getting parser stats", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve parser stats", requestLogger)
+		return
+	}
+
+	if requestAPIVersion(r) == apiV2 {
+		respondWithJSON(w, http.StatusOK, toStatsV2(stats), requestLogger)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, stats, requestLogger)
+}
+
+// HandleGetLag handles requests to GET /lag
+// It responds with 503 Service Unavailable, still including the last known head, when the node is unreachable.
+func (h *HTTPHandler) HandleGetLag(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetLag")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	current, head, lag, err := h.parserService.Lag(r.Context())
+	if err != nil {
+		if errors.Is(err, ethparser.ErrNodeUnavailable) {
+			requestLogger.Warn("Node unavailable while computing lag", "error", err)
+			respondWithJSON(w, http.StatusServiceUnavailable, LagResponse{
+				CurrentBlock: current,
+				HeadBlock:    head,
+				Lag:          lag,
+				Error:        ethparser.ErrNodeUnavailable.Error(),
+			}, requestLogger)
+			return
+		}
+		requestLogger.Error("Error getting parser lag", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve parser lag", requestLogger)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, LagResponse{CurrentBlock: current, HeadBlock: head, Lag: lag}, requestLogger)
+}
+
+// HandleRewind handles requests to POST /admin/rewind
+func (h *HTTPHandler) HandleRewind(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodPost {
+		requestLogger.Warn("Method not allowed for Rewind")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			requestLogger.Warn("Failed to close request body in HandleRewind", "error", err)
+		}
+	}()
+
+	var req RewindRequest
+	if err := h.decodeJSONBody(w, r, &req); err != nil {
+		requestLogger.Warn("Invalid request body for Rewind", "error", err)
+		respondWithError(w, bodyErrorStatus(err), "Invalid request body: "+err.Error(), requestLogger)
+		return
+	}
+	if req.Block == nil {
+		requestLogger.Warn("Missing block in Rewind request")
+		respondWithError(w, http.StatusBadRequest, "Block is required", requestLogger)
+		return
+	}
+
+	if err := h.parserService.Rewind(r.Context(), *req.Block); err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("Rewind rejected", "block", *req.Block, "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error rewinding parser", "block", *req.Block, "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to rewind parser", requestLogger)
+		}
+		return
+	}
+
+	requestLogger.Info("Parser rewound", "block", *req.Block)
+	respondWithJSON(w, http.StatusOK, RewindResponse{Success: true, Block: *req.Block}, requestLogger)
+}
+
+// HandleReplay handles requests to POST /admin/replay
+// It re-publishes the stored transactions of a subscribed address to the event subscribers and responds once
+// all of them were queued.
+func (h *HTTPHandler) HandleReplay(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodPost {
+		requestLogger.Warn("Method not allowed for Replay")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			requestLogger.Warn("Failed to close request body in HandleReplay", "error", err)
+		}
+	}()
+
+	var req ReplayRequest
+	if err := h.decodeJSONBody(w, r, &req); err != nil {
+		requestLogger.Warn("Invalid request body for Replay", "error", err)
+		respondWithError(w, bodyErrorStatus(err), "Invalid request body: "+err.Error(), requestLogger)
+		return
+	}
+	if req.Address == "" {
+		requestLogger.Warn("Missing address in Replay request")
+		respondWithError(w, http.StatusBadRequest, "Address is required", requestLogger)
+		return
+	}
+	address := canonicalAddress(req.Address)
+
+	if err := h.parserService.Replay(r.Context(), address, req.Since); err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("Replay rejected", "address", address, "since", req.Since, "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error replaying transactions", "address", address, "since", req.Since, "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to replay transactions", requestLogger)
+		}
+		return
+	}
+
+	requestLogger.Info("Transactions replayed", "address", address, "since", req.Since)
+	respondWithJSON(w, http.StatusOK, ReplayResponse{Success: true, Address: address, Since: req.Since},
+		requestLogger)
+}
+
+// HandleTriggerScan handles requests to POST /admin/scan
+// It runs a scan iteration immediately and responds once the iteration has completed.
+func (h *HTTPHandler) HandleTriggerScan(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodPost {
+		requestLogger.Warn("Method not allowed for TriggerScan")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	if err := h.parserService.TriggerScan(r.Context()); err != nil {
+		switch code, ok := clientErrorStatus(err); {
+		case ok:
+			requestLogger.Warn("TriggerScan rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		case errors.Is(err, context.DeadlineExceeded):
+			requestLogger.Warn("Requested scan did not complete in time", "error", err)
+			respondWithError(w, http.StatusGatewayTimeout, "Scan did not complete in time", requestLogger)
+		default:
+			requestLogger.Error("Error running requested scan", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to run scan", requestLogger)
+		}
+		return
+	}
+
+	blockNum, err := h.parserService.GetCurrentBlock(r.Context())
+	if err != nil {
+		requestLogger.Error("Error getting current block after requested scan", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve current block", requestLogger)
+		return
+	}
+
+	requestLogger.Info("Requested scan completed", "currentBlock", blockNum)
+	respondWithJSON(w, http.StatusOK, TriggerScanResponse{Success: true, CurrentBlock: blockNum}, requestLogger)
+}
+
 // HandleSubscribe handles requests to POST /subscribe
 func (h *HTTPHandler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
 	requestLogger := h.getRequestLogger(r)
@@ -67,23 +311,39 @@ func (h *HTTPHandler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	var req SubscribeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := h.decodeJSONBody(w, r, &req); err != nil {
 		requestLogger.Warn("Invalid request body for Subscribe", "error", err)
-		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error(), requestLogger)
+		respondWithError(w, bodyErrorStatus(err), "Invalid request body: "+err.Error(), requestLogger)
 		return
 	}
 
-	if req.Address == "" {
-		requestLogger.Warn("Empty address in Subscribe request")
-		respondWithError(w, http.StatusBadRequest, "Address cannot be empty", requestLogger)
+	req.Address = canonicalAddress(req.Address)
+	for i, address := range req.Addresses {
+		req.Addresses[i] = canonicalAddress(address)
+	}
+
+	fieldErrs, err := validateRequest(req)
+	if err != nil {
+		requestLogger.Error("Failed to validate Subscribe request", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to validate request", requestLogger)
+		return
+	}
+	if len(fieldErrs) > 0 {
+		requestLogger.Warn("Subscribe request failed validation", "fields", fieldErrs)
+		respondWithValidationError(w, fieldErrs, requestLogger)
 		return
 	}
 
-	err := h.parserService.Subscribe(r.Context(), req.Address)
+	if len(req.Addresses) > 0 {
+		h.subscribeMany(w, r, req, requestLogger)
+		return
+	}
+
+	err = h.parserService.Subscribe(r.Context(), req.Address, req.Direction)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidAddressFormat) {
-			requestLogger.Warn("Subscribe validation failed", "address", req.Address, "error", err)
-			respondWithError(w, http.StatusBadRequest, err.Error(), requestLogger)
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("Subscribe rejected", "address", req.Address, "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
 		} else {
 			requestLogger.Error("Error subscribing address", "address", req.Address, "error", err)
 			respondWithError(w, http.StatusInternalServerError, "Failed to subscribe address", requestLogger)
@@ -98,10 +358,52 @@ func (h *HTTPHandler) HandleSubscribe(w http.ResponseWriter, r *http.Request) {
 	}, requestLogger)
 }
 
+// subscribeMany handles the bulk variant of POST /subscribe.
+func (h *HTTPHandler) subscribeMany(
+	w http.ResponseWriter,
+	r *http.Request,
+	req SubscribeRequest,
+	requestLogger logger.AppLogger,
+) {
+	addresses := req.Addresses
+	if req.Address != "" {
+		addresses = append([]string{req.Address}, addresses...)
+	}
+
+	results, err := h.parserService.SubscribeMany(r.Context(), addresses, req.Direction)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("Bulk subscribe rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+			return
+		}
+		requestLogger.Error("Error subscribing addresses", "count", len(addresses), "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to subscribe addresses", requestLogger)
+		return
+	}
+
+	allSucceeded := true
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		} else {
+			allSucceeded = false
+		}
+	}
+
+	requestLogger.Info("Bulk subscribe processed", "requested", len(addresses), "succeeded", succeeded)
+	respondWithJSON(w, http.StatusOK, SubscribeManyResponse{
+		Success: allSucceeded,
+		Results: results,
+	}, requestLogger)
+}
+
 // HandleGetTransactions handles requests to GET /transactions/{address}
+// The optional from_block and to_block query parameters restrict the result to an inclusive block range.
 func (h *HTTPHandler) HandleGetTransactions(w http.ResponseWriter, r *http.Request) {
 	requestLogger := h.getRequestLogger(r)
-	address := r.PathValue("address")
+	address := canonicalAddress(r.PathValue("address"))
 
 	requestLogger = requestLogger.With("address_param", address)
 
@@ -117,11 +419,37 @@ func (h *HTTPHandler) HandleGetTransactions(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	txs, err := h.parserService.GetTransactions(r.Context(), address)
+	from, to, hasRange, err := parseBlockRange(r)
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidAddressFormat) {
-			requestLogger.Warn("GetTransactions validation failed", "error", err)
-			respondWithError(w, http.StatusBadRequest, err.Error(), requestLogger)
+		requestLogger.Warn("Invalid block range query parameters in GetTransactions", "error", err)
+		respondWithError(w, http.StatusBadRequest, err.Error(), requestLogger)
+		return
+	}
+	enveloped, err := wantsEnvelope(r)
+	if err != nil {
+		requestLogger.Warn("Invalid envelope query parameter in GetTransactions", "error", err)
+		respondWithError(w, http.StatusBadRequest, err.Error(), requestLogger)
+		return
+	}
+	minValue, sortOrder := r.URL.Query().Get("min_value"), r.URL.Query().Get("sort")
+
+	var txs []ethparser.Transaction
+	switch {
+	case minValue != "" || sortOrder != "":
+		query := ethparser.TransactionQuery{FromBlock: from, ToBlock: to, MinValue: minValue, Sort: sortOrder}
+		if !hasRange {
+			query.ToBlock = math.MaxInt64
+		}
+		txs, err = h.parserService.QueryTransactions(r.Context(), address, query)
+	case hasRange:
+		txs, err = h.parserService.GetTransactionsInRange(r.Context(), address, from, to)
+	default:
+		txs, err = h.parserService.GetTransactions(r.Context(), address)
+	}
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetTransactions rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
 		} else {
 			requestLogger.Error("Error getting transactions", "error", err)
 			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve transactions", requestLogger)
@@ -131,9 +459,647 @@ func (h *HTTPHandler) HandleGetTransactions(w http.ResponseWriter, r *http.Reque
 
 	requestLogger.Info("Successfully retrieved transactions", "count", len(txs))
 
+	version := requestAPIVersion(r)
+	if !enveloped {
+		if version == apiV2 {
+			respondWithJSON(w, http.StatusOK, toTransactionsV2(txs), requestLogger)
+			return
+		}
+		respondWithJSON(w, http.StatusOK, txs, requestLogger)
+		return
+	}
+
+	envelope := TransactionsEnvelope{
+		Address:      address,
+		Count:        len(txs),
+		Transactions: txs,
+	}
+	if envelope.Transactions == nil {
+		envelope.Transactions = []ethparser.Transaction{}
+	}
+	if hasRange && r.URL.Query().Get("from_block") != "" {
+		envelope.FromBlock = &from
+	}
+	if hasRange && r.URL.Query().Get("to_block") != "" {
+		envelope.ToBlock = &to
+	}
+	if version == apiV2 {
+		respondWithJSON(w, http.StatusOK, toTransactionsEnvelopeV2(envelope), requestLogger)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, envelope, requestLogger)
+}
+
+// HandleGetTransactionsBatch handles requests to POST /transactions/batch
+// The body lists the addresses whose transactions are returned, e.g. {"addresses":["0x...","0x..."]}.
+func (h *HTTPHandler) HandleGetTransactionsBatch(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodPost {
+		requestLogger.Warn("Method not allowed for GetTransactionsBatch")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			requestLogger.Warn("Failed to close request body in HandleGetTransactionsBatch", "error", err)
+		}
+	}()
+
+	var req TransactionsBatchRequest
+	if err := h.decodeJSONBody(w, r, &req); err != nil {
+		requestLogger.Warn("Invalid request body for GetTransactionsBatch", "error", err)
+		respondWithError(w, bodyErrorStatus(err), "Invalid request body: "+err.Error(), requestLogger)
+		return
+	}
+
+	for i, address := range req.Addresses {
+		req.Addresses[i] = canonicalAddress(address)
+	}
+
+	fieldErrs, err := validateRequest(req)
+	if err != nil {
+		requestLogger.Error("Failed to validate GetTransactionsBatch request", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to validate request", requestLogger)
+		return
+	}
+	if len(fieldErrs) > 0 {
+		requestLogger.Warn("GetTransactionsBatch request failed validation", "fields", fieldErrs)
+		respondWithValidationError(w, fieldErrs, requestLogger)
+		return
+	}
+
+	txs, err := h.parserService.GetTransactionsForAddresses(r.Context(), req.Addresses)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetTransactionsBatch rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error fetching transactions for addresses", "count", len(req.Addresses), "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve transactions", requestLogger)
+		}
+		return
+	}
+
+	resp := TransactionsBatchResponse{Transactions: txs}
+	if requestAPIVersion(r) == apiV2 {
+		respondWithJSON(w, http.StatusOK, toTransactionsBatchResponseV2(resp), requestLogger)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, resp, requestLogger)
+}
+
+// HandleGetTransactionCount handles requests to GET /transactions/{address}/count
+func (h *HTTPHandler) HandleGetTransactionCount(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+	address := canonicalAddress(r.PathValue("address"))
+
+	requestLogger = requestLogger.With("address_param", address)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetTransactionCount")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	if address == "" {
+		requestLogger.Warn("Empty address in GetTransactionCount URL path")
+		respondWithError(w, http.StatusBadRequest, "Address cannot be empty in URL path", requestLogger)
+		return
+	}
+
+	count, err := h.parserService.GetTransactionCount(r.Context(), address)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetTransactionCount rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error counting transactions", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to count transactions", requestLogger)
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, TransactionCountResponse{Count: count}, requestLogger)
+}
+
+// HandleGetLastActivity handles requests to GET /subscriptions/{address}/last_activity
+func (h *HTTPHandler) HandleGetLastActivity(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+	address := canonicalAddress(r.PathValue("address"))
+
+	requestLogger = requestLogger.With("address_param", address)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetLastActivity")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	if address == "" {
+		requestLogger.Warn("Empty address in GetLastActivity URL path")
+		respondWithError(w, http.StatusBadRequest, "Address cannot be empty in URL path", requestLogger)
+		return
+	}
+
+	blockNumber, timestamp, err := h.parserService.LastActivity(r.Context(), address)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetLastActivity rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error getting last activity", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to get last activity", requestLogger)
+		}
+		return
+	}
+
+	resp := LastActivityResponse{Address: address}
+	if blockNumber > 0 {
+		resp.BlockNumber = &blockNumber
+		resp.Timestamp = &timestamp
+	}
+	respondWithJSON(w, http.StatusOK, resp, requestLogger)
+}
+
+// HandleListSubscriptions handles requests to GET /subscriptions
+// The optional limit and offset query parameters select a page of the subscribed addresses.
+func (h *HTTPHandler) HandleListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for ListSubscriptions")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		requestLogger.Warn("Invalid pagination", "error", err)
+		respondWithError(w, http.StatusBadRequest, err.Error(), requestLogger)
+		return
+	}
+
+	addresses, total, err := h.parserService.ListSubscriptions(r.Context(), limit, offset)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("ListSubscriptions rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error listing subscriptions", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to list subscriptions", requestLogger)
+		}
+		return
+	}
+
+	if addresses == nil {
+		addresses = []string{}
+	}
+	resp := SubscriptionsResponse{Addresses: addresses, Total: total, Limit: limit, Offset: offset}
+	respondWithJSON(w, http.StatusOK, resp, requestLogger)
+}
+
+// HandleListAddresses handles requests to GET /addresses
+// It lists the addresses that have stored transactions, which may be fewer than the subscribed addresses.
+func (h *HTTPHandler) HandleListAddresses(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for ListAddresses")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	addresses, err := h.parserService.ListAddressesWithTransactions(r.Context())
+	if err != nil {
+		requestLogger.Error("Error listing addresses with transactions", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to list addresses", requestLogger)
+		return
+	}
+
+	if addresses == nil {
+		addresses = []string{}
+	}
+	respondWithJSON(w, http.StatusOK, AddressesResponse{Addresses: addresses}, requestLogger)
+}
+
+// HandleStreamTransactions handles requests to GET /transactions/{address}/stream
+// Idle streams get a keepalive comment every heartbeat interval. A client that does not accept a write within
+// the stream write timeout, or falls behind by more than the stream buffer size, is disconnected.
+func (h *HTTPHandler) HandleStreamTransactions(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+	address := canonicalAddress(r.PathValue("address"))
+
+	requestLogger = requestLogger.With("address_param", address)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for StreamTransactions")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	if address == "" {
+		requestLogger.Warn("Empty address in StreamTransactions URL path")
+		respondWithError(w, http.StatusBadRequest, "Address cannot be empty in URL path", requestLogger)
+		return
+	}
+
+	if _, ok := w.(http.Flusher); !ok {
+		requestLogger.Error("Streaming is not supported by the response writer")
+		respondWithError(w, http.StatusInternalServerError, "Streaming not supported", requestLogger)
+		return
+	}
+
+	txs, err := h.parserService.WatchTransactions(r.Context(), address)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("StreamTransactions rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error watching transactions", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to stream transactions", requestLogger)
+		}
+		return
+	}
+
+	stream := newSSEWriter(w, h.streamWriteTimeout)
+	if err := stream.open(); err != nil {
+		requestLogger.Warn("Error opening transaction stream", "error", err)
+		return
+	}
+
+	queue, overflow := queueStreamEvents(r.Context(), txs, h.streamBufferSize)
+	heartbeat := time.NewTicker(h.streamHeartbeat)
+	defer heartbeat.Stop()
+
+	version := requestAPIVersion(r)
+	requestLogger.Info("Transaction stream opened")
+	for {
+		select {
+		case <-r.Context().Done():
+			requestLogger.Info("Transaction stream closed by client")
+			return
+		case <-overflow:
+			requestLogger.Warn("Transaction stream client is not keeping up, closing stream",
+				"bufferSize", h.streamBufferSize)
+			stream.drop()
+			return
+		case <-heartbeat.C:
+			if err := stream.send(sseKeepalive); err != nil {
+				requestLogger.Warn("Error writing stream keepalive, closing stream", "error", err)
+				return
+			}
+		case tx, ok := <-queue:
+			if !ok {
+				requestLogger.Info("Transaction stream closed by service")
+				return
+			}
+			var event any = tx
+			if version == apiV2 {
+				event = toTransactionV2(tx)
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				requestLogger.Error("Error marshaling streamed transaction", "txHash", tx.Hash, "error", err)
+				continue
+			}
+			if err := stream.send("data: " + string(payload) + "\n\n"); err != nil {
+				requestLogger.Warn("Error writing streamed transaction, closing stream", "txHash", tx.Hash, "error", err)
+				return
+			}
+			heartbeat.Reset(h.streamHeartbeat)
+		}
+	}
+}
+
+// HandleGetTransaction handles requests to GET /transaction/{hash}
+func (h *HTTPHandler) HandleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+	hash := r.PathValue("hash")
+
+	requestLogger = requestLogger.With("hash_param", hash)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetTransaction")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	tx, err := h.parserService.GetTransactionByHash(r.Context(), hash)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetTransaction rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error getting transaction", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve transaction", requestLogger)
+		}
+		return
+	}
+
+	if requestAPIVersion(r) == apiV2 && tx != nil {
+		respondWithJSON(w, http.StatusOK, toTransactionV2(*tx), requestLogger)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, tx, requestLogger)
+}
+
+// HandleGetNodeTransaction handles requests to GET /node/transaction/{hash}
+// Unlike GET /transaction/{hash}, the transaction is fetched from the node and need not be stored.
+func (h *HTTPHandler) HandleGetNodeTransaction(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+	hash := r.PathValue("hash")
+
+	requestLogger = requestLogger.With("hash_param", hash)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetNodeTransaction")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	tx, err := h.parserService.GetNodeTransaction(r.Context(), hash)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetNodeTransaction rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error getting transaction from node", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve transaction from node", requestLogger)
+		}
+		return
+	}
+
+	if requestAPIVersion(r) == apiV2 && tx != nil {
+		respondWithJSON(w, http.StatusOK, toTransactionV2(*tx), requestLogger)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, tx, requestLogger)
+}
+
+// HandleGetBlock handles requests to GET /block/{number}
+func (h *HTTPHandler) HandleGetBlock(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+	numberParam := r.PathValue("number")
+
+	requestLogger = requestLogger.With("number_param", numberParam)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetBlock")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	number, err := strconv.ParseInt(numberParam, 10, 64)
+	if err != nil || number < 0 {
+		requestLogger.Warn("Invalid block number in GetBlock URL path")
+		respondWithError(w, http.StatusBadRequest, "Block number must be a non-negative integer", requestLogger)
+		return
+	}
+
+	block, err := h.parserService.GetBlock(r.Context(), number)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetBlock rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error getting block", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve block", requestLogger)
+		}
+		return
+	}
+
+	if requestAPIVersion(r) == apiV2 && block != nil {
+		respondWithJSON(w, http.StatusOK, toBlockV2(*block), requestLogger)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, block, requestLogger)
+}
+
+// HandleGetBlockByHash handles requests to GET /block/hash/{hash}
+func (h *HTTPHandler) HandleGetBlockByHash(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+	hashParam := r.PathValue("hash")
+
+	requestLogger = requestLogger.With("hash_param", hashParam)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetBlockByHash")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	if hashParam == "" {
+		requestLogger.Warn("Empty hash in GetBlockByHash URL path")
+		respondWithError(w, http.StatusBadRequest, "Block hash cannot be empty in URL path", requestLogger)
+		return
+	}
+
+	block, err := h.parserService.GetBlockByHash(r.Context(), hashParam)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetBlockByHash rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error getting block by hash", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve block", requestLogger)
+		}
+		return
+	}
+
+	if requestAPIVersion(r) == apiV2 && block != nil {
+		respondWithJSON(w, http.StatusOK, toBlockV2(*block), requestLogger)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, block, requestLogger)
+}
+
+// HandleGetBlockTransactions handles requests to GET /block/{number}/transactions
+// It returns the stored transactions of the monitored addresses included in the block, not every transaction of the block.
+func (h *HTTPHandler) HandleGetBlockTransactions(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+	numberParam := r.PathValue("number")
+
+	requestLogger = requestLogger.With("number_param", numberParam)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for GetBlockTransactions")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	number, err := strconv.ParseInt(numberParam, 10, 64)
+	if err != nil || number < 0 {
+		requestLogger.Warn("Invalid block number in GetBlockTransactions URL path")
+		respondWithError(w, http.StatusBadRequest, "Block number must be a non-negative integer", requestLogger)
+		return
+	}
+
+	txs, err := h.parserService.GetTransactionsInBlock(r.Context(), number)
+	if err != nil {
+		if code, ok := clientErrorStatus(err); ok {
+			requestLogger.Warn("GetBlockTransactions rejected", "error", err)
+			respondWithError(w, code, err.Error(), requestLogger)
+		} else {
+			requestLogger.Error("Error getting block transactions", "error", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to retrieve block transactions", requestLogger)
+		}
+		return
+	}
+
+	if requestAPIVersion(r) == apiV2 {
+		respondWithJSON(w, http.StatusOK, toTransactionsV2(txs), requestLogger)
+		return
+	}
 	respondWithJSON(w, http.StatusOK, txs, requestLogger)
 }
 
+// canonicalAddress returns the canonical (lowercase) form of a valid address, so mixed-case input is
+// echoed and logged identically. Invalid input is returned unchanged for the service to reject.
+func canonicalAddress(raw string) string {
+	address, err := domain.NewAddress(raw)
+	if err != nil {
+		return raw
+	}
+	return address.String()
+}
+
+// decodeJSONBody decodes the request body into v, reading at most maxBodyBytes.
+func (h *HTTPHandler) decodeJSONBody(w http.ResponseWriter, r *http.Request, v any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxBodyBytes)
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+// bodyErrorStatus maps a request body decoding error to 413 if the body was too large, and 400 otherwise.
+func bodyErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// parseBlockRange reads the optional from_block and to_block query parameters.
+// A missing lower bound defaults to block 0 and a missing upper bound to the highest possible block.
+func parseBlockRange(r *http.Request) (from, to int64, hasRange bool, err error) {
+	query := r.URL.Query()
+	fromParam, toParam := query.Get("from_block"), query.Get("to_block")
+	if fromParam == "" && toParam == "" {
+		return 0, 0, false, nil
+	}
+
+	from, to = 0, math.MaxInt64
+	if fromParam != "" {
+		if from, err = strconv.ParseInt(fromParam, 10, 64); err != nil || from < 0 {
+			return 0, 0, false, errors.New("from_block must be a non-negative integer")
+		}
+	}
+	if toParam != "" {
+		if to, err = strconv.ParseInt(toParam, 10, 64); err != nil || to < 0 {
+			return 0, 0, false, errors.New("to_block must be a non-negative integer")
+		}
+	}
+	if from > to {
+		return 0, 0, false, errors.New("from_block must not be greater than to_block")
+	}
+	return from, to, true, nil
+}
+
+// parsePagination reads the optional limit and offset query parameters. The limit defaults to
+// defaultPageLimit and may not exceed maxPageLimit; the offset defaults to 0.
+func parsePagination(r *http.Request) (limit, offset int, err error) {
+	query := r.URL.Query()
+	limit = defaultPageLimit
+	if param := query.Get("limit"); param != "" {
+		if limit, err = strconv.Atoi(param); err != nil || limit < 1 || limit > maxPageLimit {
+			return 0, 0, fmt.Errorf("limit must be an integer between 1 and %d", maxPageLimit)
+		}
+	}
+	if param := query.Get("offset"); param != "" {
+		if offset, err = strconv.Atoi(param); err != nil || offset < 0 {
+			return 0, 0, errors.New("offset must be a non-negative integer")
+		}
+	}
+	return limit, offset, nil
+}
+
+// wantsEnvelope reports whether the client asked for an enveloped transaction list,
+// either with the envelope=true query parameter or by accepting the envelope media type.
+func wantsEnvelope(r *http.Request) (bool, error) {
+	if param := r.URL.Query().Get("envelope"); param != "" {
+		enveloped, err := strconv.ParseBool(param)
+		if err != nil {
+			return false, errors.New("envelope must be a boolean")
+		}
+		return enveloped, nil
+	}
+	return acceptsMediaType(r, envelopeMediaType), nil
+}
+
+// requestAPIVersion returns the API version selected by the /v2/ path prefix or the v2 Accept media type.
+// Requests without either get the v1 response shapes.
+func requestAPIVersion(r *http.Request) apiVersion {
+	if version, ok := r.Context().Value(apiVersionKey{}).(apiVersion); ok {
+		return version
+	}
+	if acceptsMediaType(r, v2MediaType) {
+		return apiV2
+	}
+	return apiV1
+}
+
+// withAPIVersion marks requests passed to next as using the given API version.
+func withAPIVersion(next http.Handler, version apiVersion) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiVersionKey{}, version)))
+	})
+}
+
+// acceptsMediaType reports whether any Accept header of the request lists the media type.
+func acceptsMediaType(r *http.Request, mediaType string) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, accepted := range strings.Split(accept, ",") {
+			accepted, _, _ = strings.Cut(accepted, ";")
+			if strings.EqualFold(strings.TrimSpace(accepted), mediaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// clientErrorStatus maps errors caused by the client request to an HTTP status code.
+// It returns false for errors that should be reported as internal server errors.
+func clientErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAddressFormat),
+		errors.Is(err, domain.ErrInvalidSubscriptionDirection),
+		errors.Is(err, domain.ErrInvalidTransactionHashFormat),
+		errors.Is(err, domain.ErrInvalidBlockHashFormat),
+		errors.Is(err, domain.ErrNegativeBlockNumber),
+		errors.Is(err, domain.ErrInvalidWeiValueFormat),
+		errors.Is(err, domain.ErrNegativeWeiValue),
+		errors.Is(err, ethparser.ErrInvalidBlockRange),
+		errors.Is(err, ethparser.ErrInvalidSortOrder),
+		errors.Is(err, ethparser.ErrInvalidPagination),
+		errors.Is(err, ethparser.ErrRewindBeyondHead):
+		return http.StatusBadRequest, true
+	case errors.Is(err, ethparser.ErrBlockNotFound),
+		errors.Is(err, ethparser.ErrTransactionNotFound):
+		return http.StatusNotFound, true
+	case errors.Is(err, ethparser.ErrAddressNotSubscribed):
+		return http.StatusNotFound, true
+	case errors.Is(err, ethparser.ErrAddressAlreadySubscribed):
+		return http.StatusConflict, true
+	case errors.Is(err, ethparser.ErrSubscriptionLimitReached):
+		return http.StatusTooManyRequests, true
+	case errors.Is(err, ethparser.ErrParserNotRunning):
+		return http.StatusServiceUnavailable, true
+	default:
+		return 0, false
+	}
+}
+
 // getRequestLogger is a helper to create a request-specific logger with contextual information.
 func (h *HTTPHandler) getRequestLogger(r *http.Request) logger.AppLogger {
 	return h.logger.With(
@@ -149,6 +1115,13 @@ func respondWithError(w http.ResponseWriter, code int, message string, l logger.
 	respondWithJSON(w, code, ErrorResponse{Error: message}, l)
 }
 
+// respondWithValidationError sends a 400 JSON error response listing the fields that failed validation.
+func respondWithValidationError(w http.ResponseWriter, fieldErrs []FieldError, l logger.AppLogger) {
+	message := validationMessage(fieldErrs)
+	l.Warn("Responding with error", "http_code", http.StatusBadRequest, "message", message)
+	respondWithJSON(w, http.StatusBadRequest, ErrorResponse{Error: message, Fields: fieldErrs}, l)
+}
+
 // respondWithJSON marshals the given payload into JSON and writes it to the response writer.
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}, l logger.AppLogger) {
 	response, err := json.Marshal(payload)
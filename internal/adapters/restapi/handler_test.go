@@ -0,0 +1,1445 @@
+package restapi_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/restapi"
+	"trust_wallet_homework/internal/adapters/restapi/mocks/mock_ethparser"
+	"trust_wallet_homework/internal/core/domain"
+	applogger "trust_wallet_homework/internal/logger"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testAddress = "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+
+func TestHTTPHandler_HandleGetTransactionCount(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+
+	mockParser.On("GetTransactionCount", mock.Anything, testAddress).Return(3, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress+"/count", http.NoBody)
+	req.SetPathValue("address", testAddress)
+	rec := httptest.NewRecorder()
+
+	handler.HandleGetTransactionCount(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp restapi.TransactionCountResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.Count)
+}
+
+func TestHTTPHandler_HandleGetTransactionCount_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		address    string
+		serviceErr error
+		wantCode   int
+	}{
+		{
+			name:     "Method not allowed",
+			method:   http.MethodPost,
+			address:  testAddress,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "Empty address",
+			method:   http.MethodGet,
+			address:  "",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid address",
+			method:     http.MethodGet,
+			address:    "0xinvalid",
+			serviceErr: fmt.Errorf("address validation failed: %w", domain.ErrInvalidAddressFormat),
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "Service failure",
+			method:     http.MethodGet,
+			address:    testAddress,
+			serviceErr: errors.New("repo error"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.serviceErr != nil {
+				mockParser.On("GetTransactionCount", mock.Anything, tt.address).Return(0, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(tt.method, "/transactions/count", http.NoBody)
+			req.SetPathValue("address", tt.address)
+			rec := httptest.NewRecorder()
+
+			handler.HandleGetTransactionCount(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetLastActivity(t *testing.T) {
+	tests := []struct {
+		name        string
+		blockNumber int64
+		timestamp   uint64
+		wantBody    string
+	}{
+		{
+			name:        "Address with activity",
+			blockNumber: 42,
+			timestamp:   1700000000,
+			wantBody:    `{"address":"` + testAddress + `","block_number":42,"timestamp":1700000000}`,
+		},
+		{
+			name:     "Address without activity",
+			wantBody: `{"address":"` + testAddress + `","block_number":null,"timestamp":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			mockParser.On("LastActivity", mock.Anything, testAddress).Return(tt.blockNumber, tt.timestamp, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/subscriptions/"+testAddress+"/last_activity", http.NoBody)
+			req.SetPathValue("address", testAddress)
+			rec := httptest.NewRecorder()
+
+			handler.HandleGetLastActivity(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetLastActivity_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		address    string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "Method not allowed", method: http.MethodPost, address: testAddress, wantCode: http.StatusMethodNotAllowed},
+		{name: "Empty address", method: http.MethodGet, address: "", wantCode: http.StatusBadRequest},
+		{
+			name:       "Address not subscribed",
+			method:     http.MethodGet,
+			address:    testAddress,
+			serviceErr: fmt.Errorf("%w: %s", ethparser.ErrAddressNotSubscribed, testAddress),
+			wantCode:   http.StatusNotFound,
+		},
+		{
+			name:       "Service failure",
+			method:     http.MethodGet,
+			address:    testAddress,
+			serviceErr: errors.New("repo error"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.serviceErr != nil {
+				mockParser.On("LastActivity", mock.Anything, tt.address).Return(int64(0), uint64(0), tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(tt.method, "/subscriptions/last_activity", http.NoBody)
+			req.SetPathValue("address", tt.address)
+			rec := httptest.NewRecorder()
+
+			handler.HandleGetLastActivity(rec, req)
+
+			assertErrorResponse(t, rec, tt.wantCode)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleStreamTransactions(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+
+	txs := make(chan ethparser.Transaction, 1)
+	mockParser.On("WatchTransactions", mock.Anything, testAddress).Return((<-chan ethparser.Transaction)(txs), nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/transactions/{address}/stream", handler.HandleStreamTransactions)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/transactions/" + testAddress + "/stream")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	want := ethparser.Transaction{
+		Hash:        "0x1111111111111111111111111111111111111111111111111111111111111111",
+		From:        testAddress,
+		To:          stringPtr("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"),
+		Value:       "0x1",
+		BlockNumber: 10,
+		Timestamp:   1000,
+	}
+	txs <- want
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "data: "), "unexpected SSE line %q", line)
+
+	var got ethparser.Transaction
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "data: ")), &got))
+	assert.Equal(t, want, got)
+}
+
+func TestHTTPHandler_HandleSubscribe_Bulk(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+
+	results := []ethparser.SubscribeResult{
+		{Address: testAddress, Success: true},
+		{Address: "0xinvalid", Success: false, Error: "address validation failed"},
+	}
+	mockParser.On("SubscribeMany", mock.Anything, []string{testAddress, "0xinvalid"}, "").Return(results, nil)
+
+	body := `{"addresses":["` + testAddress + `","0xinvalid"]}`
+	req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.HandleSubscribe(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp restapi.SubscribeManyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.False(t, resp.Success)
+	assert.Equal(t, results, resp.Results)
+}
+
+func TestHTTPHandler_HandleSubscribe_BodyTooLarge(t *testing.T) {
+	mockParser := mock_ethparser.NewParser(t)
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler, err := restapi.NewHTTPHandler(mockParser, discardLogger, restapi.WithMaxBodyBytes(64))
+	require.NoError(t, err)
+
+	body := `{"addresses":["` + strings.Repeat(testAddress+`","`, 10) + testAddress + `"]}`
+	req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.HandleSubscribe(rec, req)
+
+	assertErrorResponse(t, rec, http.StatusRequestEntityTooLarge)
+	mockParser.AssertNotCalled(t, "SubscribeMany", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHTTPHandler_HandleSubscribe_BodyWithinLimit(t *testing.T) {
+	mockParser := mock_ethparser.NewParser(t)
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler, err := restapi.NewHTTPHandler(mockParser, discardLogger, restapi.WithMaxBodyBytes(64))
+	require.NoError(t, err)
+	mockParser.On("Subscribe", mock.Anything, testAddress, "").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{"address":"`+testAddress+`"}`))
+	rec := httptest.NewRecorder()
+
+	handler.HandleSubscribe(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHTTPHandler_HandleSubscribe_WithDirection(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+
+	mockParser.On("Subscribe", mock.Anything, testAddress, "in").Return(nil)
+
+	body := `{"address":"` + testAddress + `","direction":"in"}`
+	req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.HandleSubscribe(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHTTPHandler_HandleSubscribe_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantFields []restapi.FieldError
+	}{
+		{
+			name:       "Missing address",
+			body:       `{}`,
+			wantFields: []restapi.FieldError{{Field: "address", Error: "is required"}},
+		},
+		{
+			name:       "Empty address",
+			body:       `{"address":""}`,
+			wantFields: []restapi.FieldError{{Field: "address", Error: "is required"}},
+		},
+		{
+			name:       "Invalid eth address",
+			body:       `{"address":"0x12345"}`,
+			wantFields: []restapi.FieldError{{Field: "address", Error: "must be a valid Ethereum address"}},
+		},
+		{
+			name: "Invalid address and direction",
+			body: `{"address":"not-an-address","direction":"sideways"}`,
+			wantFields: []restapi.FieldError{
+				{Field: "address", Error: "must be a valid Ethereum address"},
+				{Field: "direction", Error: "must be one of: in out both"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.HandleSubscribe(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			var resp restapi.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.NotEmpty(t, resp.Error)
+			assert.Equal(t, tt.wantFields, resp.Fields)
+			mockParser.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything, mock.Anything)
+			mockParser.AssertNotCalled(t, "SubscribeMany", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleRewind(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		block      int64
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "Valid rewind", body: `{"block":100}`, block: 100, wantCode: http.StatusOK},
+		{
+			name:       "Above network head",
+			body:       `{"block":300}`,
+			block:      300,
+			serviceErr: fmt.Errorf("%w: block 300, head 210", ethparser.ErrRewindBeyondHead),
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "Negative block",
+			body:       `{"block":-1}`,
+			block:      -1,
+			serviceErr: fmt.Errorf("block validation failed: %w", domain.ErrNegativeBlockNumber),
+			wantCode:   http.StatusBadRequest,
+		},
+		{name: "Missing block", body: `{}`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.body != `{}` {
+				mockParser.On("Rewind", mock.Anything, tt.block).Return(tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/rewind", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.HandleRewind(rec, req)
+
+			if tt.wantCode != http.StatusOK {
+				assertErrorResponse(t, rec, tt.wantCode)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			var resp restapi.RewindResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, restapi.RewindResponse{Success: true, Block: tt.block}, resp)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleReplay(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		since       int64
+		callService bool
+		serviceErr  error
+		wantCode    int
+	}{
+		{
+			name:        "Replay from block",
+			body:        `{"address":"` + testAddress + `","since":100}`,
+			since:       100,
+			callService: true,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "Replay whole history",
+			body:        `{"address":"` + testAddress + `"}`,
+			callService: true,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "Address not subscribed",
+			body:        `{"address":"` + testAddress + `"}`,
+			callService: true,
+			serviceErr:  ethparser.ErrAddressNotSubscribed,
+			wantCode:    http.StatusNotFound,
+		},
+		{
+			name:        "Negative since block",
+			body:        `{"address":"` + testAddress + `","since":-1}`,
+			since:       -1,
+			callService: true,
+			serviceErr:  fmt.Errorf("since block validation failed: %w", domain.ErrNegativeBlockNumber),
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "Replay interrupted",
+			body:        `{"address":"` + testAddress + `"}`,
+			callService: true,
+			serviceErr:  context.Canceled,
+			wantCode:    http.StatusInternalServerError,
+		},
+		{name: "Missing address", body: `{"since":5}`, wantCode: http.StatusBadRequest},
+		{name: "Malformed body", body: `{"address":`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.callService {
+				mockParser.On("Replay", mock.Anything, testAddress, tt.since).Return(tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/replay", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.HandleReplay(rec, req)
+
+			if tt.wantCode != http.StatusOK {
+				assertErrorResponse(t, rec, tt.wantCode)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			var resp restapi.ReplayResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, restapi.ReplayResponse{Success: true, Address: testAddress, Since: tt.since}, resp)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleTriggerScan(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "Scan completed", method: http.MethodPost, wantCode: http.StatusOK},
+		{name: "Wrong method", method: http.MethodGet, wantCode: http.StatusMethodNotAllowed},
+		{
+			name:       "Parser not running",
+			method:     http.MethodPost,
+			serviceErr: ethparser.ErrParserNotRunning,
+			wantCode:   http.StatusServiceUnavailable,
+		},
+		{
+			name:       "Scan did not complete in time",
+			method:     http.MethodPost,
+			serviceErr: context.DeadlineExceeded,
+			wantCode:   http.StatusGatewayTimeout,
+		},
+		{
+			name:       "Scan failed",
+			method:     http.MethodPost,
+			serviceErr: errors.New("state unavailable"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.method == http.MethodPost {
+				mockParser.On("TriggerScan", mock.Anything).Return(tt.serviceErr)
+			}
+			if tt.wantCode == http.StatusOK {
+				mockParser.On("GetCurrentBlock", mock.Anything).Return(int64(120), nil)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.HandleTriggerScan(rec, httptest.NewRequest(tt.method, "/admin/scan", http.NoBody))
+
+			if tt.wantCode != http.StatusOK {
+				assertErrorResponse(t, rec, tt.wantCode)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			var resp restapi.TriggerScanResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, restapi.TriggerScanResponse{Success: true, CurrentBlock: 120}, resp)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleSubscribe_BulkServiceError(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+
+	mockParser.On("SubscribeMany", mock.Anything, []string{testAddress}, "").Return(nil, errors.New("repo error"))
+
+	req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{"addresses":["`+testAddress+`"]}`))
+	rec := httptest.NewRecorder()
+
+	handler.HandleSubscribe(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestHTTPHandler_ServiceErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantCode   int
+	}{
+		{
+			name:       "Invalid address",
+			serviceErr: fmt.Errorf("address validation failed: %w", domain.ErrInvalidAddressFormat),
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid direction",
+			serviceErr: fmt.Errorf("direction validation failed: %w", domain.ErrInvalidSubscriptionDirection),
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "Address not subscribed",
+			serviceErr: fmt.Errorf("%w: %s", ethparser.ErrAddressNotSubscribed, testAddress),
+			wantCode:   http.StatusNotFound,
+		},
+		{
+			name:       "Address already subscribed",
+			serviceErr: fmt.Errorf("%w: %s", ethparser.ErrAddressAlreadySubscribed, testAddress),
+			wantCode:   http.StatusConflict,
+		},
+		{
+			name:       "Subscription limit reached",
+			serviceErr: fmt.Errorf("%w: at most 2 addresses can be subscribed", ethparser.ErrSubscriptionLimitReached),
+			wantCode:   http.StatusTooManyRequests,
+		},
+		{
+			name:       "Unexpected error",
+			serviceErr: errors.New("repo error"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/subscribe", func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			mockParser.On("Subscribe", mock.Anything, testAddress, "").Return(tt.serviceErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{"address":"`+testAddress+`"}`))
+			rec := httptest.NewRecorder()
+			handler.HandleSubscribe(rec, req)
+
+			assertErrorResponse(t, rec, tt.wantCode)
+		})
+		t.Run(tt.name+"/transactions", func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			mockParser.On("GetTransactions", mock.Anything, testAddress).Return(nil, tt.serviceErr)
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress, http.NoBody)
+			req.SetPathValue("address", testAddress)
+			rec := httptest.NewRecorder()
+			handler.HandleGetTransactions(rec, req)
+
+			assertErrorResponse(t, rec, tt.wantCode)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetBlock(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+
+	want := &ethparser.Block{
+		Number:           42,
+		Hash:             "0x4242424242424242424242424242424242424242424242424242424242424242",
+		Timestamp:        1000,
+		TransactionCount: 0,
+		Transactions:     []ethparser.Transaction{},
+	}
+	mockParser.On("GetBlock", mock.Anything, int64(42)).Return(want, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/block/42", http.NoBody)
+	req.SetPathValue("number", "42")
+	rec := httptest.NewRecorder()
+	handler.HandleGetBlock(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got ethparser.Block
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, *want, got)
+}
+
+func TestHTTPHandler_HandleGetTransaction(t *testing.T) {
+	const hash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	want := &ethparser.Transaction{Hash: hash, From: testAddress, To: stringPtr("0x2"), Value: "0x1", BlockNumber: 15}
+
+	tests := []struct {
+		name     string
+		hash     string
+		setup    func(p *mock_ethparser.Parser)
+		wantCode int
+	}{
+		{
+			name:     "Found",
+			hash:     hash,
+			setup:    func(p *mock_ethparser.Parser) { p.On("GetTransactionByHash", mock.Anything, hash).Return(want, nil) },
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "Unknown hash",
+			hash: hash,
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetTransactionByHash", mock.Anything, hash).
+					Return(nil, fmt.Errorf("%w: %s", ethparser.ErrTransactionNotFound, hash))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "Invalid hash",
+			hash: "0x1234",
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetTransactionByHash", mock.Anything, "0x1234").
+					Return(nil, fmt.Errorf("validation failed: %w", domain.ErrInvalidTransactionHashFormat))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			tt.setup(mockParser)
+
+			req := httptest.NewRequest(http.MethodGet, "/transaction/"+tt.hash, http.NoBody)
+			req.SetPathValue("hash", tt.hash)
+			rec := httptest.NewRecorder()
+			handler.HandleGetTransaction(rec, req)
+
+			if tt.wantCode != http.StatusOK {
+				assertErrorResponse(t, rec, tt.wantCode)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			var got ethparser.Transaction
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, *want, got)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetNodeTransaction(t *testing.T) {
+	const hash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	want := &ethparser.Transaction{Hash: hash, From: testAddress, To: stringPtr("0x2"), Value: "0x1", BlockNumber: 15}
+
+	tests := []struct {
+		name     string
+		setup    func(p *mock_ethparser.Parser)
+		wantCode int
+	}{
+		{
+			name:     "Found",
+			setup:    func(p *mock_ethparser.Parser) { p.On("GetNodeTransaction", mock.Anything, hash).Return(want, nil) },
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "Unknown to the node",
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetNodeTransaction", mock.Anything, hash).
+					Return(nil, fmt.Errorf("%w: %s", ethparser.ErrTransactionNotFound, hash))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "Node error",
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetNodeTransaction", mock.Anything, hash).Return(nil, errors.New("RPC call failed"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			tt.setup(mockParser)
+
+			req := httptest.NewRequest(http.MethodGet, "/node/transaction/"+hash, http.NoBody)
+			req.SetPathValue("hash", hash)
+			rec := httptest.NewRecorder()
+			handler.HandleGetNodeTransaction(rec, req)
+
+			if tt.wantCode != http.StatusOK {
+				assertErrorResponse(t, rec, tt.wantCode)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			var got ethparser.Transaction
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, *want, got)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetBlock_NotFound(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+
+	mockParser.On("GetBlock", mock.Anything, int64(99999999)).
+		Return(nil, fmt.Errorf("%w: %d", ethparser.ErrBlockNotFound, 99999999))
+
+	req := httptest.NewRequest(http.MethodGet, "/block/99999999", http.NoBody)
+	req.SetPathValue("number", "99999999")
+	rec := httptest.NewRecorder()
+	handler.HandleGetBlock(rec, req)
+
+	assertErrorResponse(t, rec, http.StatusNotFound)
+}
+
+func TestHTTPHandler_HandleGetBlock_BadNumber(t *testing.T) {
+	for _, number := range []string{"abc", "-1", "0x10", ""} {
+		t.Run(number, func(t *testing.T) {
+			handler, _ := setupHandler(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/block/x", http.NoBody)
+			req.SetPathValue("number", number)
+			rec := httptest.NewRecorder()
+			handler.HandleGetBlock(rec, req)
+
+			assertErrorResponse(t, rec, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleListAddresses(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		addresses []string
+		err       error
+		wantCode  int
+		wantBody  string
+	}{
+		{
+			name:      "Several addresses",
+			method:    http.MethodGet,
+			addresses: []string{"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", testAddress},
+			wantCode:  http.StatusOK,
+			wantBody:  `{"addresses":["0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","` + testAddress + `"]}`,
+		},
+		{
+			name:     "No stored transactions",
+			method:   http.MethodGet,
+			wantCode: http.StatusOK,
+			wantBody: `{"addresses":[]}`,
+		},
+		{name: "Wrong method", method: http.MethodPost, wantCode: http.StatusMethodNotAllowed},
+		{
+			name:     "Repository error",
+			method:   http.MethodGet,
+			err:      errors.New("storage unavailable"),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.method == http.MethodGet {
+				mockParser.On("ListAddressesWithTransactions", mock.Anything).Return(tt.addresses, tt.err)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.HandleListAddresses(rec, httptest.NewRequest(tt.method, "/addresses", http.NoBody))
+
+			if tt.wantCode != http.StatusOK {
+				assertErrorResponse(t, rec, tt.wantCode)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHTTPHandler_HandleListSubscriptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		wantLimit  int
+		wantOffset int
+		addresses  []string
+		err        error
+		wantCode   int
+		wantBody   string
+	}{
+		{
+			name:      "Default page",
+			method:    http.MethodGet,
+			wantLimit: 100,
+			addresses: []string{testAddress},
+			wantCode:  http.StatusOK,
+			wantBody:  `{"addresses":["` + testAddress + `"],"total":3,"limit":100,"offset":0}`,
+		},
+		{
+			name:       "Explicit page",
+			method:     http.MethodGet,
+			query:      "?limit=1&offset=2",
+			wantLimit:  1,
+			wantOffset: 2,
+			addresses:  []string{testAddress},
+			wantCode:   http.StatusOK,
+			wantBody:   `{"addresses":["` + testAddress + `"],"total":3,"limit":1,"offset":2}`,
+		},
+		{
+			name:       "Offset past the end",
+			method:     http.MethodGet,
+			query:      "?offset=10",
+			wantLimit:  100,
+			wantOffset: 10,
+			wantCode:   http.StatusOK,
+			wantBody:   `{"addresses":[],"total":3,"limit":100,"offset":10}`,
+		},
+		{name: "Zero limit", method: http.MethodGet, query: "?limit=0", wantCode: http.StatusBadRequest},
+		{name: "Limit above the maximum", method: http.MethodGet, query: "?limit=1001", wantCode: http.StatusBadRequest},
+		{name: "Negative offset", method: http.MethodGet, query: "?offset=-1", wantCode: http.StatusBadRequest},
+		{name: "Non-numeric limit", method: http.MethodGet, query: "?limit=ten", wantCode: http.StatusBadRequest},
+		{name: "Wrong method", method: http.MethodPost, wantCode: http.StatusMethodNotAllowed},
+		{
+			name:      "Repository error",
+			method:    http.MethodGet,
+			wantLimit: 100,
+			err:       errors.New("storage unavailable"),
+			wantCode:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.wantLimit > 0 {
+				mockParser.On("ListSubscriptions", mock.Anything, tt.wantLimit, tt.wantOffset).
+					Return(tt.addresses, 3, tt.err)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.HandleListSubscriptions(rec, httptest.NewRequest(tt.method, "/subscriptions"+tt.query, http.NoBody))
+
+			if tt.wantCode != http.StatusOK {
+				assertErrorResponse(t, rec, tt.wantCode)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetBlockByHash(t *testing.T) {
+	const hash = "0x4242424242424242424242424242424242424242424242424242424242424242"
+	want := &ethparser.Block{Number: 42, Hash: hash, Timestamp: 1000, Transactions: []ethparser.Transaction{}}
+
+	tests := []struct {
+		name     string
+		method   string
+		hash     string
+		setup    func(p *mock_ethparser.Parser)
+		wantCode int
+	}{
+		{
+			name:     "Found",
+			method:   http.MethodGet,
+			hash:     hash,
+			setup:    func(p *mock_ethparser.Parser) { p.On("GetBlockByHash", mock.Anything, hash).Return(want, nil) },
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "Unknown hash",
+			method: http.MethodGet,
+			hash:   hash,
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetBlockByHash", mock.Anything, hash).
+					Return(nil, fmt.Errorf("%w: %s", ethparser.ErrBlockNotFound, hash))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "Invalid hash",
+			method: http.MethodGet,
+			hash:   "0x1234",
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetBlockByHash", mock.Anything, "0x1234").
+					Return(nil, fmt.Errorf("block hash validation failed: %w", domain.ErrInvalidBlockHashFormat))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "Wrong method",
+			method:   http.MethodPost,
+			hash:     hash,
+			setup:    func(*mock_ethparser.Parser) {},
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "Node error",
+			method: http.MethodGet,
+			hash:   hash,
+			setup: func(p *mock_ethparser.Parser) {
+				p.On("GetBlockByHash", mock.Anything, hash).Return(nil, errors.New("RPC call failed"))
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			tt.setup(mockParser)
+
+			req := httptest.NewRequest(tt.method, "/block/hash/"+tt.hash, http.NoBody)
+			req.SetPathValue("hash", tt.hash)
+			rec := httptest.NewRecorder()
+			handler.HandleGetBlockByHash(rec, req)
+
+			if tt.wantCode != http.StatusOK {
+				assertErrorResponse(t, rec, tt.wantCode)
+				return
+			}
+			require.Equal(t, http.StatusOK, rec.Code)
+			var got ethparser.Block
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, *want, got)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetBlockTransactions(t *testing.T) {
+	want := []ethparser.Transaction{
+		{Hash: "0x1", From: testAddress, To: stringPtr("0x2"), Value: "0x1", BlockNumber: 42},
+		{Hash: "0x2", From: "0x2", To: stringPtr(testAddress), Value: "0x2", BlockNumber: 42},
+	}
+
+	tests := []struct {
+		name   string
+		result []ethparser.Transaction
+		want   string
+	}{
+		{name: "Stored transactions", result: want},
+		{name: "No stored transactions", result: []ethparser.Transaction{}, want: `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			mockParser.On("GetTransactionsInBlock", mock.Anything, int64(42)).Return(tt.result, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/block/42/transactions", http.NoBody)
+			req.SetPathValue("number", "42")
+			rec := httptest.NewRecorder()
+			handler.HandleGetBlockTransactions(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			if tt.want != "" {
+				assert.JSONEq(t, tt.want, rec.Body.String())
+				return
+			}
+			var got []ethparser.Transaction
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, tt.result, got)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetBlockTransactions_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		number     string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "Wrong method", method: http.MethodPost, number: "42", wantCode: http.StatusMethodNotAllowed},
+		{name: "Non-numeric block", method: http.MethodGet, number: "abc", wantCode: http.StatusBadRequest},
+		{name: "Negative block", method: http.MethodGet, number: "-1", wantCode: http.StatusBadRequest},
+		{
+			name:       "Unexpected error",
+			method:     http.MethodGet,
+			number:     "42",
+			serviceErr: errors.New("repo error"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.serviceErr != nil {
+				mockParser.On("GetTransactionsInBlock", mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(tt.method, "/block/x/transactions", http.NoBody)
+			req.SetPathValue("number", tt.number)
+			rec := httptest.NewRecorder()
+			handler.HandleGetBlockTransactions(rec, req)
+
+			assertErrorResponse(t, rec, tt.wantCode)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetTransactions_BlockRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantFrom int64
+		wantTo   int64
+		result   []ethparser.Transaction
+	}{
+		{
+			name:     "Both bounds",
+			query:    "?from_block=10&to_block=20",
+			wantFrom: 10,
+			wantTo:   20,
+			result:   []ethparser.Transaction{{Hash: "0x1", BlockNumber: 15}},
+		},
+		{name: "Only lower bound", query: "?from_block=10", wantFrom: 10, wantTo: math.MaxInt64},
+		{name: "Only upper bound", query: "?to_block=20", wantFrom: 0, wantTo: 20},
+		{name: "Empty range result", query: "?from_block=5&to_block=5", wantFrom: 5, wantTo: 5, result: []ethparser.Transaction{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			mockParser.On("GetTransactionsInRange", mock.Anything, testAddress, tt.wantFrom, tt.wantTo).
+				Return(tt.result, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress+tt.query, http.NoBody)
+			req.SetPathValue("address", testAddress)
+			rec := httptest.NewRecorder()
+			handler.HandleGetTransactions(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var got []ethparser.Transaction
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Len(t, got, len(tt.result))
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetTransactions_InvalidBlockRange(t *testing.T) {
+	for _, query := range []string{"?from_block=-1", "?to_block=abc", "?from_block=20&to_block=10"} {
+		t.Run(query, func(t *testing.T) {
+			handler, _ := setupHandler(t)
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress+query, http.NoBody)
+			req.SetPathValue("address", testAddress)
+			rec := httptest.NewRecorder()
+			handler.HandleGetTransactions(rec, req)
+
+			assertErrorResponse(t, rec, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetTransactions_ValueQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantQuery ethparser.TransactionQuery
+	}{
+		{
+			name:      "Value threshold",
+			query:     "?min_value=0x10",
+			wantQuery: ethparser.TransactionQuery{ToBlock: math.MaxInt64, MinValue: "0x10"},
+		},
+		{
+			name:      "Sort order",
+			query:     "?sort=value_desc",
+			wantQuery: ethparser.TransactionQuery{ToBlock: math.MaxInt64, Sort: ethparser.SortValueDesc},
+		},
+		{
+			name:      "Combined with block range",
+			query:     "?from_block=10&to_block=20&min_value=100&sort=block_desc",
+			wantQuery: ethparser.TransactionQuery{FromBlock: 10, ToBlock: 20, MinValue: "100", Sort: ethparser.SortBlockDesc},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			mockParser.On("QueryTransactions", mock.Anything, testAddress, tt.wantQuery).
+				Return([]ethparser.Transaction{{Hash: "0x1", BlockNumber: 15}}, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress+tt.query, http.NoBody)
+			req.SetPathValue("address", testAddress)
+			rec := httptest.NewRecorder()
+			handler.HandleGetTransactions(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var got []ethparser.Transaction
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Len(t, got, 1)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetTransactions_InvalidValueQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		serviceErr error
+	}{
+		{name: "Bad sort order", query: "?sort=hash", serviceErr: ethparser.ErrInvalidSortOrder},
+		{name: "Bad min value", query: "?min_value=abc", serviceErr: domain.ErrInvalidWeiValueFormat},
+		{name: "Negative min value", query: "?min_value=-5", serviceErr: domain.ErrNegativeWeiValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			mockParser.On("QueryTransactions", mock.Anything, testAddress, mock.Anything).
+				Return(nil, fmt.Errorf("query validation failed: %w", tt.serviceErr))
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress+tt.query, http.NoBody)
+			req.SetPathValue("address", testAddress)
+			rec := httptest.NewRecorder()
+			handler.HandleGetTransactions(rec, req)
+
+			assertErrorResponse(t, rec, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetTransactions_Envelope(t *testing.T) {
+	txs := []ethparser.Transaction{
+		{Hash: "0x1", From: testAddress, To: stringPtr("0x2"), Value: "0x1", BlockNumber: 15, Direction: ethparser.DirectionOut},
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		accept       string
+		wantEnvelope bool
+	}{
+		{name: "Bare array by default", wantEnvelope: false},
+		{name: "Envelope query param", query: "?envelope=true", wantEnvelope: true},
+		{name: "Envelope disabled by query param", query: "?envelope=false", wantEnvelope: false},
+		{
+			name:         "Envelope Accept header",
+			accept:       "application/json, application/vnd.ethparser.envelope+json;q=0.9",
+			wantEnvelope: true,
+		},
+		{name: "Plain JSON Accept header", accept: "application/json", wantEnvelope: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			mockParser.On("GetTransactions", mock.Anything, testAddress).Return(txs, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress+tt.query, http.NoBody)
+			req.SetPathValue("address", testAddress)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.HandleGetTransactions(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			if !tt.wantEnvelope {
+				var got []ethparser.Transaction
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				assert.Equal(t, txs, got)
+				return
+			}
+			var got restapi.TransactionsEnvelope
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, restapi.TransactionsEnvelope{Address: testAddress, Count: 1, Transactions: txs}, got)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetTransactions_EnvelopeEchoesRange(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockParser.On("GetTransactionsInRange", mock.Anything, testAddress, int64(10), int64(math.MaxInt64)).
+		Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress+"?envelope=1&from_block=10", http.NoBody)
+	req.SetPathValue("address", testAddress)
+	rec := httptest.NewRecorder()
+	handler.HandleGetTransactions(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t,
+		`{"address":"`+testAddress+`","count":0,"fromBlock":10,"transactions":[]}`,
+		rec.Body.String())
+}
+
+func TestHTTPHandler_HandleGetTransactions_CanonicalizesAddress(t *testing.T) {
+	const mixedCase = "0x71C7656EC7ab88b098defB751B7401B5f6d8976F"
+
+	var logs bytes.Buffer
+	mockParser := mock_ethparser.NewParser(t)
+	handler, err := restapi.NewHTTPHandler(mockParser, applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&logs, nil))))
+	require.NoError(t, err)
+	mockParser.On("GetTransactions", mock.Anything, testAddress).Return([]ethparser.Transaction{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/"+mixedCase+"?envelope=true", http.NoBody)
+	req.SetPathValue("address", mixedCase)
+	rec := httptest.NewRecorder()
+	handler.HandleGetTransactions(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"address":"`+testAddress+`","count":0,"transactions":[]}`, rec.Body.String())
+	assert.Contains(t, logs.String(), `"address_param":"`+testAddress+`"`)
+	assert.NotContains(t, logs.String(), `"address_param":"`+mixedCase+`"`)
+}
+
+func TestHTTPHandler_HandleSubscribe_CanonicalizesAddresses(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockParser.On("SubscribeMany", mock.Anything, []string{testAddress, "0xinvalid"}, "").
+		Return([]ethparser.SubscribeResult{{Address: testAddress, Success: true}}, nil)
+
+	body := `{"addresses":["0x71C7656EC7AB88B098DEFB751B7401B5F6D8976F","0xinvalid"]}`
+	req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.HandleSubscribe(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHTTPHandler_HandleReplay_CanonicalizesAddress(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockParser.On("Replay", mock.Anything, testAddress, int64(7)).Return(nil)
+
+	body := `{"address":"0x71C7656EC7AB88B098DEFB751B7401B5F6D8976F","since":7}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/replay", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.HandleReplay(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp restapi.ReplayResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, testAddress, resp.Address)
+}
+
+func TestHTTPHandler_HandleGetTransactionsBatch(t *testing.T) {
+	const idleAddress = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	handler, mockParser := setupHandler(t)
+	txs := []ethparser.Transaction{
+		{Hash: "0x1", From: testAddress, To: stringPtr("0x2"), Value: "0x1", BlockNumber: 15, Direction: ethparser.DirectionOut},
+	}
+	mockParser.On("GetTransactionsForAddresses", mock.Anything, []string{testAddress, idleAddress}).
+		Return(map[string][]ethparser.Transaction{testAddress: txs, idleAddress: {}}, nil)
+
+	body := `{"addresses":["0x71C7656EC7AB88B098DEFB751B7401B5F6D8976F","` + idleAddress + `"]}`
+	req := httptest.NewRequest(http.MethodPost, "/transactions/batch", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.HandleGetTransactionsBatch(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got restapi.TransactionsBatchResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, txs, got.Transactions[testAddress])
+	assert.NotNil(t, got.Transactions[idleAddress])
+	assert.Empty(t, got.Transactions[idleAddress])
+}
+
+func TestHTTPHandler_HandleGetTransactionsBatch_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		serviceErr error
+		wantCode   int
+	}{
+		{name: "Wrong method", method: http.MethodGet, wantCode: http.StatusMethodNotAllowed},
+		{name: "Malformed body", method: http.MethodPost, body: `{"addresses":`, wantCode: http.StatusBadRequest},
+		{name: "Missing addresses", method: http.MethodPost, body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "Empty addresses", method: http.MethodPost, body: `{"addresses":[]}`, wantCode: http.StatusBadRequest},
+		{
+			name:     "Invalid address",
+			method:   http.MethodPost,
+			body:     `{"addresses":["` + testAddress + `","0xinvalid"]}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Address not subscribed",
+			method:     http.MethodPost,
+			body:       `{"addresses":["` + testAddress + `"]}`,
+			serviceErr: fmt.Errorf("%w: %s", ethparser.ErrAddressNotSubscribed, testAddress),
+			wantCode:   http.StatusNotFound,
+		},
+		{
+			name:       "Unexpected error",
+			method:     http.MethodPost,
+			body:       `{"addresses":["` + testAddress + `"]}`,
+			serviceErr: errors.New("repo error"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockParser := setupHandler(t)
+			if tt.serviceErr != nil {
+				mockParser.On("GetTransactionsForAddresses", mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(tt.method, "/transactions/batch", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.HandleGetTransactionsBatch(rec, req)
+
+			assertErrorResponse(t, rec, tt.wantCode)
+		})
+	}
+}
+
+func TestHTTPHandler_HandleGetTransactions_InvalidEnvelope(t *testing.T) {
+	handler, _ := setupHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/"+testAddress+"?envelope=maybe", http.NoBody)
+	req.SetPathValue("address", testAddress)
+	rec := httptest.NewRecorder()
+	handler.HandleGetTransactions(rec, req)
+
+	assertErrorResponse(t, rec, http.StatusBadRequest)
+}
+
+func TestHTTPHandler_HandleGetStats(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+
+	want := ethparser.ParserStats{
+		LastScannedBlock:    100,
+		NetworkHead:         105,
+		LagBlocks:           5,
+		SubscribedAddresses: 2,
+		TransactionsStored:  7,
+		UptimeSeconds:       60,
+	}
+	mockParser.On("Stats", mock.Anything).Return(want, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.HandleGetStats(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got ethparser.ParserStats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, want, got)
+}
+
+func TestHTTPHandler_HandleGetStats_Error(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockParser.On("Stats", mock.Anything).Return(ethparser.ParserStats{}, errors.New("node down"))
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.HandleGetStats(rec, req)
+
+	assertErrorResponse(t, rec, http.StatusInternalServerError)
+}
+
+func TestHTTPHandler_HandleGetLag(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockParser.On("Lag", mock.Anything).Return(int64(100), int64(105), int64(5), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/lag", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.HandleGetLag(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"current_block":100,"head_block":105,"lag":5}`, rec.Body.String())
+}
+
+func TestHTTPHandler_HandleGetLag_NodeDown(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockParser.On("Lag", mock.Anything).
+		Return(int64(100), int64(103), int64(3), fmt.Errorf("%w: %w", ethparser.ErrNodeUnavailable, errors.New("connection refused")))
+
+	req := httptest.NewRequest(http.MethodGet, "/lag", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.HandleGetLag(rec, req)
+
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var got restapi.LagResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, restapi.LagResponse{
+		CurrentBlock: 100,
+		HeadBlock:    103,
+		Lag:          3,
+		Error:        ethparser.ErrNodeUnavailable.Error(),
+	}, got)
+}
+
+func TestHTTPHandler_HandleGetLag_Error(t *testing.T) {
+	handler, mockParser := setupHandler(t)
+	mockParser.On("Lag", mock.Anything).Return(int64(0), int64(0), int64(0), errors.New("state error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/lag", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.HandleGetLag(rec, req)
+
+	assertErrorResponse(t, rec, http.StatusInternalServerError)
+}
+
+// assertErrorResponse checks the status code and that the body is a JSON error response.
+func assertErrorResponse(t *testing.T, rec *httptest.ResponseRecorder, wantCode int) {
+	t.Helper()
+	assert.Equal(t, wantCode, rec.Code)
+	var resp restapi.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.NotEmpty(t, resp.Error)
+}
+
+// stringPtr returns a pointer to s, for optional string DTO fields.
+func stringPtr(s string) *string {
+	return &s
+}
+
+// setupHandler is a helper that builds an HTTPHandler backed by a mocked parser service.
+func setupHandler(t *testing.T) (*restapi.HTTPHandler, *mock_ethparser.Parser) {
+	t.Helper()
+	mockParser := mock_ethparser.NewParser(t)
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	handler, err := restapi.NewHTTPHandler(mockParser, discardLogger)
+	require.NoError(t, err)
+
+	return handler, mockParser
+}
@@ -0,0 +1,38 @@
+package restapi
+
+import (
+	"net/http"
+	"strings"
+
+	"trust_wallet_homework/internal/logger"
+)
+
+// withConcurrencyLimit rejects requests with 503 Service Unavailable while limit requests are already in flight,
+// so a burst of clients cannot pile up work on the service and its storage. A non-positive limit disables it.
+// Server-Sent Events streams are long-lived and are not counted against the limit.
+func withConcurrencyLimit(next http.Handler, limit int, l logger.AppLogger) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+	slots := make(chan struct{}, limit)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isStreamPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			next.ServeHTTP(w, r)
+		default:
+			l.Warn("Rejecting request, too many requests in flight", "path", r.URL.Path, "limit", limit)
+			w.Header().Set("Retry-After", "1")
+			respondWithError(w, http.StatusServiceUnavailable, "Server is busy, retry later", l)
+		}
+	})
+}
+
+// isStreamPath reports whether path is a Server-Sent Events endpoint.
+func isStreamPath(path string) bool {
+	return strings.HasSuffix(path, "/stream")
+}
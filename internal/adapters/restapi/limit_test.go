@@ -0,0 +1,88 @@
+package restapi
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingHandler signals on entered for every request and holds it until release is closed.
+func blockingHandler(entered chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		entered <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestWithConcurrencyLimit_RejectsOverflowRequest(t *testing.T) {
+	const limit = 3
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	entered := make(chan struct{}, limit+1)
+	release := make(chan struct{})
+	handler := withConcurrencyLimit(blockingHandler(entered, release), limit, discardLogger)
+
+	var wg sync.WaitGroup
+	codes := make([]int, limit)
+	for i := range limit {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/transactions/0xabc", nil))
+			codes[i] = rr.Code
+		}()
+	}
+	for range limit {
+		<-entered
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/transactions/0xabc", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":"Server is busy, retry later"}`, rr.Body.String())
+
+	close(release)
+	wg.Wait()
+	for _, code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/transactions/0xabc", nil))
+	assert.Equal(t, http.StatusOK, rr.Code, "slots are freed once requests finish")
+}
+
+func TestWithConcurrencyLimit_StreamsAreNotCounted(t *testing.T) {
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	entered := make(chan struct{}, 2)
+	release := make(chan struct{})
+	handler := withConcurrencyLimit(blockingHandler(entered, release), 1, discardLogger)
+
+	done := make(chan int)
+	go func() {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/transactions/0xabc/stream", nil))
+		done <- rr.Code
+	}()
+	<-entered
+
+	go func() {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/stats", nil))
+		done <- rr.Code
+	}()
+	<-entered
+
+	close(release)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, http.StatusOK, <-done)
+}
@@ -0,0 +1,251 @@
+package restapi
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"time"
+
+	"trust_wallet_homework/internal/logger"
+)
+
+// quietAccessLogPaths are polled frequently by probes; their access log lines are emitted at debug level.
+var quietAccessLogPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// withAccessLog emits one structured log line per request with its status, response size and latency.
+func withAccessLog(next http.Handler, l logger.AppLogger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		aw := &accessLogResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(aw, r)
+
+		status := aw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		args := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", status,
+			"bytes", aw.bytes,
+			"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"remote_addr", r.RemoteAddr,
+		}
+		if quietAccessLogPaths[r.URL.Path] {
+			l.Debug("HTTP request", args...)
+			return
+		}
+		l.Info("HTTP request", args...)
+	})
+}
+
+// accessLogResponseWriter records the status code and the number of body bytes written.
+type accessLogResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// WriteHeader records the first status code sent.
+func (aw *accessLogResponseWriter) WriteHeader(code int) {
+	if aw.status == 0 {
+		aw.status = code
+	}
+	aw.ResponseWriter.WriteHeader(code)
+}
+
+// Write counts the bytes written to the client.
+func (aw *accessLogResponseWriter) Write(p []byte) (int, error) {
+	if aw.status == 0 {
+		aw.status = http.StatusOK
+	}
+	n, err := aw.ResponseWriter.Write(p)
+	aw.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher for streaming handlers.
+func (aw *accessLogResponseWriter) Flush() {
+	if f, ok := aw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (aw *accessLogResponseWriter) Unwrap() http.ResponseWriter {
+	return aw.ResponseWriter
+}
+
+// withCharset appends the charset parameter to JSON responses, e.g. "application/json; charset=utf-8".
+// An empty charset leaves the Content-Type header untouched.
+func withCharset(next http.Handler, charset string) http.Handler {
+	if charset == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&charsetResponseWriter{ResponseWriter: w, charset: charset}, r)
+	})
+}
+
+// charsetResponseWriter adds the charset to the JSON Content-Type just before the headers are sent.
+type charsetResponseWriter struct {
+	http.ResponseWriter
+	charset     string
+	wroteHeader bool
+}
+
+// WriteHeader sets the charset on JSON responses and sends the headers.
+func (cw *charsetResponseWriter) WriteHeader(code int) {
+	if !cw.wroteHeader {
+		cw.wroteHeader = true
+		if cw.Header().Get("Content-Type") == "application/json" {
+			cw.Header().Set("Content-Type", "application/json; charset="+cw.charset)
+		}
+	}
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+// Write sends the headers on first use, like http.ResponseWriter does.
+func (cw *charsetResponseWriter) Write(p []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher for streaming handlers.
+func (cw *charsetResponseWriter) Flush() {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (cw *charsetResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// withGzip compresses responses of at least minBytes when the client sends "Accept-Encoding: gzip".
+// A non-positive minBytes disables compression.
+func withGzip(next http.Handler, minBytes int) http.Handler {
+	if minBytes <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w, minBytes: minBytes, status: http.StatusOK}
+		defer gw.close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding header allows gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// gzipResponseWriter buffers the response until it is known whether it reaches the compression threshold.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minBytes    int
+	status      int
+	buf         []byte
+	gz          *gzip.Writer
+	headersSent bool
+}
+
+// WriteHeader records the status code; headers are sent once the encoding is decided.
+func (gw *gzipResponseWriter) WriteHeader(code int) {
+	if !gw.headersSent {
+		gw.status = code
+	}
+}
+
+// Write buffers small responses and switches to gzip once minBytes have been written.
+func (gw *gzipResponseWriter) Write(p []byte) (int, error) {
+	if gw.gz != nil {
+		return gw.gz.Write(p)
+	}
+	if gw.headersSent {
+		return gw.ResponseWriter.Write(p)
+	}
+
+	gw.buf = append(gw.buf, p...)
+	if len(gw.buf) < gw.minBytes {
+		return len(p), nil
+	}
+	if err := gw.startGzip(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush sends buffered data uncompressed if no encoding was chosen yet, so streams are not held back.
+func (gw *gzipResponseWriter) Flush() {
+	switch {
+	case gw.gz != nil:
+		_ = gw.gz.Flush()
+	case !gw.headersSent:
+		_ = gw.sendPlain()
+	}
+	if f, ok := gw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (gw *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return gw.ResponseWriter
+}
+
+// startGzip sends gzip headers and the buffered data through a gzip writer.
+func (gw *gzipResponseWriter) startGzip() error {
+	if gw.Header().Get("Content-Encoding") != "" {
+		return gw.sendPlain()
+	}
+	gw.Header().Set("Content-Encoding", "gzip")
+	gw.Header().Del("Content-Length")
+	gw.ResponseWriter.WriteHeader(gw.status)
+	gw.headersSent = true
+
+	gw.gz = gzip.NewWriter(gw.ResponseWriter)
+	_, err := gw.gz.Write(gw.buf)
+	gw.buf = nil
+	return err
+}
+
+// sendPlain sends the headers and the buffered data without compression.
+func (gw *gzipResponseWriter) sendPlain() error {
+	gw.ResponseWriter.WriteHeader(gw.status)
+	gw.headersSent = true
+	_, err := gw.ResponseWriter.Write(gw.buf)
+	gw.buf = nil
+	return err
+}
+
+// close finishes the response: it terminates the gzip stream or sends a small response uncompressed.
+func (gw *gzipResponseWriter) close() {
+	if gw.gz != nil {
+		_ = gw.gz.Close()
+		return
+	}
+	if !gw.headersSent {
+		_ = gw.sendPlain()
+	}
+}
@@ -0,0 +1,133 @@
+package restapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func jsonPayloadHandler(items int) http.Handler {
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		payload := make([]string, items)
+		for i := range payload {
+			payload[i] = strings.Repeat("a", 32)
+		}
+		respondWithJSON(w, http.StatusOK, payload, discardLogger)
+	})
+}
+
+func TestWithGzip_CompressesLargeResponse(t *testing.T) {
+	handler := withCharset(withGzip(jsonPayloadHandler(100), 256), "utf-8")
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"))
+
+	gz, err := gzip.NewReader(rr.Body)
+	require.NoError(t, err)
+	defer gz.Close()
+
+	var got []string
+	require.NoError(t, json.NewDecoder(gz).Decode(&got))
+	assert.Len(t, got, 100)
+	assert.Equal(t, strings.Repeat("a", 32), got[0])
+}
+
+func TestWithGzip_LeavesSmallResponseUncompressed(t *testing.T) {
+	handler := withGzip(jsonPayloadHandler(1), 256)
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	var got []string
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Len(t, got, 1)
+}
+
+func TestWithGzip_SkipsClientsWithoutGzip(t *testing.T) {
+	handler := withGzip(jsonPayloadHandler(100), 256)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/transactions", nil))
+
+	assert.Empty(t, rr.Header().Get("Content-Encoding"))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var got []string
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+	assert.Len(t, got, 100)
+}
+
+func TestWithCharset_EmptyCharsetKeepsContentType(t *testing.T) {
+	handler := withCharset(jsonPayloadHandler(1), "")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/transactions", nil))
+
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+}
+
+func TestWithAccessLog_LogsRequestSummary(t *testing.T) {
+	var buf bytes.Buffer
+	l := applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&buf, nil)))
+	handler := withAccessLog(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("short and stout"))
+	}), l)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/subscribe", nil))
+	require.Equal(t, http.StatusTeapot, rr.Code)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "HTTP request", entry["msg"])
+	assert.Equal(t, http.MethodPost, entry["method"])
+	assert.Equal(t, "/subscribe", entry["path"])
+	assert.EqualValues(t, http.StatusTeapot, entry["status"])
+	assert.EqualValues(t, len("short and stout"), entry["bytes"])
+	assert.Contains(t, entry, "duration_ms")
+}
+
+func TestWithAccessLog_DefaultsStatusToOK(t *testing.T) {
+	var buf bytes.Buffer
+	l := applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&buf, nil)))
+	handler := withAccessLog(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), l)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/stats", nil))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.EqualValues(t, http.StatusOK, entry["status"])
+	assert.EqualValues(t, 0, entry["bytes"])
+}
+
+func TestWithAccessLog_QuietPathsLogAtDebug(t *testing.T) {
+	var buf bytes.Buffer
+	l := applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	handler := withAccessLog(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), l)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Empty(t, buf.String(), "probe requests must not be logged at info level")
+}
@@ -0,0 +1,749 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mock_ethparser
+
+import (
+	context "context"
+	ethparser "trust_wallet_homework/pkg/ethparser"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Parser is an autogenerated mock type for the Parser type
+type Parser struct {
+	mock.Mock
+}
+
+// ExportTransactions provides a mock function with given fields: ctx, address, fn
+func (_m *Parser) ExportTransactions(ctx context.Context, address string, fn func(ethparser.Transaction) error) error {
+	ret := _m.Called(ctx, address, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTransactions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(ethparser.Transaction) error) error); ok {
+		r0 = rf(ctx, address, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirstScanCompleted provides a mock function with no fields
+func (_m *Parser) FirstScanCompleted() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirstScanCompleted")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// GetBlock provides a mock function with given fields: ctx, number
+func (_m *Parser) GetBlock(ctx context.Context, number int64) (*ethparser.Block, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlock")
+	}
+
+	var r0 *ethparser.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*ethparser.Block, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *ethparser.Block); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ethparser.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByHash provides a mock function with given fields: ctx, hash
+func (_m *Parser) GetBlockByHash(ctx context.Context, hash string) (*ethparser.Block, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByHash")
+	}
+
+	var r0 *ethparser.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ethparser.Block, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ethparser.Block); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ethparser.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrentBlock provides a mock function with given fields: ctx
+func (_m *Parser) GetCurrentBlock(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrentBlock")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNodeTransaction provides a mock function with given fields: ctx, hash
+func (_m *Parser) GetNodeTransaction(ctx context.Context, hash string) (*ethparser.Transaction, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNodeTransaction")
+	}
+
+	var r0 *ethparser.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ethparser.Transaction, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ethparser.Transaction); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ethparser.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionByHash provides a mock function with given fields: ctx, hash
+func (_m *Parser) GetTransactionByHash(ctx context.Context, hash string) (*ethparser.Transaction, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByHash")
+	}
+
+	var r0 *ethparser.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ethparser.Transaction, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ethparser.Transaction); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ethparser.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionCount provides a mock function with given fields: ctx, address
+func (_m *Parser) GetTransactionCount(ctx context.Context, address string) (int, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactions provides a mock function with given fields: ctx, address
+func (_m *Parser) GetTransactions(ctx context.Context, address string) ([]ethparser.Transaction, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactions")
+	}
+
+	var r0 []ethparser.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]ethparser.Transaction, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []ethparser.Transaction); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ethparser.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionsForAddresses provides a mock function with given fields: ctx, addresses
+func (_m *Parser) GetTransactionsForAddresses(ctx context.Context, addresses []string) (map[string][]ethparser.Transaction, error) {
+	ret := _m.Called(ctx, addresses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsForAddresses")
+	}
+
+	var r0 map[string][]ethparser.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string][]ethparser.Transaction, error)); ok {
+		return rf(ctx, addresses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string][]ethparser.Transaction); ok {
+		r0 = rf(ctx, addresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]ethparser.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, addresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionsInBlock provides a mock function with given fields: ctx, number
+func (_m *Parser) GetTransactionsInBlock(ctx context.Context, number int64) ([]ethparser.Transaction, error) {
+	ret := _m.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsInBlock")
+	}
+
+	var r0 []ethparser.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]ethparser.Transaction, error)); ok {
+		return rf(ctx, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []ethparser.Transaction); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ethparser.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionsInRange provides a mock function with given fields: ctx, address, from, to
+func (_m *Parser) GetTransactionsInRange(ctx context.Context, address string, from int64, to int64) ([]ethparser.Transaction, error) {
+	ret := _m.Called(ctx, address, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsInRange")
+	}
+
+	var r0 []ethparser.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) ([]ethparser.Transaction, error)); ok {
+		return rf(ctx, address, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) []ethparser.Transaction); ok {
+		r0 = rf(ctx, address, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ethparser.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, address, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lag provides a mock function with given fields: ctx
+func (_m *Parser) Lag(ctx context.Context) (int64, int64, int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lag")
+	}
+
+	var r0 int64
+	var r1 int64
+	var r2 int64
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, int64, int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) int64); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) int64); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Get(2).(int64)
+	}
+
+	if rf, ok := ret.Get(3).(func(context.Context) error); ok {
+		r3 = rf(ctx)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// LastActivity provides a mock function with given fields: ctx, address
+func (_m *Parser) LastActivity(ctx context.Context, address string) (int64, uint64, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastActivity")
+	}
+
+	var r0 int64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, uint64, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) uint64); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, address)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAddressesWithTransactions provides a mock function with given fields: ctx
+func (_m *Parser) ListAddressesWithTransactions(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAddressesWithTransactions")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSubscriptions provides a mock function with given fields: ctx, limit, offset
+func (_m *Parser) ListSubscriptions(ctx context.Context, limit int, offset int) ([]string, int, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubscriptions")
+	}
+
+	var r0 []string
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]string, int, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []string); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) int); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
+		r2 = rf(ctx, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PingStorage provides a mock function with given fields: ctx
+func (_m *Parser) PingStorage(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PingStorage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// QueryTransactions provides a mock function with given fields: ctx, address, query
+func (_m *Parser) QueryTransactions(ctx context.Context, address string, query ethparser.TransactionQuery) ([]ethparser.Transaction, error) {
+	ret := _m.Called(ctx, address, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryTransactions")
+	}
+
+	var r0 []ethparser.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ethparser.TransactionQuery) ([]ethparser.Transaction, error)); ok {
+		return rf(ctx, address, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ethparser.TransactionQuery) []ethparser.Transaction); ok {
+		r0 = rf(ctx, address, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ethparser.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ethparser.TransactionQuery) error); ok {
+		r1 = rf(ctx, address, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Replay provides a mock function with given fields: ctx, address, since
+func (_m *Parser) Replay(ctx context.Context, address string, since int64) error {
+	ret := _m.Called(ctx, address, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replay")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, address, since)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Rewind provides a mock function with given fields: ctx, block
+func (_m *Parser) Rewind(ctx context.Context, block int64) error {
+	ret := _m.Called(ctx, block)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rewind")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, block)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *Parser) Start(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Stats provides a mock function with given fields: ctx
+func (_m *Parser) Stats(ctx context.Context) (ethparser.ParserStats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 ethparser.ParserStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (ethparser.ParserStats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) ethparser.ParserStats); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(ethparser.ParserStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Stop provides a mock function with given fields: ctx
+func (_m *Parser) Stop(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stop")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: ctx, address, direction
+func (_m *Parser) Subscribe(ctx context.Context, address string, direction string) error {
+	ret := _m.Called(ctx, address, direction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, address, direction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscribeMany provides a mock function with given fields: ctx, addresses, direction
+func (_m *Parser) SubscribeMany(ctx context.Context, addresses []string, direction string) ([]ethparser.SubscribeResult, error) {
+	ret := _m.Called(ctx, addresses, direction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeMany")
+	}
+
+	var r0 []ethparser.SubscribeResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) ([]ethparser.SubscribeResult, error)); ok {
+		return rf(ctx, addresses, direction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) []ethparser.SubscribeResult); ok {
+		r0 = rf(ctx, addresses, direction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ethparser.SubscribeResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = rf(ctx, addresses, direction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerScan provides a mock function with given fields: ctx
+func (_m *Parser) TriggerScan(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TriggerScan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WatchTransactions provides a mock function with given fields: ctx, address
+func (_m *Parser) WatchTransactions(ctx context.Context, address string) (<-chan ethparser.Transaction, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchTransactions")
+	}
+
+	var r0 <-chan ethparser.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan ethparser.Transaction, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan ethparser.Transaction); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan ethparser.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewParser creates a new instance of Parser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewParser(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Parser {
+	mock := &Parser{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
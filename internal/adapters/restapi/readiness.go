@@ -0,0 +1,56 @@
+package restapi
+
+import (
+	"net/http"
+
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// WithReadinessGate makes data endpoints respond with 503 Service Unavailable until the parser
+// has completed its first scan, so clients do not mistake not-yet-indexed history for missing history.
+func WithReadinessGate(enabled bool) HandlerOption {
+	return func(h *HTTPHandler) {
+		h.readinessGate = enabled
+	}
+}
+
+// requireFirstScan wraps a data endpoint with the readiness gate, if it is enabled.
+func (h *HTTPHandler) requireFirstScan(next http.HandlerFunc) http.HandlerFunc {
+	if !h.readinessGate {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.parserService.FirstScanCompleted() {
+			requestLogger := h.getRequestLogger(r)
+			requestLogger.Warn("Rejecting request before the first scan completed")
+			respondWithError(w, http.StatusServiceUnavailable, "Parser is still completing its first scan", requestLogger)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// HandleReadyz handles requests to GET /readyz
+// It responds with 200 OK once the parser has completed its first scan and its storage is writable, and with
+// 503 Service Unavailable otherwise, as transactions found meanwhile could not be stored.
+func (h *HTTPHandler) HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	requestLogger := h.getRequestLogger(r)
+
+	if r.Method != http.MethodGet {
+		requestLogger.Warn("Method not allowed for Readyz")
+		respondWithError(w, http.StatusMethodNotAllowed, "Method Not Allowed", requestLogger)
+		return
+	}
+
+	resp := ReadinessResponse{Ready: h.parserService.FirstScanCompleted()}
+	if err := h.parserService.PingStorage(r.Context()); err != nil {
+		requestLogger.Warn("Not ready, storage is unavailable", "error", err)
+		resp.Ready = false
+		resp.Error = ethparser.ErrStorageUnavailable.Error()
+	}
+	code := http.StatusOK
+	if !resp.Ready {
+		code = http.StatusServiceUnavailable
+	}
+	respondWithJSON(w, code, resp, requestLogger)
+}
@@ -0,0 +1,135 @@
+package restapi
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/restapi/mocks/mock_ethparser"
+	"trust_wallet_homework/internal/config"
+	applogger "trust_wallet_homework/internal/logger"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newReadinessTestRouter(t *testing.T, gate bool) (http.Handler, *mock_ethparser.Parser) {
+	t.Helper()
+	mockParser := mock_ethparser.NewParser(t)
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	h, err := NewHTTPHandler(mockParser, discardLogger, WithReadinessGate(gate))
+	require.NoError(t, err)
+	return setupRouter(h, &config.ServerConfig{}), mockParser
+}
+
+func TestReadinessGate_DataEndpoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		gate      bool
+		firstScan bool
+		wantCode  int
+	}{
+		{name: "Gate rejects before the first scan", gate: true, firstScan: false, wantCode: http.StatusServiceUnavailable},
+		{name: "Gate passes after the first scan", gate: true, firstScan: true, wantCode: http.StatusOK},
+		{name: "Disabled gate always passes", gate: false, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockParser := newReadinessTestRouter(t, tt.gate)
+			if tt.gate {
+				mockParser.On("FirstScanCompleted").Return(tt.firstScan)
+			}
+			if tt.wantCode == http.StatusOK {
+				mockParser.On("GetCurrentBlock", mock.Anything).Return(int64(100), nil)
+			}
+
+			for _, path := range []string{"/current_block", "/v2/current_block"} {
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+				assert.Equal(t, tt.wantCode, rec.Code, path)
+			}
+		})
+	}
+}
+
+func TestReadinessGate_LeavesOtherEndpointsOpen(t *testing.T) {
+	const subscribeAddress = "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	router, mockParser := newReadinessTestRouter(t, true)
+	mockParser.On("FirstScanCompleted").Return(false).Maybe()
+	mockParser.On("Subscribe", mock.Anything, subscribeAddress, "").Return(nil)
+
+	rec := httptest.NewRecorder()
+	body := `{"address":"` + subscribeAddress + `"}`
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandleReadyz(t *testing.T) {
+	storageErr := fmt.Errorf("%w: transactions: disk full", ethparser.ErrStorageUnavailable)
+	tests := []struct {
+		name       string
+		firstScan  bool
+		storageErr error
+		wantCode   int
+		wantBody   string
+	}{
+		{name: "Ready", firstScan: true, wantCode: http.StatusOK, wantBody: `{"ready":true}`},
+		{
+			name:     "First scan pending",
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: `{"ready":false}`,
+		},
+		{
+			name:       "Storage not writable",
+			firstScan:  true,
+			storageErr: storageErr,
+			wantCode:   http.StatusServiceUnavailable,
+			wantBody:   `{"ready":false,"error":"storage is unavailable"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockParser := newReadinessTestRouter(t, false)
+			mockParser.On("FirstScanCompleted").Return(tt.firstScan)
+			mockParser.On("PingStorage", mock.Anything).Return(tt.storageErr)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", http.NoBody))
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRouter_BlockSubroutes(t *testing.T) {
+	const hash = "0x4242424242424242424242424242424242424242424242424242424242424242"
+
+	router, mockParser := newReadinessTestRouter(t, false)
+	mockParser.On("GetBlockByHash", mock.Anything, hash).Return(&ethparser.Block{Number: 42, Hash: hash}, nil)
+	mockParser.On("GetTransactionsInBlock", mock.Anything, int64(42)).Return([]ethparser.Transaction{}, nil)
+
+	tests := []struct {
+		path     string
+		wantCode int
+	}{
+		{path: "/block/hash/" + hash, wantCode: http.StatusOK},
+		{path: "/block/42/transactions", wantCode: http.StatusOK},
+		{path: "/block/42/other", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		assert.Equal(t, tt.wantCode, rec.Code, tt.path)
+	}
+}
@@ -14,13 +14,21 @@ import (
 
 // Server wraps the HTTP server and its dependencies.
 type Server struct {
-	httpServer *http.Server
-	service    ethparser.Parser
-	logger     logger.AppLogger
+	httpServer  *http.Server
+	service     ethparser.Parser
+	logger      logger.AppLogger
+	tlsCertFile string
+	tlsKeyFile  string
 }
 
-// NewServer creates a new instance of the REST API server.
-func NewServer(service ethparser.Parser, appLogger logger.AppLogger, cfg *config.ServerConfig) (*Server, error) {
+// NewServer creates a new instance of the REST API server. Options are applied to its handler after the
+// settings taken from cfg.
+func NewServer(
+	service ethparser.Parser,
+	appLogger logger.AppLogger,
+	cfg *config.ServerConfig,
+	opts ...HandlerOption,
+) (*Server, error) {
 	if service == nil {
 		return nil, errors.New("service cannot be nil for Server")
 	}
@@ -31,16 +39,23 @@ func NewServer(service ethparser.Parser, appLogger logger.AppLogger, cfg *config
 		return nil, errors.New("config cannot be nil for Server")
 	}
 
-	h, err := NewHTTPHandler(service, appLogger)
+	handlerOpts := append([]HandlerOption{
+		WithMaxBodyBytes(cfg.MaxBodyBytes),
+		WithReadinessGate(cfg.WaitForFirstScan),
+		WithStreamWriteTimeout(time.Duration(cfg.StreamWriteTimeoutSeconds) * time.Second),
+		WithStreamHeartbeat(time.Duration(cfg.StreamHeartbeatSeconds) * time.Second),
+		WithStreamBufferSize(cfg.StreamBufferSize),
+	}, opts...)
+	h, err := NewHTTPHandler(service, appLogger, handlerOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize handler: %w", err)
 	}
 
-	smux := setupRouter(h, cfg.Port)
+	router := setupRouter(h, cfg)
 
 	server := &http.Server{
 		Addr:              cfg.Port,
-		Handler:           smux,
+		Handler:           router,
 		ReadTimeout:       time.Duration(cfg.ReadTimeoutSeconds) * time.Second,
 		WriteTimeout:      time.Duration(cfg.WriteTimeoutSeconds) * time.Second,
 		IdleTimeout:       time.Duration(cfg.IdleTimeoutSeconds) * time.Second,
@@ -48,14 +63,27 @@ func NewServer(service ethparser.Parser, appLogger logger.AppLogger, cfg *config
 	}
 
 	return &Server{
-		httpServer: server,
-		service:    service,
-		logger:     appLogger,
+		httpServer:  server,
+		service:     service,
+		logger:      appLogger,
+		tlsCertFile: cfg.TLSCertFile,
+		tlsKeyFile:  cfg.TLSKeyFile,
 	}, nil
 }
 
-// Start runs the HTTP server.
+// Start runs the HTTP server. It serves HTTPS when both a TLS certificate and key file are configured,
+// and plain HTTP otherwise.
 func (s *Server) Start() error {
+	if s.tlsCertFile != "" && s.tlsKeyFile != "" {
+		s.logger.Info("HTTPS server starting", "address", s.httpServer.Addr, "certFile", s.tlsCertFile)
+		err := s.httpServer.ListenAndServeTLS(s.tlsCertFile, s.tlsKeyFile)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP server ListenAndServeTLS error", "error", err)
+			return err
+		}
+		return nil
+	}
+
 	s.logger.Info("HTTP server starting", "address", s.httpServer.Addr)
 	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		s.logger.Error("HTTP server ListenAndServe error", "error", err)
@@ -75,21 +103,90 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-// setupRouter creates a new ServeMux and registers all API handlers.
-func setupRouter(h *HTTPHandler, port string) *http.ServeMux {
+// blockSubrouteHandler serves GET /block/hash/{hash} and GET /block/{number}/transactions. ServeMux rejects
+// registering both patterns because "/block/hash/transactions" would match either, so they share one pattern.
+func blockSubrouteHandler(byHash, transactions http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.PathValue("number") == "hash":
+			r.SetPathValue("hash", r.PathValue("sub"))
+			byHash(w, r)
+		case r.PathValue("sub") == "transactions":
+			transactions(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// setupRouter creates a new ServeMux, registers all API handlers and wraps it with the concurrency limit,
+// response, CORS (when origins are configured) and access log middleware.
+func setupRouter(h *HTTPHandler, cfg *config.ServerConfig) http.Handler {
 	smux := http.NewServeMux()
 
-	smux.HandleFunc("/current_block", h.HandleGetCurrentBlock)
+	smux.HandleFunc("/current_block", h.requireFirstScan(h.HandleGetCurrentBlock))
+	smux.HandleFunc("/readyz", h.HandleReadyz)
+	smux.HandleFunc("/stats", h.HandleGetStats)
+	smux.HandleFunc("/lag", h.HandleGetLag)
 	smux.HandleFunc("/subscribe", h.HandleSubscribe)
-	smux.HandleFunc("/transactions/{address}", h.HandleGetTransactions)
+	smux.HandleFunc("/block/{number}", h.HandleGetBlock)
+	smux.HandleFunc("/block/{number}/{sub}", blockSubrouteHandler(
+		h.HandleGetBlockByHash,
+		h.requireFirstScan(h.HandleGetBlockTransactions),
+	))
+	smux.HandleFunc("/transaction/{hash}", h.requireFirstScan(h.HandleGetTransaction))
+	smux.HandleFunc("/node/transaction/{hash}", h.HandleGetNodeTransaction)
+	smux.HandleFunc("/transactions/batch", h.requireFirstScan(h.HandleGetTransactionsBatch))
+	smux.HandleFunc("/transactions/{address}", h.requireFirstScan(h.HandleGetTransactions))
+	smux.HandleFunc("/transactions/{address}/count", h.requireFirstScan(h.HandleGetTransactionCount))
+	smux.HandleFunc("/transactions/{address}/stream", h.HandleStreamTransactions)
+	smux.HandleFunc("/subscriptions", h.HandleListSubscriptions)
+	smux.HandleFunc("/subscriptions/{address}/last_activity", h.requireFirstScan(h.HandleGetLastActivity))
+	smux.HandleFunc("/addresses", h.requireFirstScan(h.HandleListAddresses))
+	smux.HandleFunc("/export", h.requireFirstScan(h.HandleExport))
+	if cfg.AdminEnabled {
+		smux.HandleFunc("/admin/rewind", h.HandleRewind)
+		smux.HandleFunc("/admin/scan", h.HandleTriggerScan)
+		smux.HandleFunc("/admin/replay", h.HandleReplay)
+		smux.HandleFunc("/debug/config", h.HandleDebugConfig)
+	}
+	smux.Handle("/v2/", withAPIVersion(http.StripPrefix("/v2", smux), apiV2))
 
 	h.logger.Info("-------------------------------------")
-	h.logger.Info("API Server starting", "address", port)
+	h.logger.Info("API Server starting", "address", cfg.Port)
 	h.logger.Info("Available Endpoints:")
 	h.logger.Info("  GET  /current_block")
-	h.logger.Info("  POST /subscribe       (Body: {'address':'0x...'})")
+	h.logger.Info("  GET  /readyz")
+	h.logger.Info("  GET  /stats")
+	h.logger.Info("  GET  /lag")
+	h.logger.Info("  POST /subscribe       (Body: {'address':'0x...'} or {'addresses':['0x...']})")
+	h.logger.Info("  GET  /block/{number}")
+	h.logger.Info("  GET  /block/{number}/transactions")
+	h.logger.Info("  GET  /block/hash/{hash}")
+	h.logger.Info("  GET  /transaction/{hash}")
+	h.logger.Info("  GET  /subscriptions       (Query: limit, offset)")
 	h.logger.Info("  GET  /transactions/{address}")
+	h.logger.Info("  POST /transactions/batch (Body: {'addresses':['0x...']})")
+	h.logger.Info("  GET  /transactions/{address}/count")
+	h.logger.Info("  GET  /transactions/{address}/stream (Server-Sent Events)")
+	h.logger.Info("  GET  /addresses")
+	if cfg.AdminEnabled {
+		h.logger.Info("  POST /admin/rewind    (Body: {'block':N})")
+		h.logger.Info("  POST /admin/scan")
+		h.logger.Info("  POST /admin/replay    (Body: {'address':'0x...','since':N})")
+		h.logger.Info("  GET  /debug/config")
+	}
+	h.logger.Info("All endpoints are also served under /v2/ with snake_case JSON fields.")
 	h.logger.Info("-------------------------------------")
 
-	return smux
+	limited := withConcurrencyLimit(smux, cfg.MaxConcurrentRequests, h.logger)
+	handler := withCharset(withGzip(limited, cfg.GzipMinBytes), cfg.ContentTypeCharset)
+	if len(cfg.CORSAllowedOrigins) > 0 {
+		handler = withCORS(handler, corsPolicy{
+			origins: cfg.CORSAllowedOrigins,
+			methods: cfg.CORSAllowedMethods,
+			headers: cfg.CORSAllowedHeaders,
+		})
+	}
+	return withAccessLog(handler, h.logger)
 }
@@ -0,0 +1,135 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// Default settings of Server-Sent Events streams, used when none are configured.
+const (
+	defaultStreamWriteTimeout = 10 * time.Second
+	defaultStreamHeartbeat    = 15 * time.Second
+	defaultStreamBufferSize   = 64
+)
+
+// sseKeepalive is the comment line sent on idle streams. Clients ignore it, but it keeps proxies from closing
+// the connection and reveals clients that went away without closing it.
+const sseKeepalive = ": keepalive\n\n"
+
+// WithStreamWriteTimeout bounds each write to a streaming client; a client that does not accept an event
+// within it is disconnected. A non-positive timeout keeps the default.
+func WithStreamWriteTimeout(timeout time.Duration) HandlerOption {
+	return func(h *HTTPHandler) {
+		if timeout > 0 {
+			h.streamWriteTimeout = timeout
+		}
+	}
+}
+
+// WithStreamHeartbeat sets how often a keepalive comment is sent on a stream with no events.
+// A non-positive interval keeps the default.
+func WithStreamHeartbeat(interval time.Duration) HandlerOption {
+	return func(h *HTTPHandler) {
+		if interval > 0 {
+			h.streamHeartbeat = interval
+		}
+	}
+}
+
+// WithStreamBufferSize sets the number of events queued for each streaming client. A client that falls so far
+// behind that its queue is full is disconnected instead of having events silently dropped.
+// A non-positive size keeps the default.
+func WithStreamBufferSize(size int) HandlerOption {
+	return func(h *HTTPHandler) {
+		if size > 0 {
+			h.streamBufferSize = size
+		}
+	}
+}
+
+// sseWriter writes Server-Sent Events to one client, bounding every write by a deadline.
+type sseWriter struct {
+	w            http.ResponseWriter
+	rc           *http.ResponseController
+	writeTimeout time.Duration
+}
+
+// newSSEWriter creates a writer for the response.
+func newSSEWriter(w http.ResponseWriter, writeTimeout time.Duration) *sseWriter {
+	return &sseWriter{w: w, rc: http.NewResponseController(w), writeTimeout: writeTimeout}
+}
+
+// open sends the event stream response headers.
+func (s *sseWriter) open() error {
+	s.w.Header().Set("Content-Type", "text/event-stream")
+	s.w.Header().Set("Cache-Control", "no-cache")
+	s.w.Header().Set("Connection", "keep-alive")
+	if err := s.setDeadline(); err != nil {
+		return err
+	}
+	s.w.WriteHeader(http.StatusOK)
+	return s.rc.Flush()
+}
+
+// send writes chunk and flushes it to the client.
+func (s *sseWriter) send(chunk string) error {
+	if err := s.setDeadline(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, chunk); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// drop disconnects a client that is not keeping up. Moving the write deadline to the past makes writing the end
+// of the response fail, so the server closes the connection instead of keeping it alive for further requests.
+func (s *sseWriter) drop() {
+	_ = s.rc.SetWriteDeadline(time.Now())
+}
+
+// setDeadline replaces the server write timeout, which would end a long-lived stream, by a deadline for the
+// next write only.
+func (s *sseWriter) setDeadline() error {
+	err := s.rc.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// queueStreamEvents copies events into a queue of the given size until ctx is done. The queue is closed
+// when events is closed; overflow is closed instead, and copying stops, when the client is not keeping up.
+func queueStreamEvents(
+	ctx context.Context,
+	events <-chan ethparser.Transaction,
+	size int,
+) (queue <-chan ethparser.Transaction, overflow <-chan struct{}) {
+	q := make(chan ethparser.Transaction, size)
+	full := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					close(q)
+					return
+				}
+				select {
+				case q <- event:
+				default:
+					close(full)
+					return
+				}
+			}
+		}
+	}()
+	return q, full
+}
@@ -0,0 +1,97 @@
+package restapi_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/adapters/restapi"
+	"trust_wallet_homework/internal/adapters/restapi/mocks/mock_ethparser"
+	applogger "trust_wallet_homework/internal/logger"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newStreamServer serves the transaction stream of testAddress from txs and closes handlerDone when the
+// stream handler returns.
+func newStreamServer(
+	t *testing.T,
+	txs chan ethparser.Transaction,
+	opts ...restapi.HandlerOption,
+) (server *httptest.Server, handlerDone <-chan struct{}) {
+	t.Helper()
+	mockParser := mock_ethparser.NewParser(t)
+	mockParser.On("WatchTransactions", mock.Anything, testAddress).Return((<-chan ethparser.Transaction)(txs), nil)
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler, err := restapi.NewHTTPHandler(mockParser, discardLogger, opts...)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/transactions/{address}/stream", func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		handler.HandleStreamTransactions(w, r)
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, done
+}
+
+func TestHTTPHandler_HandleStreamTransactions_Heartbeat(t *testing.T) {
+	server, _ := newStreamServer(t, make(chan ethparser.Transaction), restapi.WithStreamHeartbeat(20*time.Millisecond))
+
+	resp, err := server.Client().Get(server.URL + "/transactions/" + testAddress + "/stream")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": keepalive\n", line)
+}
+
+func TestHTTPHandler_HandleStreamTransactions_DisconnectsNonReadingClient(t *testing.T) {
+	txs := make(chan ethparser.Transaction)
+	server, handlerDone := newStreamServer(t, txs,
+		restapi.WithStreamWriteTimeout(100*time.Millisecond),
+		restapi.WithStreamBufferSize(4),
+	)
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	_, err = fmt.Fprintf(conn, "GET /transactions/%s/stream HTTP/1.1\r\nHost: test\r\n\r\n", testAddress)
+	require.NoError(t, err)
+
+	// Publish large transactions to a client that never reads, until the server gives up on it.
+	large := ethparser.Transaction{Hash: "0x1", From: testAddress, Value: "0x" + strings.Repeat("f", 64<<10)}
+	go func() {
+		for {
+			select {
+			case txs <- large:
+			case <-handlerDone:
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-handlerDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream handler did not disconnect the non-reading client")
+	}
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = io.Copy(io.Discard, conn)
+	assert.NoError(t, err, "the server should close the connection")
+}
@@ -0,0 +1,72 @@
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// requestValidator enforces the `validate` struct tags of request DTOs.
+// A validator instance caches struct metadata and is safe for concurrent use.
+var requestValidator = newRequestValidator()
+
+// newRequestValidator creates a validator that reports fields by their JSON names.
+func newRequestValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// validateRequest checks req against its struct tags and returns one FieldError per violated field.
+// It returns an error only when req cannot be validated at all.
+func validateRequest(req any) ([]FieldError, error) {
+	err := requestValidator.Struct(req)
+	if err == nil {
+		return nil, nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil, fmt.Errorf("failed to validate request: %w", err)
+	}
+
+	fieldErrs := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fieldErrs = append(fieldErrs, FieldError{Field: fe.Field(), Error: fieldErrorMessage(fe)})
+	}
+	return fieldErrs, nil
+}
+
+// fieldErrorMessage describes a failed validation rule in plain words.
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_without":
+		return "is required"
+	case "eth_addr":
+		return "must be a valid Ethereum address"
+	case "oneof":
+		return "must be one of: " + fe.Param()
+	case "min":
+		return "must contain at least " + fe.Param() + " entries"
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
+
+// validationMessage summarizes field errors into a single human-readable message.
+func validationMessage(fieldErrs []FieldError) string {
+	parts := make([]string, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		parts = append(parts, fe.Field+" "+fe.Error)
+	}
+	return "Invalid request: " + strings.Join(parts, "; ")
+}
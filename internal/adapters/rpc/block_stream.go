@@ -0,0 +1,383 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/client"
+)
+
+// Compile-time check to ensure EthereumNodeAdapter implements client.BlockStreamer
+var _ client.BlockStreamer = (*EthereumNodeAdapter)(nil)
+
+// StreamBlockTransactions fetches a block by its number and passes its transactions to fn one at a time as they
+// are decoded from the response, so a large block is never held in memory as a whole.
+// The returned block has no transactions; a nil block means the node has no such block yet.
+// The first error returned by fn stops the stream and is returned as is. Only a failure to reach an endpoint
+// fails over to the next one, as transactions already passed to fn cannot be taken back.
+// With debug logging enabled only the request body is logged.
+func (a *EthereumNodeAdapter) StreamBlockTransactions(
+	ctx context.Context,
+	blockNumber domain.BlockNumber,
+	fn func(domain.Transaction) error,
+) (*domain.Block, error) {
+	const method = "eth_getBlockByNumber"
+	if err := a.checkMethod(method); err != nil {
+		return nil, err
+	}
+
+	jsonReqBody, err := json.Marshal(JSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  []interface{}{fmt.Sprintf("0x%x", blockNumber.Value()), true},
+		ID:      a.requestID.Add(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RPC request: %w", err)
+	}
+
+	candidates := a.endpoints.candidates()
+	if len(candidates) == 0 {
+		return nil, errors.New("no RPC endpoints configured")
+	}
+
+	var lastErr error
+	for _, rpcURL := range candidates {
+		body, err := a.openStream(ctx, rpcURL, method, jsonReqBody)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			if a.endpoints.markFailure(rpcURL) {
+				a.logger.Warn("RPC endpoint marked unhealthy",
+					"endpoint", redactEndpoint(rpcURL),
+					"cooldown", endpointCooldown.String())
+			}
+			a.logger.Warn("RPC call failed, trying next endpoint",
+				"method", method,
+				"endpoint", redactEndpoint(rpcURL),
+				"error", err)
+			lastErr = err
+			continue
+		}
+
+		delivered := 0
+		block, skipped, rpcErr, err := decodeBlockStream(body, a.parseMode, func(tx domain.Transaction) error {
+			delivered++
+			return fn(tx)
+		})
+		if errClose := body.Close(); errClose != nil {
+			log.Printf("[WARN] Failed to close response body in StreamBlockTransactions: %v", errClose)
+		}
+		if rpcErr != nil && rpcErr.IsRateLimited() && delivered == 0 {
+			a.endpoints.markFailure(rpcURL)
+			a.logger.Warn("RPC call rate limited, trying next endpoint",
+				"method", method,
+				"endpoint", redactEndpoint(rpcURL),
+				"error", rpcErr)
+			lastErr = rpcErr
+			continue
+		}
+		a.endpoints.markSuccess(rpcURL)
+		a.health.recordSuccess(a.logger)
+		if rpcErr != nil {
+			return nil, fmt.Errorf("RPC call failed: %w", rpcErr)
+		}
+		if err != nil {
+			return nil, err
+		}
+		a.logSkippedTransactions(block, skipped)
+		return block, nil
+	}
+
+	a.health.recordFailure(a.logger, lastErr)
+	return nil, fmt.Errorf("RPC call failed: all RPC endpoints failed: %w", lastErr)
+}
+
+// openStream sends an encoded JSON-RPC payload to a single endpoint and returns the response body unread.
+// Closing the body also releases the per-call timeout.
+func (a *EthereumNodeAdapter) openStream(
+	ctx context.Context,
+	rpcURL string,
+	method string,
+	jsonReqBody []byte,
+) (io.ReadCloser, error) {
+	cancel := context.CancelFunc(func() {})
+	if a.callTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, a.callTimeout)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewBuffer(jsonReqBody))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	acceptGzip(httpReq)
+	a.applyAuth(httpReq, rpcURL)
+	a.logRequestPayload(rpcURL, method, jsonReqBody)
+
+	started := time.Now()
+	httpResp, err := a.httpClient.Do(httpReq)
+	a.latency.observe(method, time.Since(started))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to execute HTTP request: %w", redactURLError(err))
+	}
+
+	body, err := decodedBody(httpResp)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(body)
+		_ = body.Close()
+		cancel()
+		return nil, fmt.Errorf("HTTP request failed with status %s: %s", httpResp.Status, string(bodyBytes))
+	}
+
+	return &cancelOnClose{ReadCloser: body, cancel: cancel}, nil
+}
+
+// cancelOnClose is a response body that cancels its request context once closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the response body and then cancels the call context, releasing its timeout.
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// logSkippedTransactions counts and logs the malformed transactions left out of a block in lenient mode.
+func (a *EthereumNodeAdapter) logSkippedTransactions(block *domain.Block, skipped []error) {
+	if len(skipped) == 0 {
+		return
+	}
+	total := a.skippedTxs.Add(int64(len(skipped)))
+	a.logger.Warn("Skipped malformed transactions in block",
+		"blockNumber", block.Number.Value(),
+		"skipped", len(skipped),
+		"skippedTotal", total,
+		"errors", errors.Join(skipped...))
+}
+
+// decodeBlockStream decodes an eth_getBlockByNumber response token by token, passing each transaction to fn.
+// An error reported by the node is returned as rpcErr. A null result yields a nil block.
+func decodeBlockStream(
+	r io.Reader,
+	mode parseMode,
+	fn func(domain.Transaction) error,
+) (block *domain.Block, skipped []error, rpcErr *RPCError, err error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode RPC response: %w", err)
+	}
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to decode RPC response: %w", err)
+		}
+		switch key {
+		case "result":
+			block, skipped, err = decodeStreamedBlock(dec, mode, fn)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		case "error":
+			var rpcError *Error
+			if err := dec.Decode(&rpcError); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to decode RPC error: %w", err)
+			}
+			if rpcError != nil {
+				rpcErr = &RPCError{Code: rpcError.Code, Message: rpcError.Message}
+			}
+		default:
+			if err := skipValue(dec); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to decode RPC response: %w", err)
+			}
+		}
+	}
+	if rpcErr != nil {
+		return nil, nil, rpcErr, nil
+	}
+	return block, skipped, nil, nil
+}
+
+// decodeStreamedBlock decodes the block object of a response, passing each mapped transaction to fn.
+// Transactions are mapped as soon as the block number, hash and timestamp are known. Nodes usually send these
+// fields before the transactions; otherwise the transactions are held back until the end of the block.
+// The base fee is added to the header wherever it appears.
+func decodeStreamedBlock(
+	dec *json.Decoder,
+	mode parseMode,
+	fn func(domain.Transaction) error,
+) (*domain.Block, []error, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode block result: %w", err)
+	}
+	if tok == nil {
+		return nil, nil, nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("failed to decode block result: unexpected token %v", tok)
+	}
+
+	var (
+		rpcHeader Block
+		seen      int
+		header    *domain.Block
+		decoded   int
+		txIndex   int
+		pending   []Transaction
+		skipped   []error
+	)
+	// deliver maps a transaction against the known header and passes it to fn.
+	deliver := func(rpcTx *Transaction) error {
+		tx, skipErr, err := mapRPCBlockTransaction(rpcTx, txIndex, *header, mode)
+		txIndex++
+		if err != nil {
+			return err
+		}
+		if skipErr != nil {
+			skipped = append(skipped, skipErr)
+			return nil
+		}
+		return fn(*tx)
+	}
+	// headerReady maps the header once its number, hash and timestamp were all decoded.
+	headerReady := func() (bool, error) {
+		if header != nil {
+			return true, nil
+		}
+		if seen < 3 {
+			return false, nil
+		}
+		h, err := mapRPCBlockHeader(&rpcHeader)
+		if err != nil {
+			return false, err
+		}
+		header = &h
+		return true, nil
+	}
+
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode block result: %w", err)
+		}
+		switch key {
+		case "number", "hash", "timestamp":
+			var value string
+			if err := dec.Decode(&value); err != nil {
+				return nil, nil, fmt.Errorf("failed to decode block %s: %w", key, err)
+			}
+			switch key {
+			case "number":
+				rpcHeader.Number = value
+			case "hash":
+				rpcHeader.Hash = value
+			default:
+				rpcHeader.Timestamp = value
+			}
+			seen++
+		case "baseFeePerGas":
+			if err := dec.Decode(&rpcHeader.BaseFeePerGas); err != nil {
+				return nil, nil, fmt.Errorf("failed to decode block %s: %w", key, err)
+			}
+		case "transactions":
+			if err := expectDelim(dec, '['); err != nil {
+				return nil, nil, fmt.Errorf("failed to decode block transactions: %w", err)
+			}
+			for dec.More() {
+				var rpcTx Transaction
+				if err := dec.Decode(&rpcTx); err != nil {
+					return nil, nil, fmt.Errorf("failed to decode block transaction at index %d: %w", decoded, err)
+				}
+				decoded++
+				ready, err := headerReady()
+				if err != nil {
+					return nil, nil, err
+				}
+				if !ready {
+					pending = append(pending, rpcTx)
+					continue
+				}
+				if err := deliver(&rpcTx); err != nil {
+					return nil, nil, err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return nil, nil, fmt.Errorf("failed to decode block transactions: %w", err)
+			}
+		default:
+			if err := skipValue(dec); err != nil {
+				return nil, nil, fmt.Errorf("failed to decode block %s: %w", key, err)
+			}
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode block result: %w", err)
+	}
+
+	if header == nil {
+		h, err := mapRPCBlockHeader(&rpcHeader)
+		if err != nil {
+			return nil, nil, err
+		}
+		header = &h
+	} else if header.BaseFeePerGas, err = mapRPCBaseFee(&rpcHeader); err != nil {
+		// The header was mapped as soon as it could identify the block; the base fee may have followed.
+		return nil, nil, err
+	}
+	for i := range pending {
+		if err := deliver(&pending[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return header, skipped, nil
+}
+
+// decodeKey reads the next object key.
+func decodeKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected object key, got %v", tok)
+	}
+	return key, nil
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// skipValue reads and discards the next value.
+func skipValue(dec *json.Decoder) error {
+	var discard json.RawMessage
+	return dec.Decode(&discard)
+}
@@ -0,0 +1,246 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/rpc"
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	syntheticBlockHeader = `"number":"0x2a",` +
+		`"hash":"0x2222222222222222222222222222222222222222222222222222222222222222",` +
+		`"timestamp":"0x5"`
+	syntheticBlockExtra = `"miner":"0xcccccccccccccccccccccccccccccccccccccccc","uncles":[],"gasUsed":"0x5208"`
+	syntheticBaseFee    = `"baseFeePerGas":"0x2540be400"`
+	syntheticFeeFields  = `"type":"0x2","gasPrice":"0x2540be401","maxFeePerGas":"0x4a817c800",` +
+		`"maxPriorityFeePerGas":"0x1"`
+)
+
+// syntheticTransactions returns n JSON-encoded transactions with distinct hashes and senders.
+// When withBad is set, every tenth transaction has a malformed hash.
+func syntheticTransactions(n int, withBad bool) []string {
+	txs := make([]string, n)
+	for i := range txs {
+		hash := fmt.Sprintf("0x%064x", i+1)
+		if withBad && i%10 == 9 {
+			hash = "0xbad"
+		}
+		txs[i] = fmt.Sprintf(`{"hash":%q,"from":"0x%040x","to":"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",`+
+			`"value":"0x%x","transactionIndex":"0x%x","input":"0x","gas":"0x5208","nonce":"0x%x"}`,
+			hash, i+1, i+1, i, i)
+	}
+	return txs
+}
+
+// syntheticBlockResponse wraps transactions in an eth_getBlockByNumber response. When headerLast is set,
+// the block number, hash and timestamp follow the transactions.
+func syntheticBlockResponse(txs []string, headerLast bool) string {
+	return syntheticBlockResponseWithExtra(txs, headerLast, syntheticBlockExtra)
+}
+
+// syntheticDynamicFeeBlockResponse is syntheticBlockResponse for a post-London block of EIP-1559 transactions.
+// The base fee sits between the header and the transactions, so it follows the header unless headerLast is set.
+func syntheticDynamicFeeBlockResponse(txs []string, headerLast bool) string {
+	dynamic := make([]string, len(txs))
+	for i, tx := range txs {
+		dynamic[i] = "{" + syntheticFeeFields + "," + strings.TrimPrefix(tx, "{")
+	}
+	return syntheticBlockResponseWithExtra(dynamic, headerLast, syntheticBlockExtra+","+syntheticBaseFee)
+}
+
+func syntheticBlockResponseWithExtra(txs []string, headerLast bool, extra string) string {
+	transactions := `"transactions":[` + strings.Join(txs, ",") + `]`
+	fields := []string{syntheticBlockHeader, extra, transactions}
+	if headerLast {
+		fields = []string{transactions, extra, syntheticBlockHeader}
+	}
+	return `{"jsonrpc":"2.0","id":1,"result":{` + strings.Join(fields, ",") + `}}`
+}
+
+// staticServer answers every request with body.
+func staticServer(tb testing.TB, body string) *httptest.Server {
+	tb.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	tb.Cleanup(server.Close)
+	return server
+}
+
+// streamAll collects the header and transactions of a streamed block.
+func streamAll(t *testing.T, adapter *rpc.EthereumNodeAdapter, blockNum domain.BlockNumber) (*domain.Block, error) {
+	t.Helper()
+	var txs []domain.Transaction
+	block, err := adapter.StreamBlockTransactions(context.Background(), blockNum, func(tx domain.Transaction) error {
+		txs = append(txs, tx)
+		return nil
+	})
+	if block != nil {
+		block.Transactions = txs
+	}
+	return block, err
+}
+
+func TestEthereumNodeAdapter_StreamBlockTransactions_MatchesFullDecode(t *testing.T) {
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		withBad    bool
+		headerLast bool
+		dynamicFee bool
+	}{
+		{name: "Header before transactions"},
+		{name: "Header after transactions", headerLast: true},
+		{name: "Malformed transactions are skipped", withBad: true},
+		{name: "Malformed transactions with header last", withBad: true, headerLast: true},
+		{name: "Dynamic fee block", dynamicFee: true},
+		{name: "Dynamic fee block with header last", dynamicFee: true, headerLast: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := syntheticBlockResponse(syntheticTransactions(50, tt.withBad), tt.headerLast)
+			if tt.dynamicFee {
+				response = syntheticDynamicFeeBlockResponse(syntheticTransactions(50, tt.withBad), tt.headerLast)
+			}
+			server := staticServer(t, response)
+			adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+			want, err := adapter.GetBlockWithTransactions(context.Background(), blockNum)
+			require.NoError(t, err)
+			got, err := streamAll(t, adapter, blockNum)
+			require.NoError(t, err)
+
+			assert.Equal(t, want, got)
+			if tt.withBad {
+				assert.Len(t, got.Transactions, 45)
+			}
+			if tt.dynamicFee {
+				assert.Equal(t, "0x2540be400", got.BaseFeePerGas.String())
+				assert.Equal(t, domain.TransactionTypeDynamicFee, got.Transactions[0].Type)
+			}
+		})
+	}
+}
+
+func TestEthereumNodeAdapter_StreamBlockTransactions_StrictParsing(t *testing.T) {
+	server := staticServer(t, syntheticBlockResponse(syntheticTransactions(10, true), false))
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client(), rpc.WithStrictParsing(true))
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(t, err)
+
+	block, err := streamAll(t, adapter, blockNum)
+	require.Error(t, err)
+	assert.Nil(t, block)
+}
+
+func TestEthereumNodeAdapter_StreamBlockTransactions_NullResult(t *testing.T) {
+	server := staticServer(t, `{"jsonrpc":"2.0","id":1,"result":null}`)
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(t, err)
+
+	block, err := streamAll(t, adapter, blockNum)
+	require.NoError(t, err)
+	assert.Nil(t, block)
+}
+
+func TestEthereumNodeAdapter_StreamBlockTransactions_RPCError(t *testing.T) {
+	server := staticServer(t, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"header not found"}}`)
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(t, err)
+
+	block, err := streamAll(t, adapter, blockNum)
+	var rpcErr *rpc.RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, -32000, rpcErr.Code)
+	assert.Nil(t, block)
+}
+
+func TestEthereumNodeAdapter_StreamBlockTransactions_CallbackErrorStopsStream(t *testing.T) {
+	server := staticServer(t, syntheticBlockResponse(syntheticTransactions(10, false), false))
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(t, err)
+
+	errStop := errors.New("stop")
+	calls := 0
+	block, err := adapter.StreamBlockTransactions(context.Background(), blockNum, func(domain.Transaction) error {
+		calls++
+		if calls == 3 {
+			return errStop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.Nil(t, block)
+	assert.Equal(t, 3, calls)
+}
+
+func TestEthereumNodeAdapter_StreamBlockTransactions_FailsOverBeforeStreaming(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	healthy := staticServer(t, syntheticBlockResponse(syntheticTransactions(3, false), false))
+	adapter := rpc.NewEthereumNodeAdapter([]string{failing.URL, healthy.URL}, healthy.Client())
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(t, err)
+
+	block, err := streamAll(t, adapter, blockNum)
+	require.NoError(t, err)
+	assert.Len(t, block.Transactions, 3)
+}
+
+// benchmarkBlockTransactions is the size of the synthetic block used by the decode benchmarks.
+const benchmarkBlockTransactions = 5000
+
+// Compare B/op of the two benchmarks below to see the memory held by decoding a whole block.
+func BenchmarkEthereumNodeAdapter_GetBlockWithTransactions(b *testing.B) {
+	server := staticServer(b, syntheticBlockResponse(syntheticTransactions(benchmarkBlockTransactions, false), false))
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		block, err := adapter.GetBlockWithTransactions(context.Background(), blockNum)
+		if err != nil || len(block.Transactions) != benchmarkBlockTransactions {
+			b.Fatalf("unexpected result: %v", err)
+		}
+	}
+}
+
+func BenchmarkEthereumNodeAdapter_StreamBlockTransactions(b *testing.B) {
+	server := staticServer(b, syntheticBlockResponse(syntheticTransactions(benchmarkBlockTransactions, false), false))
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count := 0
+		_, err := adapter.StreamBlockTransactions(context.Background(), blockNum, func(domain.Transaction) error {
+			count++
+			return nil
+		})
+		if err != nil || count != benchmarkBlockTransactions {
+			b.Fatalf("unexpected result: %v", err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptGzip asks the node to gzip its response. Setting the header disables the transparent decompression
+// of http.Transport, so responses must be read through decodedBody.
+func acceptGzip(req *http.Request) {
+	req.Header.Set("Accept-Encoding", "gzip")
+}
+
+// decodedBody returns the body of resp, decompressing it when the node sent it gzipped.
+// Closing the returned body also closes resp.Body.
+func decodedBody(resp *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), "gzip") {
+		return resp.Body, nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to decompress response body: %w", err)
+	}
+	return &gzipBody{Reader: zr, body: resp.Body}, nil
+}
+
+// gzipBody is a gzipped response body read through a gzip.Reader.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close closes the gzip reader and the underlying response body, returning the body's error first.
+func (g *gzipBody) Close() error {
+	errGzip := g.Reader.Close()
+	if err := g.body.Close(); err != nil {
+		return err
+	}
+	return errGzip
+}
@@ -0,0 +1,84 @@
+package rpc_test
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/rpc"
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gzipServer answers every request with body, gzipped when gzipResponse is set and the client accepts gzip.
+func gzipServer(t *testing.T, body string, gzipResponse bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !gzipResponse || !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write([]byte(body))
+		_ = zw.Close()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestEthereumNodeAdapter_GzipResponses(t *testing.T) {
+	response := `{"jsonrpc":"2.0","id":1,"result":` + fullBlockResult + `}`
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		gzipResponse bool
+	}{
+		{name: "Gzipped response", gzipResponse: true},
+		{name: "Identity response", gzipResponse: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := gzipServer(t, response, tt.gzipResponse)
+			adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+			block, err := adapter.GetBlockWithTransactions(context.Background(), blockNum)
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), block.Number.Value())
+			require.Len(t, block.Transactions, 1)
+			assert.Equal(t,
+				"0x1111111111111111111111111111111111111111111111111111111111111111",
+				block.Transactions[0].Hash.String())
+
+			streamed := 0
+			header, err := adapter.StreamBlockTransactions(context.Background(), blockNum, func(domain.Transaction) error {
+				streamed++
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, block.Hash, header.Hash)
+			assert.Equal(t, 1, streamed)
+		})
+	}
+}
+
+func TestEthereumNodeAdapter_GzipCorruptResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer server.Close()
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+	_, err := adapter.GetLatestBlockNumber(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decompress response body")
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"trust_wallet_homework/internal/logger"
+)
+
+// WithLogger sets the logger used for operational events such as the node becoming unreachable or reachable again.
+// By default these events are logged through the standard library logger.
+func WithLogger(l logger.AppLogger) Option {
+	return func(a *EthereumNodeAdapter) {
+		if l != nil {
+			a.logger = l
+		}
+	}
+}
+
+// connectivityTracker follows whether the node is reachable across calls, so that only
+// transitions are logged: once when the node is lost and once when it is restored.
+// The node is assumed to be reachable until a call fails.
+type connectivityTracker struct {
+	mu                  sync.Mutex
+	lost                bool
+	lostAt              time.Time
+	consecutiveFailures int
+	now                 func() time.Time
+}
+
+// newConnectivityTracker creates a tracker that starts in the reachable state.
+func newConnectivityTracker() *connectivityTracker {
+	return &connectivityTracker{now: time.Now}
+}
+
+// recordSuccess notes that some endpoint answered a call, logging a restore if the node was lost.
+func (c *connectivityTracker) recordSuccess(l logger.AppLogger) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.lost {
+		return
+	}
+	l.Info("Node connectivity restored",
+		"failedCalls", c.consecutiveFailures,
+		"downtime", c.now().Sub(c.lostAt).Round(time.Millisecond).String())
+	c.lost = false
+	c.consecutiveFailures = 0
+}
+
+// recordFailure notes that no endpoint answered a call, logging a loss if the node was reachable.
+func (c *connectivityTracker) recordFailure(l logger.AppLogger, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.consecutiveFailures++
+	if c.lost {
+		return
+	}
+	l.Warn("Node connectivity lost", "error", err)
+	c.lost = true
+	c.lostAt = c.now()
+}
@@ -0,0 +1,102 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/rpc"
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// connectivityLogs returns the level and message of every connectivity log entry written to buf.
+func connectivityLogs(t *testing.T, buf *bytes.Buffer) []string {
+	t.Helper()
+	var logs []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if msg, _ := entry["msg"].(string); strings.HasPrefix(msg, "Node connectivity") {
+			logs = append(logs, entry["level"].(string)+" "+msg)
+		}
+	}
+	return logs
+}
+
+func TestEthereumNodeAdapter_ConnectivityTransitions(t *testing.T) {
+	var up atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	l := applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&buf, nil)))
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client(), rpc.WithLogger(l))
+
+	steps := []struct {
+		name     string
+		up       bool
+		wantLogs []string
+	}{
+		{name: "Initial success is not a recovery", up: true, wantLogs: nil},
+		{name: "Success to failure", up: false, wantLogs: []string{"WARN Node connectivity lost"}},
+		{name: "Repeated failure stays quiet", up: false, wantLogs: nil},
+		{name: "Failure to success", up: true, wantLogs: []string{"INFO Node connectivity restored"}},
+		{name: "Repeated success stays quiet", up: true, wantLogs: nil},
+		{name: "Second outage is reported again", up: false, wantLogs: []string{"WARN Node connectivity lost"}},
+	}
+
+	for _, step := range steps {
+		buf.Reset()
+		up.Store(step.up)
+
+		_, err := adapter.GetLatestBlockNumber(context.Background())
+		if step.up {
+			require.NoError(t, err, step.name)
+		} else {
+			require.Error(t, err, step.name)
+		}
+		assert.Equal(t, step.wantLogs, connectivityLogs(t, &buf), step.name)
+	}
+}
+
+func TestEthereumNodeAdapter_RPCErrorCountsAsConnected(t *testing.T) {
+	var up atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"header not found"}}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	l := applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&buf, nil)))
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client(), rpc.WithLogger(l))
+
+	_, err := adapter.GetLatestBlockNumber(context.Background())
+	require.Error(t, err)
+	up.Store(true)
+	_, err = adapter.GetLatestBlockNumber(context.Background())
+	require.Error(t, err, "the node answered with a JSON-RPC error")
+
+	assert.Equal(t, []string{"WARN Node connectivity lost", "INFO Node connectivity restored"}, connectivityLogs(t, &buf))
+}
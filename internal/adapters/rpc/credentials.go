@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// basicAuth holds HTTP Basic Auth credentials extracted from an endpoint URL.
+type basicAuth struct {
+	username string
+	password string
+}
+
+// splitCredentials strips userinfo from rawURL and returns the sanitized URL with the extracted credentials.
+// URLs without userinfo, or that cannot be parsed, are returned unchanged with nil credentials.
+func splitCredentials(rawURL string) (string, *basicAuth) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User == nil {
+		return rawURL, nil
+	}
+
+	password, _ := parsed.User.Password()
+	creds := &basicAuth{username: parsed.User.Username(), password: password}
+	parsed.User = nil
+	return parsed.String(), creds
+}
+
+// WithBearerToken sends the given token as "Authorization: Bearer <token>" on every request.
+// Endpoints with credentials embedded in their URL use Basic Auth instead.
+func WithBearerToken(token string) Option {
+	return func(a *EthereumNodeAdapter) {
+		a.bearerToken = token
+	}
+}
+
+// applyAuth sets the Authorization header for a request to the given endpoint.
+func (a *EthereumNodeAdapter) applyAuth(req *http.Request, rpcURL string) {
+	if creds, ok := a.credentials[rpcURL]; ok {
+		req.SetBasicAuth(creds.username, creds.password)
+		return
+	}
+	if a.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+a.bearerToken)
+	}
+}
@@ -0,0 +1,76 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/rpc"
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthereumNodeAdapter_BasicAuthFromURLUserinfo(t *testing.T) {
+	var gotUser, gotPass string
+	var gotOK bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, gotPass, gotOK = r.BasicAuth()
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer server.Close()
+
+	authURL := strings.Replace(server.URL, "http://", "http://alice:s3cret@", 1)
+	adapter := rpc.NewEthereumNodeAdapter([]string{authURL}, server.Client(), rpc.WithBearerToken("ignored"))
+
+	_, err := adapter.GetLatestBlockNumber(context.Background())
+	require.NoError(t, err)
+	assert.True(t, gotOK)
+	assert.Equal(t, "alice", gotUser)
+	assert.Equal(t, "s3cret", gotPass)
+}
+
+func TestEthereumNodeAdapter_BearerToken(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client(), rpc.WithBearerToken("tok-123"))
+
+	_, err := adapter.GetLatestBlockNumber(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer tok-123", gotAuth)
+}
+
+func TestEthereumNodeAdapter_CredentialsAreRedacted(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	var logs bytes.Buffer
+	l := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(&logs, nil)))
+	adapter := rpc.NewEthereumNodeAdapter([]string{
+		strings.Replace(failing.URL, "http://", "http://alice:s3cret@", 1) + "/v3/path-key?apikey=query-key",
+		unreachable.URL + "/v3/path-key",
+	}, failing.Client(), rpc.WithLogger(l))
+
+	_, err := adapter.GetLatestBlockNumber(context.Background())
+	require.Error(t, err)
+	for _, secret := range []string{"s3cret", "path-key", "query-key"} {
+		assert.NotContains(t, err.Error(), secret)
+		assert.NotContains(t, logs.String(), secret)
+	}
+	assert.Contains(t, logs.String(), "endpoint="+failing.URL, "the endpoint host should still be logged")
+	assert.Contains(t, logs.String(), "endpoint="+unreachable.URL)
+}
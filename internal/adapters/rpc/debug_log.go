@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"trust_wallet_homework/internal/logger"
+)
+
+// WithDebugLogging logs every JSON-RPC request and response body at Debug level, cut to maxBodyBytes bytes.
+// Only the bodies and the scheme and host of the endpoint are logged, never headers or the URL path and query,
+// so authorization tokens and API keys stay out of the logs. A non-positive maxBodyBytes logs the bodies in full.
+func WithDebugLogging(l logger.AppLogger, maxBodyBytes int) Option {
+	return func(a *EthereumNodeAdapter) {
+		a.debugLogger = l
+		a.debugMaxBodyBytes = maxBodyBytes
+	}
+}
+
+// logRequestPayload logs an outgoing request body if debug logging is enabled.
+func (a *EthereumNodeAdapter) logRequestPayload(rpcURL, method string, body []byte) {
+	if a.debugLogger == nil {
+		return
+	}
+	payload, truncated := truncatePayload(body, a.debugMaxBodyBytes)
+	a.debugLogger.Debug("JSON-RPC request",
+		"endpoint", redactEndpoint(rpcURL),
+		"method", method,
+		"bodyBytes", len(body),
+		"truncated", truncated,
+		"body", payload)
+}
+
+// logResponsePayload logs an incoming response body if debug logging is enabled.
+func (a *EthereumNodeAdapter) logResponsePayload(rpcURL, method string, status int, body []byte) {
+	if a.debugLogger == nil {
+		return
+	}
+	payload, truncated := truncatePayload(body, a.debugMaxBodyBytes)
+	a.debugLogger.Debug("JSON-RPC response",
+		"endpoint", redactEndpoint(rpcURL),
+		"method", method,
+		"status", status,
+		"bodyBytes", len(body),
+		"truncated", truncated,
+		"body", payload)
+}
+
+// truncatePayload returns at most limit bytes of body as a string and whether anything was cut off.
+func truncatePayload(body []byte, limit int) (string, bool) {
+	if limit <= 0 || len(body) <= limit {
+		return string(body), false
+	}
+	return string(body[:limit]), true
+}
@@ -0,0 +1,78 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/rpc"
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthereumNodeAdapter_DebugLogging(t *testing.T) {
+	padding := strings.Repeat("f", 500)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10","padding":"` + padding + `"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "Disabled by default", enabled: false},
+		{name: "Enabled", enabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+			var opts []rpc.Option
+			if tt.enabled {
+				opts = append(opts, rpc.WithDebugLogging(l, 64), rpc.WithBearerToken("secret-token"))
+			}
+			adapter := rpc.NewEthereumNodeAdapter([]string{server.URL + "/v3/api-key"}, server.Client(), opts...)
+
+			_, err := adapter.GetLatestBlockNumber(context.Background())
+			require.NoError(t, err)
+
+			if !tt.enabled {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.NotContains(t, buf.String(), "secret-token")
+			assert.NotContains(t, buf.String(), "api-key")
+
+			var entries []map[string]any
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var entry map[string]any
+				require.NoError(t, json.Unmarshal([]byte(line), &entry))
+				entries = append(entries, entry)
+			}
+			require.Len(t, entries, 2)
+
+			request, response := entries[0], entries[1]
+			assert.Equal(t, "JSON-RPC request", request["msg"])
+			assert.Equal(t, "DEBUG", request["level"])
+			assert.Equal(t, "eth_blockNumber", request["method"])
+			assert.Equal(t, server.URL, request["endpoint"])
+			assert.Contains(t, request["body"], `"method":"eth_blockNumber"`)
+			assert.Equal(t, false, request["truncated"])
+
+			assert.Equal(t, "JSON-RPC response", response["msg"])
+			assert.Equal(t, float64(http.StatusOK), response["status"])
+			assert.Equal(t, true, response["truncated"])
+			assert.Len(t, response["body"], 64)
+			assert.Greater(t, response["bodyBytes"], float64(500))
+		})
+	}
+}
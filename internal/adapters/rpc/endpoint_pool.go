@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// endpointFailureThreshold is the number of consecutive failures after which an endpoint is marked unhealthy.
+	endpointFailureThreshold = 3
+	// endpointCooldown is how long an unhealthy endpoint is skipped before it is tried again.
+	endpointCooldown = 30 * time.Second
+)
+
+// endpoint holds the health state of a single RPC URL.
+type endpoint struct {
+	url                 string
+	consecutiveFailures int
+	unhealthyUntil      time.Time
+}
+
+// endpointPool selects RPC endpoints using round-robin with a simple health check.
+type endpointPool struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+	current   int
+	now       func() time.Time
+}
+
+// newEndpointPool creates a pool from the given URLs, preserving their order of preference.
+func newEndpointPool(urls []string) *endpointPool {
+	endpoints := make([]*endpoint, 0, len(urls))
+	for _, u := range urls {
+		endpoints = append(endpoints, &endpoint{url: u})
+	}
+	return &endpointPool{
+		endpoints: endpoints,
+		now:       time.Now,
+	}
+}
+
+// candidates returns the URLs to try for a request, starting from the current endpoint.
+// Healthy endpoints come first; unhealthy ones are kept as a last resort.
+func (p *endpointPool) candidates() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	healthy := make([]string, 0, len(p.endpoints))
+	var unhealthy []string
+	for i := range p.endpoints {
+		ep := p.endpoints[(p.current+i)%len(p.endpoints)]
+		if now.Before(ep.unhealthyUntil) {
+			unhealthy = append(unhealthy, ep.url)
+			continue
+		}
+		healthy = append(healthy, ep.url)
+	}
+	return append(healthy, unhealthy...)
+}
+
+// markSuccess resets the failure counter of the URL and makes it the current endpoint.
+func (p *endpointPool) markSuccess(url string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, ep := range p.endpoints {
+		if ep.url == url {
+			ep.consecutiveFailures = 0
+			ep.unhealthyUntil = time.Time{}
+			p.current = i
+			return
+		}
+	}
+}
+
+// markFailure records a failed call and reports whether the URL has just been marked unhealthy.
+func (p *endpointPool) markFailure(url string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, ep := range p.endpoints {
+		if ep.url != url {
+			continue
+		}
+		ep.consecutiveFailures++
+		if ep.consecutiveFailures < endpointFailureThreshold {
+			return false
+		}
+		ep.consecutiveFailures = 0
+		ep.unhealthyUntil = p.now().Add(endpointCooldown)
+		if p.current == i {
+			p.current = (i + 1) % len(p.endpoints)
+		}
+		return true
+	}
+	return false
+}
@@ -5,36 +5,134 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"trust_wallet_homework/internal/core/domain"
 	"trust_wallet_homework/internal/core/domain/client"
+	"trust_wallet_homework/internal/logger"
 	"trust_wallet_homework/internal/utils"
 )
 
+// ErrInvalidBlockTag indicates that a block tag is not one of the supported tags.
+var ErrInvalidBlockTag = errors.New("invalid block tag")
+
+// maxReceiptBatchSize bounds the number of receipt requests sent in a single JSON-RPC batch.
+const maxReceiptBatchSize = 100
+
+// validBlockTags is the set of tags accepted by GetBlockNumberByTag.
+var validBlockTags = map[string]struct{}{
+	client.BlockTagLatest:    {},
+	client.BlockTagPending:   {},
+	client.BlockTagSafe:      {},
+	client.BlockTagFinalized: {},
+}
+
 // EthereumNodeAdapter implements the client.EthereumClient interface by making JSON-RPC calls to an Ethereum node.
 type EthereumNodeAdapter struct {
-	rpcURL     string
-	httpClient *http.Client
-	requestID  int
+	endpoints   *endpointPool
+	httpClient  *http.Client
+	callTimeout time.Duration
+	requestID   atomic.Int64
+	credentials map[string]basicAuth
+	bearerToken string
+	logger      logger.AppLogger
+	health      *connectivityTracker
+	parseMode   parseMode
+	skippedTxs  atomic.Int64
+
+	// allowedMethods, if non-nil, is the set of JSON-RPC methods the adapter may call.
+	allowedMethods map[string]struct{}
+
+	debugLogger       logger.AppLogger
+	debugMaxBodyBytes int
+
+	// latency, if non-nil, records call latencies and reports their percentiles.
+	latency *latencyTracker
+}
+
+// Option configures optional behavior of the EthereumNodeAdapter.
+type Option func(*EthereumNodeAdapter)
+
+// WithCallTimeout bounds every individual RPC request with the given timeout, independent of the caller's deadline.
+// A non-positive timeout leaves requests bounded only by the caller's context and the HTTP client.
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(a *EthereumNodeAdapter) {
+		a.callTimeout = timeout
+	}
+}
+
+// WithStrictParsing makes a block with any malformed transaction fail as a whole, instead of being returned
+// without the malformed transactions, which are then counted and logged.
+func WithStrictParsing(strict bool) Option {
+	return func(a *EthereumNodeAdapter) {
+		a.parseMode = parseModeLenient
+		if strict {
+			a.parseMode = parseModeStrict
+		}
+	}
 }
 
 // Compile-time check to ensure EthereumNodeAdapter implements client.EthereumClient
 var _ client.EthereumClient = (*EthereumNodeAdapter)(nil)
 
 // NewEthereumNodeAdapter creates a new RPC adapter.
-func NewEthereumNodeAdapter(rpcURL string, httpClient *http.Client) *EthereumNodeAdapter {
+// The URLs are tried in order; a failing URL is skipped in favor of the next one.
+// Credentials embedded in a URL (user:pass@host) are stripped from it and sent as Basic Auth instead,
+// so they never appear in logs or error messages.
+func NewEthereumNodeAdapter(rpcURLs []string, httpClient *http.Client, opts ...Option) *EthereumNodeAdapter {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
-	return &EthereumNodeAdapter{
-		rpcURL:     rpcURL,
-		httpClient: httpClient,
-		requestID:  0,
+	sanitizedURLs := make([]string, 0, len(rpcURLs))
+	credentials := make(map[string]basicAuth)
+	for _, rawURL := range rpcURLs {
+		sanitized, creds := splitCredentials(rawURL)
+		if creds != nil {
+			credentials[sanitized] = *creds
+		}
+		sanitizedURLs = append(sanitizedURLs, sanitized)
+	}
+	adapter := &EthereumNodeAdapter{
+		endpoints:   newEndpointPool(sanitizedURLs),
+		httpClient:  httpClient,
+		credentials: credentials,
+		logger:      logger.NewSlogAdapter(slog.Default()),
+		health:      newConnectivityTracker(),
 	}
+	for _, opt := range opts {
+		opt(adapter)
+	}
+	return adapter
+}
+
+// GetChainID fetches the chain ID of the network the node is connected to.
+func (a *EthereumNodeAdapter) GetChainID(ctx context.Context) (int64, error) {
+	respBody, err := a.doRPC(ctx, "eth_chainId", []interface{}{})
+	if err != nil {
+		return 0, fmt.Errorf("RPC call failed: %w", err)
+	}
+
+	if respBody.Result == nil {
+		return 0, fmt.Errorf("RPC result is null for eth_chainId")
+	}
+
+	var resultStr string
+	if err := json.Unmarshal(respBody.Result, &resultStr); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal chain ID result: %w", err)
+	}
+
+	chainID, err := utils.HexToInt64(resultStr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse chain ID hex '%s': %w", resultStr, err)
+	}
+	return chainID, nil
 }
 
 // GetLatestBlockNumber fetches the number of the most recent block.
@@ -61,62 +159,213 @@ func (a *EthereumNodeAdapter) GetLatestBlockNumber(ctx context.Context) (domain.
 	return domain.NewBlockNumber(blockNumberInt)
 }
 
+// GetBlockNumberByTag fetches the number of the block identified by the given tag.
+// Supported tags are "latest", "pending", "safe" and "finalized".
+func (a *EthereumNodeAdapter) GetBlockNumberByTag(ctx context.Context, tag string) (domain.BlockNumber, error) {
+	if _, ok := validBlockTags[tag]; !ok {
+		return domain.BlockNumber{}, fmt.Errorf("%w: %q", ErrInvalidBlockTag, tag)
+	}
+
+	respBody, err := a.doRPC(ctx, "eth_getBlockByNumber", []interface{}{tag, false})
+	if err != nil {
+		return domain.BlockNumber{}, fmt.Errorf("RPC call failed: %w", err)
+	}
+
+	if respBody.Result == nil || string(respBody.Result) == "null" {
+		return domain.BlockNumber{}, fmt.Errorf("no block available for tag %q", tag)
+	}
+
+	var header struct {
+		Number *string `json:"number"`
+	}
+	if err := json.Unmarshal(respBody.Result, &header); err != nil {
+		return domain.BlockNumber{}, fmt.Errorf("failed to unmarshal block header for tag %q: %w", tag, err)
+	}
+	if header.Number == nil {
+		return domain.BlockNumber{}, fmt.Errorf("block for tag %q has no number", tag)
+	}
+
+	blockNumberInt, err := utils.HexToInt64(*header.Number)
+	if err != nil {
+		return domain.BlockNumber{}, fmt.Errorf("failed to parse block number hex '%s': %w", *header.Number, err)
+	}
+
+	return domain.NewBlockNumber(blockNumberInt)
+}
+
 // GetBlockWithTransactions fetches a block by its number and includes its transactions.
 func (a *EthereumNodeAdapter) GetBlockWithTransactions(
 	ctx context.Context,
 	blockNumber domain.BlockNumber,
 ) (*domain.Block, error) {
-	blockNumberHex := fmt.Sprintf("0x%x", blockNumber.Value())
-	params := []interface{}{blockNumberHex, true}
+	return a.getBlock(ctx, "eth_getBlockByNumber", fmt.Sprintf("0x%x", blockNumber.Value()), true)
+}
+
+// GetBlockByHash fetches a block by its hash, including full transaction objects.
+// A nil block is returned if the node does not know the hash, e.g. because the block was reorged out.
+func (a *EthereumNodeAdapter) GetBlockByHash(ctx context.Context, hash domain.BlockHash) (*domain.Block, error) {
+	return a.getBlock(ctx, "eth_getBlockByHash", hash.String(), true)
+}
 
-	respBody, err := a.doRPC(ctx, "eth_getBlockByNumber", params)
+// GetBlockByTag fetches the block identified by the given tag, so that e.g. the finalized block can be
+// requested without resolving its number first. Supported tags are "latest", "pending", "safe" and "finalized".
+// When full is false only the block header is fetched and the returned block has no transactions.
+// A nil block is returned if the node has no block for the tag yet.
+func (a *EthereumNodeAdapter) GetBlockByTag(ctx context.Context, tag string, full bool) (*domain.Block, error) {
+	if _, ok := validBlockTags[tag]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBlockTag, tag)
+	}
+	return a.getBlock(ctx, "eth_getBlockByNumber", tag, full)
+}
+
+// getBlock calls eth_getBlockByNumber for a hex block number or a block tag, or eth_getBlockByHash for a
+// block hash, and maps the result.
+// Without full transaction objects the node returns transaction hashes, which are not mapped.
+func (a *EthereumNodeAdapter) getBlock(
+	ctx context.Context,
+	method string,
+	blockParam string,
+	full bool,
+) (*domain.Block, error) {
+	respBody, err := a.doRPC(ctx, method, []interface{}{blockParam, full})
 	if err != nil {
 		return nil, fmt.Errorf("RPC call failed: %w", err)
 	}
 
 	if respBody.Result == nil {
-		log.Printf("Received null result for block %d (0x%x)", blockNumber.Value(), blockNumber.Value())
+		log.Printf("Received null result for block %s", blockParam)
 		return nil, nil
 	}
 
 	var rpcBlock *Block
-	if err := json.Unmarshal(respBody.Result, &rpcBlock); err != nil {
-		log.Printf("Error unmarshaling block %d (0x%x): %v. JSON: %s",
-			blockNumber.Value(),
-			blockNumber.Value(),
-			err,
-			string(respBody.Result),
-		)
+	if full {
+		err = json.Unmarshal(respBody.Result, &rpcBlock)
+	} else {
+		var header *struct {
+			Block
+			Transactions []string `json:"transactions"`
+		}
+		err = json.Unmarshal(respBody.Result, &header)
+		if header != nil {
+			rpcBlock = &header.Block
+		}
+	}
+	if err != nil {
+		log.Printf("Error unmarshaling block %s: %v. JSON: %s", blockParam, err, string(respBody.Result))
 		return nil, fmt.Errorf("failed to unmarshal block result for block %s: %w. JSON: %s",
-			blockNumberHex,
+			blockParam,
 			err,
 			string(respBody.Result),
 		)
 	}
 
 	if rpcBlock == nil {
-		log.Printf("Block %d unmarshalled to nil unexpectedly (after non-null raw result for 0x%x)\n",
-			blockNumber.Value(),
-			blockNumber.Value(),
-		)
+		log.Printf("Block %s unmarshalled to nil unexpectedly (after non-null raw result)\n", blockParam)
 		return nil, nil
 	}
 
-	return mapRPCBlockToDomain(rpcBlock)
+	block, skipped, err := mapRPCBlockToDomain(rpcBlock, a.parseMode)
+	if err != nil {
+		return nil, err
+	}
+	a.logSkippedTransactions(block, skipped)
+	return block, nil
+}
+
+// GetTransactionByHash fetches a transaction from the node by its hash.
+// A nil transaction is returned if the node does not know the hash. Timestamp is left zero, as it is a
+// property of the block; pending transactions also have a zero block number and index.
+func (a *EthereumNodeAdapter) GetTransactionByHash(
+	ctx context.Context,
+	hash domain.TransactionHash,
+) (*domain.Transaction, error) {
+	respBody, err := a.doRPC(ctx, "eth_getTransactionByHash", []interface{}{hash.String()})
+	if err != nil {
+		return nil, fmt.Errorf("RPC call failed: %w", err)
+	}
+
+	if respBody.Result == nil || string(respBody.Result) == "null" {
+		return nil, nil
+	}
+
+	var rpcTx Transaction
+	if err := json.Unmarshal(respBody.Result, &rpcTx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction %s: %w", hash.String(), err)
+	}
+
+	var blockNum domain.BlockNumber
+	if rpcTx.BlockNumber != nil {
+		num, err := utils.HexToInt64(*rpcTx.BlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx block number hex '%s': %w", *rpcTx.BlockNumber, err)
+		}
+		blockNum, err = domain.NewBlockNumber(num)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating domain block number: %w", err)
+		}
+	}
+
+	return mapRPCTransactionToDomain(&rpcTx, blockNum, 0)
+}
+
+// GetTransactionStatuses fetches the receipts of the given transactions using batched eth_getTransactionReceipt
+// calls and returns their statuses. Transactions the node has no receipt for are omitted from the result.
+func (a *EthereumNodeAdapter) GetTransactionStatuses(
+	ctx context.Context,
+	hashes []domain.TransactionHash,
+) (map[domain.TransactionHash]domain.TransactionStatus, error) {
+	statuses := make(map[domain.TransactionHash]domain.TransactionStatus, len(hashes))
+	for start := 0; start < len(hashes); start += maxReceiptBatchSize {
+		batch := hashes[start:min(start+maxReceiptBatchSize, len(hashes))]
+		params := make([][]interface{}, len(batch))
+		for i, hash := range batch {
+			params[i] = []interface{}{hash.String()}
+		}
+
+		responses, err := a.doBatchRPC(ctx, "eth_getTransactionReceipt", params)
+		if err != nil {
+			return nil, fmt.Errorf("RPC batch call failed: %w", err)
+		}
+
+		for i, resp := range responses {
+			if resp.Error != nil {
+				return nil, fmt.Errorf("failed to get receipt of %s: %w",
+					batch[i].String(), &RPCError{Code: resp.Error.Code, Message: resp.Error.Message})
+			}
+			if resp.Result == nil || string(resp.Result) == "null" {
+				continue
+			}
+			var receipt TransactionReceipt
+			if err := json.Unmarshal(resp.Result, &receipt); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal receipt of %s: %w", batch[i].String(), err)
+			}
+			status, err := mapRPCReceiptStatus(&receipt)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map receipt of %s: %w", batch[i].String(), err)
+			}
+			statuses[batch[i]] = status
+		}
+	}
+	return statuses, nil
 }
 
 // doRPC performs the actual JSON-RPC call.
+// Methods that are not on the allowlist fail with *MethodNotAllowedError without contacting the node.
+// Errors reported by the node are returned as *RPCError; rate-limited calls fail over to the next endpoint.
 func (a *EthereumNodeAdapter) doRPC(
 	ctx context.Context,
 	method string,
 	params []interface{},
 ) (*JSONRPCResponse, error) {
-	a.requestID++
+	if err := a.checkMethod(method); err != nil {
+		return nil, err
+	}
+
 	reqBody := JSONRPCRequest{
 		JSONRPC: "2.0",
 		Method:  method,
 		Params:  params,
-		ID:      a.requestID,
+		ID:      a.requestID.Add(1),
 	}
 
 	jsonReqBody, err := json.Marshal(reqBody)
@@ -124,32 +373,136 @@ func (a *EthereumNodeAdapter) doRPC(
 		return nil, fmt.Errorf("failed to marshal RPC request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.rpcURL, bytes.NewBuffer(jsonReqBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	candidates := a.endpoints.candidates()
+	if len(candidates) == 0 {
+		return nil, errors.New("no RPC endpoints configured")
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	httpResp, err := a.httpClient.Do(httpReq.WithContext(ctx))
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute HTTP request: %w", err)
-	}
+	var lastErr error
+	for _, rpcURL := range candidates {
+		rpcResp, err := a.send(ctx, rpcURL, method, jsonReqBody)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			if a.endpoints.markFailure(rpcURL) {
+				a.logger.Warn("RPC endpoint marked unhealthy",
+					"endpoint", redactEndpoint(rpcURL),
+					"cooldown", endpointCooldown.String())
+			}
+			a.logger.Warn("RPC call failed, trying next endpoint",
+				"method", method,
+				"endpoint", redactEndpoint(rpcURL),
+				"error", err)
+			lastErr = err
+			continue
+		}
 
-	if httpResp.Body != nil {
-		defer func() {
-			if errClose := httpResp.Body.Close(); errClose != nil {
-				log.Printf("[WARN] Failed to close response body in doRPC for method %s: %v", method, errClose)
+		if rpcResp.Error != nil {
+			rpcErr := &RPCError{Code: rpcResp.Error.Code, Message: rpcResp.Error.Message}
+			if rpcErr.IsRateLimited() {
+				a.endpoints.markFailure(rpcURL)
+				a.logger.Warn("RPC call rate limited, trying next endpoint",
+					"method", method,
+					"endpoint", redactEndpoint(rpcURL),
+					"error", rpcErr)
+				lastErr = rpcErr
+				continue
 			}
-		}()
+			a.endpoints.markSuccess(rpcURL)
+			a.health.recordSuccess(a.logger)
+			return nil, rpcErr
+		}
+		a.endpoints.markSuccess(rpcURL)
+		a.health.recordSuccess(a.logger)
+		return rpcResp, nil
+	}
+
+	a.health.recordFailure(a.logger, lastErr)
+	return nil, fmt.Errorf("all RPC endpoints failed: %w", lastErr)
+}
+
+// doBatchRPC sends one JSON-RPC batch calling method once per params entry.
+// The responses are returned in the order of params; per-call errors are left in the responses.
+func (a *EthereumNodeAdapter) doBatchRPC(
+	ctx context.Context,
+	method string,
+	params [][]interface{},
+) ([]JSONRPCResponse, error) {
+	if err := a.checkMethod(method); err != nil {
+		return nil, err
 	}
 
-	bodyBytes, err := io.ReadAll(httpResp.Body)
+	reqBodies := make([]JSONRPCRequest, len(params))
+	positions := make(map[int64]int, len(params))
+	for i, p := range params {
+		id := a.requestID.Add(1)
+		reqBodies[i] = JSONRPCRequest{JSONRPC: "2.0", Method: method, Params: p, ID: id}
+		positions[id] = i
+	}
+
+	jsonReqBody, err := json.Marshal(reqBodies)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to marshal RPC batch request: %w", err)
 	}
 
-	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP request failed with status %s: %s", httpResp.Status, string(bodyBytes))
+	candidates := a.endpoints.candidates()
+	if len(candidates) == 0 {
+		return nil, errors.New("no RPC endpoints configured")
+	}
+
+	var lastErr error
+	for _, rpcURL := range candidates {
+		bodyBytes, err := a.post(ctx, rpcURL, method, jsonReqBody)
+		if err == nil {
+			var batchResp []JSONRPCResponse
+			if err = json.Unmarshal(bodyBytes, &batchResp); err != nil {
+				err = fmt.Errorf("failed to unmarshal RPC batch response: %w, body: %s", err, string(bodyBytes))
+			} else if len(batchResp) != len(params) {
+				err = fmt.Errorf("RPC batch response has %d entries, expected %d", len(batchResp), len(params))
+			} else {
+				a.endpoints.markSuccess(rpcURL)
+				a.health.recordSuccess(a.logger)
+				ordered := make([]JSONRPCResponse, len(params))
+				for _, resp := range batchResp {
+					pos, ok := positions[resp.ID]
+					if !ok {
+						return nil, fmt.Errorf("RPC batch response has unexpected id %d", resp.ID)
+					}
+					ordered[pos] = resp
+				}
+				return ordered, nil
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if a.endpoints.markFailure(rpcURL) {
+			a.logger.Warn("RPC endpoint marked unhealthy",
+				"endpoint", redactEndpoint(rpcURL),
+				"cooldown", endpointCooldown.String())
+		}
+		a.logger.Warn("RPC batch call failed, trying next endpoint",
+			"method", method,
+			"endpoint", redactEndpoint(rpcURL),
+			"error", err)
+		lastErr = err
+	}
+
+	a.health.recordFailure(a.logger, lastErr)
+	return nil, fmt.Errorf("all RPC endpoints failed: %w", lastErr)
+}
+
+// send posts an encoded JSON-RPC request to a single endpoint and decodes the response envelope.
+func (a *EthereumNodeAdapter) send(
+	ctx context.Context,
+	rpcURL string,
+	method string,
+	jsonReqBody []byte,
+) (*JSONRPCResponse, error) {
+	bodyBytes, err := a.post(ctx, rpcURL, method, jsonReqBody)
+	if err != nil {
+		return nil, err
 	}
 
 	var rpcResp JSONRPCResponse
@@ -157,9 +510,57 @@ func (a *EthereumNodeAdapter) doRPC(
 		return nil, fmt.Errorf("failed to unmarshal RPC response: %w, body: %s", err, string(bodyBytes))
 	}
 
-	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("RPC error: code=%d, message='%s'", rpcResp.Error.Code, rpcResp.Error.Message)
+	return &rpcResp, nil
+}
+
+// post sends an encoded JSON-RPC payload to a single endpoint and returns the raw response body.
+func (a *EthereumNodeAdapter) post(
+	ctx context.Context,
+	rpcURL string,
+	method string,
+	jsonReqBody []byte,
+) ([]byte, error) {
+	if a.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.callTimeout)
+		defer cancel()
 	}
 
-	return &rpcResp, nil
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewBuffer(jsonReqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	acceptGzip(httpReq)
+	a.applyAuth(httpReq, rpcURL)
+	a.logRequestPayload(rpcURL, method, jsonReqBody)
+
+	started := time.Now()
+	defer func() { a.latency.observe(method, time.Since(started)) }()
+	httpResp, err := a.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute HTTP request: %w", redactURLError(err))
+	}
+
+	body, err := decodedBody(httpResp)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if errClose := body.Close(); errClose != nil {
+			log.Printf("[WARN] Failed to close response body in doRPC for method %s: %v", method, errClose)
+		}
+	}()
+
+	bodyBytes, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	a.logResponsePayload(rpcURL, method, httpResp.StatusCode, bodyBytes)
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP request failed with status %s: %s", httpResp.Status, string(bodyBytes))
+	}
+
+	return bodyBytes, nil
 }
@@ -0,0 +1,515 @@
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/adapters/rpc"
+	"trust_wallet_homework/internal/core/domain"
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthereumNodeAdapter_FailsOverToNextEndpoint(t *testing.T) {
+	var failingHits, healthyHits atomic.Int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		failingHits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		healthyHits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer healthy.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{failing.URL, healthy.URL}, healthy.Client())
+
+	blockNum, err := adapter.GetLatestBlockNumber(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(16), blockNum.Value())
+	assert.Equal(t, int32(1), failingHits.Load())
+	assert.Equal(t, int32(1), healthyHits.Load())
+}
+
+func TestEthereumNodeAdapter_RotatesAwayFromUnhealthyEndpoint(t *testing.T) {
+	var failingHits atomic.Int32
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		failingHits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer healthy.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{failing.URL, healthy.URL}, healthy.Client())
+
+	for i := 0; i < 5; i++ {
+		_, err := adapter.GetLatestBlockNumber(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), failingHits.Load(), "healthy endpoint should become current after failover")
+}
+
+func TestEthereumNodeAdapter_AllEndpointsFail(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{failing.URL, failing.URL + "/other"}, failing.Client())
+
+	_, err := adapter.GetLatestBlockNumber(context.Background())
+	assert.Error(t, err)
+}
+
+func TestEthereumNodeAdapter_ConcurrentRequestIDsAreUnique(t *testing.T) {
+	var mu sync.Mutex
+	seenIDs := make(map[int64]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpc.JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		seenIDs[req.ID]++
+		mu.Unlock()
+		resp := rpc.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(`"0x1"`)}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+	const calls = 100
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := adapter.GetLatestBlockNumber(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, seenIDs, calls)
+	for id, count := range seenIDs {
+		assert.Equal(t, 1, count, "request ID %d was reused", id)
+		assert.Positive(t, id)
+	}
+}
+
+func TestEthereumNodeAdapter_PerCallTimeout(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+		}
+	}))
+	defer slow.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter(
+		[]string{slow.URL},
+		slow.Client(),
+		rpc.WithCallTimeout(100*time.Millisecond),
+	)
+
+	started := time.Now()
+	_, err := adapter.GetLatestBlockNumber(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+func TestEthereumNodeAdapter_GetBlockNumberByTag(t *testing.T) {
+	for _, tag := range []string{"latest", "pending", "safe", "finalized"} {
+		t.Run(tag, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req rpc.JSONRPCRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if req.Method != "eth_getBlockByNumber" || len(req.Params) != 2 ||
+					req.Params[0] != tag || req.Params[1] != false {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"number":"0x2a","hash":"0xabc"}}`))
+			}))
+			defer server.Close()
+
+			adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+			blockNum, err := adapter.GetBlockNumberByTag(context.Background(), tag)
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), blockNum.Value())
+		})
+	}
+}
+
+func TestEthereumNodeAdapter_GetBlockNumberByTag_InvalidTag(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+	_, err := adapter.GetBlockNumberByTag(context.Background(), "earliest-ish")
+	require.ErrorIs(t, err, rpc.ErrInvalidBlockTag)
+	assert.Equal(t, int32(0), hits.Load(), "invalid tag must not reach the node")
+}
+
+func TestEthereumNodeAdapter_GetBlockNumberByTag_NullResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+	_, err := adapter.GetBlockNumberByTag(context.Background(), "finalized")
+	assert.Error(t, err)
+}
+
+func TestEthereumNodeAdapter_GetChainID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpc.JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_chainId" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0xaa36a7"}`))
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+	chainID, err := adapter.GetChainID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(11155111), chainID)
+}
+
+func TestEthereumNodeAdapter_GetChainID_InvalidResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"not-hex"}`))
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+	_, err := adapter.GetChainID(context.Background())
+	assert.Error(t, err)
+}
+
+func TestEthereumNodeAdapter_GetBlockWithTransactions_ParseMode(t *testing.T) {
+	const blockWithBadTx = `{"jsonrpc":"2.0","id":1,"result":{
+		"number":"0x10",
+		"hash":"0x2222222222222222222222222222222222222222222222222222222222222222",
+		"timestamp":"0x5",
+		"transactions":[
+			{"hash":"0x1111111111111111111111111111111111111111111111111111111111111111",
+			 "from":"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","value":"0x1"},
+			{"hash":"0xbad","from":"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","value":"0x1"}
+		]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(blockWithBadTx))
+	}))
+	defer server.Close()
+	blockNum, err := domain.NewBlockNumber(16)
+	require.NoError(t, err)
+
+	t.Run("Lenient", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&buf, nil)))
+		adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client(), rpc.WithLogger(l))
+
+		block, err := adapter.GetBlockWithTransactions(context.Background(), blockNum)
+		require.NoError(t, err)
+		assert.Len(t, block.Transactions, 1)
+		assert.Contains(t, buf.String(), `"msg":"Skipped malformed transactions in block"`)
+		assert.Contains(t, buf.String(), `"skippedTotal":1`)
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client(), rpc.WithStrictParsing(true))
+
+		block, err := adapter.GetBlockWithTransactions(context.Background(), blockNum)
+		require.Error(t, err)
+		assert.Nil(t, block)
+	})
+}
+
+// blockByNumberServer answers eth_getBlockByNumber with result and records the params of the last request.
+func blockByNumberServer(t *testing.T, result string, params *[]interface{}) *httptest.Server {
+	t.Helper()
+	return rpcMethodServer(t, "eth_getBlockByNumber", result, params)
+}
+
+// rpcMethodServer answers requests for method with result and records the params of the last request.
+func rpcMethodServer(t *testing.T, method, result string, params *[]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpc.JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != method {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		*params = req.Params
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+const fullBlockResult = `{
+	"number":"0x2a",
+	"hash":"0x2222222222222222222222222222222222222222222222222222222222222222",
+	"timestamp":"0x5",
+	"transactions":[
+		{"hash":"0x1111111111111111111111111111111111111111111111111111111111111111",
+		 "from":"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","value":"0x1","transactionIndex":"0x0"}
+	]}`
+
+func TestEthereumNodeAdapter_GetBlockWithTransactions_NumericBlock(t *testing.T) {
+	var params []interface{}
+	server := blockByNumberServer(t, fullBlockResult, &params)
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+	blockNum, err := domain.NewBlockNumber(42)
+	require.NoError(t, err)
+
+	block, err := adapter.GetBlockWithTransactions(context.Background(), blockNum)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"0x2a", true}, params)
+	assert.Equal(t, int64(42), block.Number.Value())
+	assert.Len(t, block.Transactions, 1)
+}
+
+func TestEthereumNodeAdapter_GetBlockByHash(t *testing.T) {
+	const blockHash = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	hash, err := domain.NewBlockHash(blockHash)
+	require.NoError(t, err)
+
+	t.Run("Known hash", func(t *testing.T) {
+		var params []interface{}
+		server := rpcMethodServer(t, "eth_getBlockByHash", fullBlockResult, &params)
+		adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+		block, err := adapter.GetBlockByHash(context.Background(), hash)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{blockHash, true}, params)
+		assert.Equal(t, int64(42), block.Number.Value())
+		assert.Equal(t, hash, block.Hash)
+		assert.Len(t, block.Transactions, 1)
+	})
+
+	t.Run("Unknown hash", func(t *testing.T) {
+		var params []interface{}
+		server := rpcMethodServer(t, "eth_getBlockByHash", `null`, &params)
+		adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+		block, err := adapter.GetBlockByHash(context.Background(), hash)
+		require.NoError(t, err)
+		assert.Nil(t, block)
+	})
+}
+
+func TestEthereumNodeAdapter_GetBlockByTag(t *testing.T) {
+	t.Run("Full", func(t *testing.T) {
+		var params []interface{}
+		server := blockByNumberServer(t, fullBlockResult, &params)
+		adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+		block, err := adapter.GetBlockByTag(context.Background(), "finalized", true)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"finalized", true}, params)
+		assert.Equal(t, int64(42), block.Number.Value())
+		assert.Len(t, block.Transactions, 1)
+	})
+
+	t.Run("Header only", func(t *testing.T) {
+		var params []interface{}
+		server := blockByNumberServer(t, `{
+			"number":"0x2a",
+			"hash":"0x2222222222222222222222222222222222222222222222222222222222222222",
+			"timestamp":"0x5",
+			"transactions":["0x1111111111111111111111111111111111111111111111111111111111111111"]}`, &params)
+		adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+		block, err := adapter.GetBlockByTag(context.Background(), "safe", false)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"safe", false}, params)
+		assert.Equal(t, int64(42), block.Number.Value())
+		assert.Empty(t, block.Transactions, "transaction hashes are not mapped")
+	})
+
+	t.Run("No block for tag", func(t *testing.T) {
+		var params []interface{}
+		server := blockByNumberServer(t, `null`, &params)
+		adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+		block, err := adapter.GetBlockByTag(context.Background(), "finalized", true)
+		require.NoError(t, err)
+		assert.Nil(t, block)
+	})
+
+	t.Run("Invalid tag", func(t *testing.T) {
+		adapter := rpc.NewEthereumNodeAdapter([]string{"http://127.0.0.1:0"}, nil)
+
+		_, err := adapter.GetBlockByTag(context.Background(), "0x2a", true)
+		assert.ErrorIs(t, err, rpc.ErrInvalidBlockTag)
+	})
+}
+
+func TestEthereumNodeAdapter_GetTransactionByHash(t *testing.T) {
+	const txHash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	tests := []struct {
+		name      string
+		result    string
+		wantNil   bool
+		wantBlock int64
+		wantIndex uint64
+	}{
+		{
+			name: "Mined transaction",
+			result: `{"hash":"` + txHash + `","blockNumber":"0x2a","transactionIndex":"0x3",
+				"from":"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","to":"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb","value":"0x1"}`,
+			wantBlock: 42,
+			wantIndex: 3,
+		},
+		{
+			name: "Pending transaction",
+			result: `{"hash":"` + txHash + `","blockNumber":null,"transactionIndex":null,
+				"from":"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","to":"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb","value":"0x1"}`,
+		},
+		{name: "Unknown hash", result: `null`, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req rpc.JSONRPCRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil ||
+					req.Method != "eth_getTransactionByHash" || len(req.Params) != 1 || req.Params[0] != txHash {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + tt.result + `}`))
+			}))
+			defer server.Close()
+			adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+			hash, err := domain.NewTransactionHash(txHash)
+			require.NoError(t, err)
+
+			tx, err := adapter.GetTransactionByHash(context.Background(), hash)
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, tx)
+				return
+			}
+			require.NotNil(t, tx)
+			assert.Equal(t, hash, tx.Hash)
+			assert.Equal(t, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", tx.To.String())
+			assert.Equal(t, tt.wantBlock, tx.BlockNumber.Value())
+			assert.Equal(t, tt.wantIndex, tx.TransactionIndex)
+		})
+	}
+}
+
+func TestEthereumNodeAdapter_GetTransactionStatuses(t *testing.T) {
+	const (
+		successHash  = "0x1111111111111111111111111111111111111111111111111111111111111111"
+		revertedHash = "0x2222222222222222222222222222222222222222222222222222222222222222"
+		pendingHash  = "0x3333333333333333333333333333333333333333333333333333333333333333"
+	)
+	receipts := map[string]string{
+		successHash:  `{"transactionHash":"` + successHash + `","blockNumber":"0x10","status":"0x1"}`,
+		revertedHash: `{"transactionHash":"` + revertedHash + `","blockNumber":"0x10","status":"0x0"}`,
+		pendingHash:  `null`,
+	}
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		var reqs []rpc.JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// Respond in reverse order: batch responses are matched by id, not by position.
+		resps := make([]string, 0, len(reqs))
+		for i := len(reqs) - 1; i >= 0; i-- {
+			req := reqs[i]
+			if req.Method != "eth_getTransactionReceipt" || len(req.Params) != 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			hash, _ := req.Params[0].(string)
+			resps = append(resps, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, req.ID, receipts[hash]))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(resps, ",") + "]"))
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+	hashes := make([]domain.TransactionHash, 0, 3)
+	for _, raw := range []string{successHash, revertedHash, pendingHash} {
+		hash, err := domain.NewTransactionHash(raw)
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+
+	statuses, err := adapter.GetTransactionStatuses(context.Background(), hashes)
+	require.NoError(t, err)
+	assert.Equal(t, map[domain.TransactionHash]domain.TransactionStatus{
+		hashes[0]: domain.TransactionStatusSuccess,
+		hashes[1]: domain.TransactionStatusFailed,
+	}, statuses)
+	assert.Equal(t, int32(1), hits.Load(), "receipts must be fetched in a single batch")
+}
+
+func TestEthereumNodeAdapter_GetTransactionStatuses_RPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []rpc.JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil || len(reqs) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `[{"jsonrpc":"2.0","id":%d,"error":{"code":-32602,"message":"invalid argument"}}]`, reqs[0].ID)
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+	hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+
+	_, err = adapter.GetTransactionStatuses(context.Background(), []domain.TransactionHash{hash})
+	var rpcErr *rpc.RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	assert.True(t, rpcErr.IsInvalidParams())
+}
@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"trust_wallet_homework/internal/logger"
+)
+
+// maxLatencySamples bounds the samples kept per method within one reporting window. Once it is reached the
+// oldest samples are overwritten, so the percentiles describe the most recent calls.
+const maxLatencySamples = 1024
+
+// WithLatencyReporting records how long every JSON-RPC call takes and logs the p50, p95 and p99 latency of each
+// method every interval, over the calls made since the previous report. Reports are written by the calls
+// themselves, so nothing is logged while the node is not called. Streamed blocks are timed until the response
+// headers arrive, since the body is decoded while it is read. A non-positive interval disables it.
+func WithLatencyReporting(l logger.AppLogger, interval time.Duration) Option {
+	return func(a *EthereumNodeAdapter) {
+		if l == nil || interval <= 0 {
+			a.latency = nil
+			return
+		}
+		a.latency = newLatencyTracker(l, interval)
+	}
+}
+
+// latencyStats summarizes the latency of the calls to one method.
+type latencyStats struct {
+	calls int
+	p50   time.Duration
+	p95   time.Duration
+	p99   time.Duration
+}
+
+// latencyWindow holds the latency samples of one method, used as a ring buffer once it is full.
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+	calls   int
+}
+
+// latencyTracker collects call latencies per method and reports their percentiles once per interval.
+type latencyTracker struct {
+	mu          sync.Mutex
+	logger      logger.AppLogger
+	interval    time.Duration
+	windowStart time.Time
+	windows     map[string]*latencyWindow
+	now         func() time.Time
+}
+
+// newLatencyTracker creates a tracker whose first window starts with the first observed call.
+func newLatencyTracker(l logger.AppLogger, interval time.Duration) *latencyTracker {
+	return &latencyTracker{
+		logger:   l,
+		interval: interval,
+		windows:  make(map[string]*latencyWindow),
+		now:      time.Now,
+	}
+}
+
+// observe records the latency of a call to method and logs a report when the current window has ended.
+// It does nothing on a nil tracker, so latency reporting can be left disabled.
+func (t *latencyTracker) observe(method string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	now := t.now()
+	if t.windowStart.IsZero() {
+		t.windowStart = now
+	}
+	t.add(method, d)
+	if now.Sub(t.windowStart) < t.interval {
+		t.mu.Unlock()
+		return
+	}
+	stats := t.drain()
+	window := now.Sub(t.windowStart)
+	t.windowStart = now
+	t.mu.Unlock()
+
+	methods := make([]string, 0, len(stats))
+	for method := range stats {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+	for _, method := range methods {
+		s := stats[method]
+		t.logger.Info("RPC latency",
+			"method", method,
+			"calls", s.calls,
+			"window", window.Round(time.Second).String(),
+			"p50", s.p50.String(),
+			"p95", s.p95.String(),
+			"p99", s.p99.String())
+	}
+}
+
+// add records a sample for method, overwriting the oldest one when the window of the method is full.
+func (t *latencyTracker) add(method string, d time.Duration) {
+	w, ok := t.windows[method]
+	if !ok {
+		w = &latencyWindow{}
+		t.windows[method] = w
+	}
+	w.calls++
+	if len(w.samples) < maxLatencySamples {
+		w.samples = append(w.samples, d)
+		return
+	}
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % maxLatencySamples
+}
+
+// drain returns the latency percentiles of every method called in the current window and starts a new one.
+func (t *latencyTracker) drain() map[string]latencyStats {
+	stats := make(map[string]latencyStats, len(t.windows))
+	for method, w := range t.windows {
+		sorted := slices.Clone(w.samples)
+		slices.Sort(sorted)
+		stats[method] = latencyStats{
+			calls: w.calls,
+			p50:   percentile(sorted, 50),
+			p95:   percentile(sorted, 95),
+			p99:   percentile(sorted, 99),
+		}
+	}
+	t.windows = make(map[string]*latencyWindow)
+	return stats
+}
+
+// percentile returns the p-th percentile of sorted samples using the nearest-rank method:
+// the smallest sample that at least p percent of the samples do not exceed.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
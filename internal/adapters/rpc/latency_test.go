@@ -0,0 +1,118 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// msDurations returns the durations from..to milliseconds, in steps of one millisecond.
+func msDurations(from, to int) []time.Duration {
+	durations := make([]time.Duration, 0, to-from+1)
+	for ms := from; ms <= to; ms++ {
+		durations = append(durations, time.Duration(ms)*time.Millisecond)
+	}
+	return durations
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{name: "Median of 100", sorted: msDurations(1, 100), p: 50, want: 50 * time.Millisecond},
+		{name: "p95 of 100", sorted: msDurations(1, 100), p: 95, want: 95 * time.Millisecond},
+		{name: "p99 of 100", sorted: msDurations(1, 100), p: 99, want: 99 * time.Millisecond},
+		{name: "p99 of 10 is the maximum", sorted: msDurations(1, 10), p: 99, want: 10 * time.Millisecond},
+		{name: "Median of 3", sorted: msDurations(1, 3), p: 50, want: 2 * time.Millisecond},
+		{name: "Single sample", sorted: msDurations(7, 7), p: 50, want: 7 * time.Millisecond},
+		{name: "No samples", sorted: nil, p: 99, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, percentile(tt.sorted, tt.p))
+		})
+	}
+}
+
+func TestLatencyTracker_DrainComputesPercentilesPerMethod(t *testing.T) {
+	tracker := newLatencyTracker(applogger.NewSlogAdapter(slog.Default()), time.Hour)
+	// Samples are added out of order; they are sorted when the window is drained.
+	for _, d := range msDurations(51, 100) {
+		tracker.add("eth_getBlockByNumber", d)
+	}
+	for _, d := range msDurations(1, 50) {
+		tracker.add("eth_getBlockByNumber", d)
+	}
+	tracker.add("eth_chainId", 3*time.Millisecond)
+
+	stats := tracker.drain()
+
+	ms := time.Millisecond
+	assert.Equal(t, map[string]latencyStats{
+		"eth_getBlockByNumber": {calls: 100, p50: 50 * ms, p95: 95 * ms, p99: 99 * ms},
+		"eth_chainId":          {calls: 1, p50: 3 * ms, p95: 3 * ms, p99: 3 * ms},
+	}, stats)
+	assert.Empty(t, tracker.drain(), "draining starts a new window")
+}
+
+func TestLatencyTracker_KeepsMostRecentSamples(t *testing.T) {
+	tracker := newLatencyTracker(applogger.NewSlogAdapter(slog.Default()), time.Hour)
+	for range maxLatencySamples {
+		tracker.add("eth_blockNumber", time.Second)
+	}
+	for range maxLatencySamples {
+		tracker.add("eth_blockNumber", time.Millisecond)
+	}
+
+	stats := tracker.drain()["eth_blockNumber"]
+	assert.Equal(t, 2*maxLatencySamples, stats.calls)
+	assert.Equal(t, time.Millisecond, stats.p99, "older samples were overwritten")
+}
+
+func TestLatencyTracker_ReportsOncePerInterval(t *testing.T) {
+	var buf bytes.Buffer
+	tracker := newLatencyTracker(applogger.NewSlogAdapter(slog.New(slog.NewJSONHandler(&buf, nil))), time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	for _, d := range msDurations(1, 99) {
+		tracker.observe("eth_getBlockByNumber", d)
+	}
+	assert.Empty(t, buf.String(), "nothing is reported before the interval has passed")
+
+	now = now.Add(time.Minute)
+	tracker.observe("eth_getBlockByNumber", 100*time.Millisecond)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "RPC latency", entry["msg"])
+	assert.Equal(t, "eth_getBlockByNumber", entry["method"])
+	assert.EqualValues(t, 100, entry["calls"])
+	assert.Equal(t, "50ms", entry["p50"])
+	assert.Equal(t, "95ms", entry["p95"])
+	assert.Equal(t, "99ms", entry["p99"])
+
+	buf.Reset()
+	now = now.Add(30 * time.Second)
+	tracker.observe("eth_getBlockByNumber", time.Millisecond)
+	assert.Empty(t, buf.String(), "a new window started with the report")
+}
+
+func TestLatencyTracker_NilIsDisabled(t *testing.T) {
+	var tracker *latencyTracker
+	assert.NotPanics(t, func() { tracker.observe("eth_chainId", time.Millisecond) })
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMethodNotAllowed indicates that a JSON-RPC method is not on the adapter's method allowlist.
+var ErrMethodNotAllowed = errors.New("RPC method not allowed")
+
+// MethodNotAllowedError is returned when the adapter refuses to call a method that is not on its allowlist.
+// No request is sent to the node.
+type MethodNotAllowedError struct {
+	Method string
+}
+
+// Error returns the string representation of the error.
+func (e *MethodNotAllowedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMethodNotAllowed, e.Method)
+}
+
+// Is makes MethodNotAllowedError match ErrMethodNotAllowed.
+func (e *MethodNotAllowedError) Is(target error) bool {
+	return target == ErrMethodNotAllowed
+}
+
+// WithMethodAllowlist restricts the adapter to the given JSON-RPC methods; calls to any other method fail
+// with *MethodNotAllowedError before a request is made. An empty list allows every method.
+func WithMethodAllowlist(methods []string) Option {
+	return func(a *EthereumNodeAdapter) {
+		if len(methods) == 0 {
+			a.allowedMethods = nil
+			return
+		}
+		a.allowedMethods = make(map[string]struct{}, len(methods))
+		for _, m := range methods {
+			a.allowedMethods[m] = struct{}{}
+		}
+	}
+}
+
+// checkMethod returns an error if method is not on the allowlist.
+func (a *EthereumNodeAdapter) checkMethod(method string) error {
+	if a.allowedMethods == nil {
+		return nil
+	}
+	if _, ok := a.allowedMethods[method]; !ok {
+		return &MethodNotAllowedError{Method: method}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package rpc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/rpc"
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthereumNodeAdapter_MethodAllowlist(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client(),
+		rpc.WithMethodAllowlist([]string{"eth_blockNumber"}))
+
+	blockNum, err := adapter.GetLatestBlockNumber(context.Background())
+	require.NoError(t, err, "eth_blockNumber is allowed")
+	assert.Equal(t, int64(16), blockNum.Value())
+	assert.Equal(t, int32(1), hits.Load())
+
+	_, err = adapter.GetChainID(context.Background())
+	require.ErrorIs(t, err, rpc.ErrMethodNotAllowed)
+	var notAllowed *rpc.MethodNotAllowedError
+	require.ErrorAs(t, err, &notAllowed)
+	assert.Equal(t, "eth_chainId", notAllowed.Method)
+
+	hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	_, err = adapter.GetTransactionStatuses(context.Background(), []domain.TransactionHash{hash})
+	require.ErrorIs(t, err, rpc.ErrMethodNotAllowed, "batched calls are checked too")
+
+	assert.Equal(t, int32(1), hits.Load(), "disallowed methods never reach the node")
+}
+
+func TestEthereumNodeAdapter_EmptyMethodAllowlistAllowsAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client(), rpc.WithMethodAllowlist(nil))
+
+	chainID, err := adapter.GetChainID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), chainID)
+}
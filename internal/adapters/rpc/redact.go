@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"errors"
+	"net/url"
+)
+
+// redactEndpoint returns only the scheme and host of an endpoint URL, so it can be logged without the
+// credentials, path and query parameters where node providers put API keys.
+func redactEndpoint(rpcURL string) string {
+	parsed, err := url.Parse(rpcURL)
+	if err != nil || parsed.Host == "" {
+		return "invalid URL"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// redactURLError redacts the request URL that HTTP client errors repeat in their message.
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactEndpoint(urlErr.URL)
+	}
+	return err
+}
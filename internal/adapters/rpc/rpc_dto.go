@@ -9,7 +9,7 @@ type JSONRPCRequest struct {
 	JSONRPC string        `json:"jsonrpc"`
 	Method  string        `json:"method"`
 	Params  []interface{} `json:"params"`
-	ID      int           `json:"id"`
+	ID      int64         `json:"id"`
 }
 
 // Error represents the error object in a JSON-RPC response.
@@ -21,29 +21,32 @@ type Error struct {
 // JSONRPCResponse represents the basic structure of a JSON-RPC response.
 type JSONRPCResponse struct {
 	JSONRPC string          `json:"jsonrpc"`
-	ID      int             `json:"id"`
+	ID      int64           `json:"id"`
 	Result  json.RawMessage `json:"result,omitempty"`
 	Error   *Error          `json:"error,omitempty"`
 }
 
 // Transaction represents the DTO for a transaction from the Ethereum node.
+// MaxFeePerGas and MaxPriorityFeePerGas are present only for EIP-1559 (type 2 and later) transactions.
 type Transaction struct {
-	BlockHash        *string `json:"blockHash"`
-	BlockNumber      *string `json:"blockNumber"`
-	From             string  `json:"from"`
-	Gas              string  `json:"gas"`
-	GasPrice         string  `json:"gasPrice"`
-	Hash             string  `json:"hash"`
-	Input            string  `json:"input"`
-	Nonce            string  `json:"nonce"`
-	To               *string `json:"to"`
-	TransactionIndex *string `json:"transactionIndex"`
-	Value            string  `json:"value"`
-	Type             string  `json:"type"`
-	ChainID          *string `json:"chainId,omitempty"`
-	V                string  `json:"v"`
-	R                string  `json:"r"`
-	S                string  `json:"s"`
+	BlockHash            *string `json:"blockHash"`
+	BlockNumber          *string `json:"blockNumber"`
+	From                 string  `json:"from"`
+	Gas                  string  `json:"gas"`
+	GasPrice             string  `json:"gasPrice"`
+	MaxFeePerGas         *string `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *string `json:"maxPriorityFeePerGas,omitempty"`
+	Hash                 string  `json:"hash"`
+	Input                string  `json:"input"`
+	Nonce                string  `json:"nonce"`
+	To                   *string `json:"to"`
+	TransactionIndex     *string `json:"transactionIndex"`
+	Value                string  `json:"value"`
+	Type                 string  `json:"type"`
+	ChainID              *string `json:"chainId,omitempty"`
+	V                    string  `json:"v"`
+	R                    string  `json:"r"`
+	S                    string  `json:"s"`
 }
 
 // Block represents the DTO for a block from the Ethereum node.
@@ -69,3 +72,11 @@ type Block struct {
 	Uncles           []string      `json:"uncles"`
 	BaseFeePerGas    *string       `json:"baseFeePerGas,omitempty"`
 }
+
+// TransactionReceipt represents the DTO for the fields of a transaction receipt used by the parser.
+// Status is absent for receipts of pre-Byzantium transactions.
+type TransactionReceipt struct {
+	TransactionHash string  `json:"transactionHash"`
+	BlockNumber     string  `json:"blockNumber"`
+	Status          *string `json:"status"`
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JSON-RPC error codes defined by the JSON-RPC 2.0 specification and EIP-1474.
+const (
+	CodeParseError       = -32700
+	CodeInvalidRequest   = -32600
+	CodeMethodNotFound   = -32601
+	CodeInvalidParams    = -32602
+	CodeInternalError    = -32603
+	CodeInvalidInput     = -32000
+	CodeResourceNotFound = -32001
+	CodeLimitExceeded    = -32005
+)
+
+// rateLimitCodes lists codes used by the specification and common providers to signal throttling.
+var rateLimitCodes = map[int]struct{}{
+	CodeLimitExceeded: {},
+	-32007:            {}, // request limit reached
+	-32029:            {}, // too many requests
+	-32090:            {}, // rate limited, retry later
+	429:               {}, // HTTP status echoed as code
+}
+
+// RPCError is an error object returned by the node in a JSON-RPC response.
+type RPCError struct {
+	Code    int
+	Message string
+}
+
+// Error returns the string representation of the RPC error.
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error: code=%d, message='%s'", e.Code, e.Message)
+}
+
+// IsRateLimited reports whether the node rejected the request because of rate limiting.
+func (e *RPCError) IsRateLimited() bool {
+	if _, ok := rateLimitCodes[e.Code]; ok {
+		return true
+	}
+	msg := strings.ToLower(e.Message)
+	return strings.Contains(msg, "rate limit") || strings.Contains(msg, "too many requests")
+}
+
+// IsNotFound reports whether the requested resource (block, transaction, ...) does not exist on the node.
+func (e *RPCError) IsNotFound() bool {
+	if e.Code == CodeResourceNotFound {
+		return true
+	}
+	return e.Code == CodeInvalidInput && strings.Contains(strings.ToLower(e.Message), "not found")
+}
+
+// IsInvalidParams reports whether the request parameters were rejected by the node.
+func (e *RPCError) IsInvalidParams() bool {
+	return e.Code == CodeInvalidParams
+}
+
+// IsMethodNotFound reports whether the node does not support the requested method.
+func (e *RPCError) IsMethodNotFound() bool {
+	return e.Code == CodeMethodNotFound
+}
@@ -0,0 +1,80 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/rpc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCError_Classification(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                rpc.RPCError
+		wantRateLimited    bool
+		wantNotFound       bool
+		wantInvalidParams  bool
+		wantMethodNotFound bool
+	}{
+		{name: "Limit exceeded", err: rpc.RPCError{Code: -32005, Message: "limit exceeded"}, wantRateLimited: true},
+		{name: "Provider request limit", err: rpc.RPCError{Code: -32007, Message: "request limit reached"}, wantRateLimited: true},
+		{name: "HTTP 429 as code", err: rpc.RPCError{Code: 429, Message: "Too Many Requests"}, wantRateLimited: true},
+		{
+			name:            "Rate limit by message",
+			err:             rpc.RPCError{Code: -32000, Message: "Your app has exceeded its rate limit"},
+			wantRateLimited: true,
+		},
+		{name: "Resource not found", err: rpc.RPCError{Code: -32001, Message: "resource not found"}, wantNotFound: true},
+		{name: "Header not found", err: rpc.RPCError{Code: -32000, Message: "header not found"}, wantNotFound: true},
+		{name: "Invalid params", err: rpc.RPCError{Code: -32602, Message: "invalid argument 0"}, wantInvalidParams: true},
+		{name: "Method not found", err: rpc.RPCError{Code: -32601, Message: "the method does not exist"}, wantMethodNotFound: true},
+		{name: "Internal error", err: rpc.RPCError{Code: -32603, Message: "internal error"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantRateLimited, tt.err.IsRateLimited())
+			assert.Equal(t, tt.wantNotFound, tt.err.IsNotFound())
+			assert.Equal(t, tt.wantInvalidParams, tt.err.IsInvalidParams())
+			assert.Equal(t, tt.wantMethodNotFound, tt.err.IsMethodNotFound())
+		})
+	}
+}
+
+func TestEthereumNodeAdapter_ReturnsTypedRPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid argument"}}`))
+	}))
+	defer server.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{server.URL}, server.Client())
+
+	_, err := adapter.GetLatestBlockNumber(context.Background())
+	var rpcErr *rpc.RPCError
+	require.True(t, errors.As(err, &rpcErr), "error should wrap *rpc.RPCError")
+	assert.Equal(t, -32602, rpcErr.Code)
+	assert.True(t, rpcErr.IsInvalidParams())
+}
+
+func TestEthereumNodeAdapter_RateLimitedFailsOver(t *testing.T) {
+	limited := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"limit exceeded"}}`))
+	}))
+	defer limited.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer healthy.Close()
+
+	adapter := rpc.NewEthereumNodeAdapter([]string{limited.URL, healthy.URL}, healthy.Client())
+
+	blockNum, err := adapter.GetLatestBlockNumber(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(16), blockNum.Value())
+}
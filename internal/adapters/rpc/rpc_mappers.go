@@ -2,45 +2,108 @@ package rpc
 
 import (
 	"fmt"
-	"log"
+	"math"
 
 	"trust_wallet_homework/internal/core/domain"
 	"trust_wallet_homework/internal/utils"
 )
 
+// parseMode selects how mapRPCBlockToDomain handles malformed transactions.
+// Malformed block-level fields (number, hash, timestamp) always fail the block, as it cannot be identified.
+type parseMode int
+
+const (
+	// parseModeLenient skips malformed transactions and reports them to the caller.
+	parseModeLenient parseMode = iota
+	// parseModeStrict fails the whole block on any malformed field.
+	parseModeStrict
+)
+
 // mapRPCBlockToDomain converts the RPC DTO for a block to the domain model.
-func mapRPCBlockToDomain(rpcBlock *Block) (*domain.Block, error) {
+// In lenient mode it also returns one error per malformed transaction that was left out of the block.
+func mapRPCBlockToDomain(rpcBlock *Block, mode parseMode) (*domain.Block, []error, error) {
+	header, err := mapRPCBlockHeader(rpcBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var skipped []error
+	domainTxs := make([]domain.Transaction, 0, len(rpcBlock.Transactions))
+	for i := range rpcBlock.Transactions {
+		domainTx, skipErr, err := mapRPCBlockTransaction(&rpcBlock.Transactions[i], i, header, mode)
+		if err != nil {
+			return nil, nil, err
+		}
+		if skipErr != nil {
+			skipped = append(skipped, skipErr)
+			continue
+		}
+		domainTxs = append(domainTxs, *domainTx)
+	}
+
+	header.Transactions = domainTxs
+	return &header, skipped, nil
+}
+
+// mapRPCBlockHeader converts the number, hash, timestamp and base fee of an RPC block; its transactions are
+// ignored. These fields describe the block as a whole, so a malformed one fails the block in every parse mode.
+func mapRPCBlockHeader(rpcBlock *Block) (domain.Block, error) {
 	num, err := utils.HexToInt64(rpcBlock.Number)
 	if err != nil {
-		return nil, fmt.Errorf("invalid block number hex '%s': %w", rpcBlock.Number, err)
+		return domain.Block{}, fmt.Errorf("invalid block number hex '%s': %w", rpcBlock.Number, err)
 	}
 	domainBlockNum, err := domain.NewBlockNumber(num)
 	if err != nil {
-		return nil, fmt.Errorf("failed creating domain block number: %w", err)
+		return domain.Block{}, fmt.Errorf("failed creating domain block number: %w", err)
 	}
 
 	domainBlockHash, err := domain.NewBlockHash(rpcBlock.Hash)
 	if err != nil {
-		return nil, fmt.Errorf("failed creating domain block hash: %w", err)
+		return domain.Block{}, fmt.Errorf("failed creating domain block hash: %w", err)
 	}
 
 	timestamp, err := utils.HexToUint64(rpcBlock.Timestamp)
 	if err != nil {
-		return nil, fmt.Errorf("invalid block timestamp hex '%s': %w", rpcBlock.Timestamp, err)
+		return domain.Block{}, fmt.Errorf("invalid block timestamp hex '%s': %w", rpcBlock.Timestamp, err)
 	}
 
-	domainTxs := make([]domain.Transaction, 0, len(rpcBlock.Transactions))
-	for i, rpcTx := range rpcBlock.Transactions {
-		domainTx, err := mapRPCTransactionToDomain(&rpcTx, domainBlockNum, timestamp)
-		if err != nil {
-			log.Printf("Error mapping transaction index %d (hash: %s) in block %d: %v", i, rpcTx.Hash, num, err)
-			continue
-		}
-		domainTxs = append(domainTxs, *domainTx)
+	baseFee, err := mapRPCBaseFee(rpcBlock)
+	if err != nil {
+		return domain.Block{}, err
 	}
 
-	domainBlock := domain.NewBlock(domainBlockNum, domainBlockHash, timestamp, domainTxs)
-	return &domainBlock, nil
+	block := domain.NewBlock(domainBlockNum, domainBlockHash, timestamp, nil)
+	block.BaseFeePerGas = baseFee
+	return block, nil
+}
+
+// mapRPCBaseFee converts the base fee of an RPC block; it is zero for blocks before the London fork.
+func mapRPCBaseFee(rpcBlock *Block) (domain.WeiValue, error) {
+	baseFee, err := optionalWeiValue(rpcBlock.BaseFeePerGas)
+	if err != nil {
+		return domain.WeiValue{}, fmt.Errorf("invalid block base fee '%s': %w", *rpcBlock.BaseFeePerGas, err)
+	}
+	return baseFee, nil
+}
+
+// mapRPCBlockTransaction converts the transaction at index i of the block with the given header.
+// A malformed transaction fails the block in strict mode; in lenient mode it is reported in skipErr instead.
+func mapRPCBlockTransaction(
+	rpcTx *Transaction,
+	i int,
+	header domain.Block,
+	mode parseMode,
+) (tx *domain.Transaction, skipErr, err error) {
+	domainTx, err := mapRPCTransactionToDomain(rpcTx, header.Number, header.Timestamp)
+	if err != nil {
+		txErr := fmt.Errorf("invalid transaction at index %d (hash: %s) in block %d: %w",
+			i, rpcTx.Hash, header.Number.Value(), err)
+		if mode == parseModeStrict {
+			return nil, nil, txErr
+		}
+		return nil, txErr, nil
+	}
+	return domainTx, nil, nil
 }
 
 // mapRPCTransactionToDomain converts the RPC DTO for a transaction to the domain model.
@@ -72,6 +135,72 @@ func mapRPCTransactionToDomain(
 		return nil, fmt.Errorf("invalid tx value '%s': %w", rpcTx.Value, err)
 	}
 
+	var index uint64
+	if rpcTx.TransactionIndex != nil {
+		index, err = utils.HexToUint64(*rpcTx.TransactionIndex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx index hex '%s': %w", *rpcTx.TransactionIndex, err)
+		}
+	}
+
 	domainTx := domain.NewTransaction(hash, from, to, value, blockNum, blockTimestamp)
+	domainTx.TransactionIndex = index
+	domainTx.Input = rpcTx.Input
+	if err := mapRPCTransactionFees(rpcTx, &domainTx); err != nil {
+		return nil, err
+	}
 	return &domainTx, nil
 }
+
+// mapRPCTransactionFees sets the type and the fee fields of tx from the RPC DTO. Fields the node does not
+// report, such as the EIP-1559 fee caps of legacy transactions, stay zero.
+func mapRPCTransactionFees(rpcTx *Transaction, tx *domain.Transaction) error {
+	if rpcTx.Type != "" {
+		txType, err := utils.HexToUint64(rpcTx.Type)
+		if err != nil || txType > math.MaxUint8 {
+			return fmt.Errorf("invalid tx type hex '%s'", rpcTx.Type)
+		}
+		tx.Type = domain.TransactionType(txType)
+	}
+
+	var err error
+	if rpcTx.GasPrice != "" {
+		if tx.GasPrice, err = domain.NewWeiValue(rpcTx.GasPrice); err != nil {
+			return fmt.Errorf("invalid tx gas price '%s': %w", rpcTx.GasPrice, err)
+		}
+	}
+	if tx.MaxFeePerGas, err = optionalWeiValue(rpcTx.MaxFeePerGas); err != nil {
+		return fmt.Errorf("invalid tx max fee per gas '%s': %w", *rpcTx.MaxFeePerGas, err)
+	}
+	if tx.MaxPriorityFeePerGas, err = optionalWeiValue(rpcTx.MaxPriorityFeePerGas); err != nil {
+		return fmt.Errorf("invalid tx max priority fee per gas '%s': %w", *rpcTx.MaxPriorityFeePerGas, err)
+	}
+	return nil
+}
+
+// optionalWeiValue converts an optional RPC quantity; an absent or empty one is the zero WeiValue.
+func optionalWeiValue(s *string) (domain.WeiValue, error) {
+	if s == nil || *s == "" {
+		return domain.WeiValue{}, nil
+	}
+	return domain.NewWeiValue(*s)
+}
+
+// mapRPCReceiptStatus converts the status of an RPC receipt to the domain transaction status.
+func mapRPCReceiptStatus(receipt *TransactionReceipt) (domain.TransactionStatus, error) {
+	if receipt.Status == nil {
+		return domain.TransactionStatusUnknown, nil
+	}
+	status, err := utils.HexToUint64(*receipt.Status)
+	if err != nil {
+		return domain.TransactionStatusUnknown, fmt.Errorf("invalid receipt status hex '%s': %w", *receipt.Status, err)
+	}
+	switch status {
+	case 0:
+		return domain.TransactionStatusFailed, nil
+	case 1:
+		return domain.TransactionStatusSuccess, nil
+	default:
+		return domain.TransactionStatusUnknown, fmt.Errorf("unexpected receipt status %d", status)
+	}
+}
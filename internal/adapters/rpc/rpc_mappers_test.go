@@ -0,0 +1,242 @@
+package rpc
+
+import (
+	"testing"
+
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapRPCTransactionToDomain_Recipient(t *testing.T) {
+	const (
+		sender      = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		recipient   = "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+		zeroAddress = "0x0000000000000000000000000000000000000000"
+	)
+	empty, regular, zero := "", recipient, zeroAddress
+
+	tests := []struct {
+		name                 string
+		to                   *string
+		wantContractCreation bool
+		wantTo               string
+	}{
+		{name: "Absent recipient is a contract creation", to: nil, wantContractCreation: true},
+		{name: "Empty recipient is a contract creation", to: &empty, wantContractCreation: true},
+		{name: "Regular recipient", to: &regular, wantTo: recipient},
+		{name: "Zero address is a regular recipient", to: &zero, wantTo: zeroAddress},
+	}
+
+	blockNum, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcTx := &Transaction{
+				Hash:  "0x1111111111111111111111111111111111111111111111111111111111111111",
+				From:  sender,
+				To:    tt.to,
+				Value: "0x0",
+			}
+
+			tx, err := mapRPCTransactionToDomain(rpcTx, blockNum, 1000)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantContractCreation, tx.IsContractCreation())
+			assert.Equal(t, tt.wantTo, tx.To.String())
+		})
+	}
+}
+
+func TestMapRPCTransactionToDomain_InvalidRecipient(t *testing.T) {
+	invalid := "0x1234"
+	blockNum, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+
+	_, err = mapRPCTransactionToDomain(&Transaction{
+		Hash:  "0x1111111111111111111111111111111111111111111111111111111111111111",
+		From:  "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		To:    &invalid,
+		Value: "0x0",
+	}, blockNum, 1000)
+	assert.ErrorIs(t, err, domain.ErrInvalidAddressFormat)
+}
+
+func TestMapRPCTransactionToDomain_TransactionIndex(t *testing.T) {
+	present, invalid := "0x1a", "0xzz"
+	tests := []struct {
+		name      string
+		index     *string
+		wantIndex uint64
+		wantErr   bool
+	}{
+		{name: "Present index", index: &present, wantIndex: 26},
+		{name: "Absent index of a pending transaction", index: nil, wantIndex: 0},
+		{name: "Invalid index", index: &invalid, wantErr: true},
+	}
+
+	blockNum, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := mapRPCTransactionToDomain(&Transaction{
+				Hash:             "0x1111111111111111111111111111111111111111111111111111111111111111",
+				From:             "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Value:            "0x0",
+				TransactionIndex: tt.index,
+			}, blockNum, 1000)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIndex, tx.TransactionIndex)
+		})
+	}
+}
+
+func TestMapRPCBlockToDomain_ParseModes(t *testing.T) {
+	const (
+		validBlockHash = "0x2222222222222222222222222222222222222222222222222222222222222222"
+		validTxHash    = "0x1111111111111111111111111111111111111111111111111111111111111111"
+		sender         = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	)
+	validTx := Transaction{Hash: validTxHash, From: sender, Value: "0x1"}
+	badTx := Transaction{Hash: "0xbad", From: sender, Value: "0x1"}
+
+	tests := []struct {
+		name        string
+		mode        parseMode
+		blockHash   string
+		txs         []Transaction
+		wantErr     bool
+		wantTxs     int
+		wantSkipped int
+	}{
+		{name: "Lenient skips a bad transaction", mode: parseModeLenient, blockHash: validBlockHash,
+			txs: []Transaction{validTx, badTx}, wantTxs: 1, wantSkipped: 1},
+		{name: "Strict fails on a bad transaction", mode: parseModeStrict, blockHash: validBlockHash,
+			txs: []Transaction{validTx, badTx}, wantErr: true},
+		{name: "Lenient fails on a bad block hash", mode: parseModeLenient, blockHash: "0x1234",
+			txs: []Transaction{validTx}, wantErr: true},
+		{name: "Strict fails on a bad block hash", mode: parseModeStrict, blockHash: "0x1234",
+			txs: []Transaction{validTx}, wantErr: true},
+		{name: "Strict accepts a well-formed block", mode: parseModeStrict, blockHash: validBlockHash,
+			txs: []Transaction{validTx}, wantTxs: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcBlock := &Block{Number: "0x10", Hash: tt.blockHash, Timestamp: "0x5", Transactions: tt.txs}
+
+			block, skipped, err := mapRPCBlockToDomain(rpcBlock, tt.mode)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, block)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, block.Transactions, tt.wantTxs)
+			assert.Len(t, skipped, tt.wantSkipped)
+		})
+	}
+}
+
+func TestMapRPCTransactionToDomain_Fees(t *testing.T) {
+	gwei, twoGwei, invalid := "0x3b9aca00", "0x77359400", "0xzz"
+	tests := []struct {
+		name            string
+		rpcTx           Transaction
+		wantType        domain.TransactionType
+		wantGasPrice    string
+		wantMaxFee      string
+		wantPriorityFee string
+		wantErr         bool
+	}{
+		{
+			name: "EIP-1559 transaction",
+			rpcTx: Transaction{Type: "0x2", GasPrice: gwei, MaxFeePerGas: &twoGwei,
+				MaxPriorityFeePerGas: &gwei},
+			wantType:        domain.TransactionTypeDynamicFee,
+			wantGasPrice:    gwei,
+			wantMaxFee:      twoGwei,
+			wantPriorityFee: gwei,
+		},
+		{
+			name:            "Legacy transaction has no fee caps",
+			rpcTx:           Transaction{Type: "0x0", GasPrice: gwei},
+			wantType:        domain.TransactionTypeLegacy,
+			wantGasPrice:    gwei,
+			wantMaxFee:      "0x0",
+			wantPriorityFee: "0x0",
+		},
+		{
+			name:            "Node without type and fee fields",
+			rpcTx:           Transaction{},
+			wantType:        domain.TransactionTypeLegacy,
+			wantGasPrice:    "0x0",
+			wantMaxFee:      "0x0",
+			wantPriorityFee: "0x0",
+		},
+		{name: "Invalid type", rpcTx: Transaction{Type: invalid}, wantErr: true},
+		{name: "Type out of range", rpcTx: Transaction{Type: "0x100"}, wantErr: true},
+		{name: "Invalid gas price", rpcTx: Transaction{GasPrice: invalid}, wantErr: true},
+		{name: "Invalid max fee", rpcTx: Transaction{Type: "0x2", MaxFeePerGas: &invalid}, wantErr: true},
+		{name: "Invalid priority fee", rpcTx: Transaction{Type: "0x2", MaxPriorityFeePerGas: &invalid}, wantErr: true},
+	}
+
+	blockNum, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcTx := tt.rpcTx
+			rpcTx.Hash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+			rpcTx.From = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+			rpcTx.Value = "0x0"
+
+			tx, err := mapRPCTransactionToDomain(&rpcTx, blockNum, 1000)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, tx.Type)
+			assert.Equal(t, tt.wantGasPrice, tx.GasPrice.String())
+			assert.Equal(t, tt.wantMaxFee, tx.MaxFeePerGas.String())
+			assert.Equal(t, tt.wantPriorityFee, tx.MaxPriorityFeePerGas.String())
+		})
+	}
+}
+
+func TestMapRPCBlockToDomain_BaseFee(t *testing.T) {
+	baseFee, invalid := "0x2540be400", "0xzz"
+	tests := []struct {
+		name        string
+		baseFee     *string
+		wantBaseFee string
+		wantErr     bool
+	}{
+		{name: "Post-London block", baseFee: &baseFee, wantBaseFee: baseFee},
+		{name: "Pre-London block", baseFee: nil, wantBaseFee: "0x0"},
+		{name: "Invalid base fee", baseFee: &invalid, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpcBlock := &Block{
+				Number:        "0x10",
+				Hash:          "0x2222222222222222222222222222222222222222222222222222222222222222",
+				Timestamp:     "0x5",
+				BaseFeePerGas: tt.baseFee,
+			}
+
+			block, _, err := mapRPCBlockToDomain(rpcBlock, parseModeStrict)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBaseFee, block.BaseFeePerGas.String())
+		})
+	}
+}
@@ -3,6 +3,8 @@ package address
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"sync"
 
 	"trust_wallet_homework/internal/core/domain"
@@ -10,9 +12,10 @@ import (
 )
 
 // InMemoryAddressRepo implements the MonitoredAddressRepository interface using an in-memory map.
+// Every method returns the context error without doing any work if its context is already done.
 type InMemoryAddressRepo struct {
 	mu        sync.RWMutex
-	addresses map[domain.Address]struct{}
+	addresses map[domain.Address]domain.SubscriptionDirection
 }
 
 // Compile-time check to ensure InMemoryAddressRepo implements repository.MonitoredAddressRepository
@@ -21,21 +24,31 @@ var _ repository.MonitoredAddressRepository = (*InMemoryAddressRepo)(nil)
 // NewInMemoryAddressRepo creates a new in-memory address repository.
 func NewInMemoryAddressRepo() *InMemoryAddressRepo {
 	return &InMemoryAddressRepo{
-		addresses: make(map[domain.Address]struct{}),
+		addresses: make(map[domain.Address]domain.SubscriptionDirection),
 	}
 }
 
-// Add persists a new address to be monitored.
-func (r *InMemoryAddressRepo) Add(_ context.Context, address domain.Address) error {
+// Add persists a new address to be monitored together with its subscription direction.
+func (r *InMemoryAddressRepo) Add(
+	ctx context.Context,
+	address domain.Address,
+	direction domain.SubscriptionDirection,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.addresses[address] = struct{}{}
+	r.addresses[address] = direction
 	return nil
 }
 
 // Exists checks if a given address is already being monitored.
-func (r *InMemoryAddressRepo) Exists(_ context.Context, address domain.Address) (bool, error) {
+func (r *InMemoryAddressRepo) Exists(ctx context.Context, address domain.Address) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -44,7 +57,10 @@ func (r *InMemoryAddressRepo) Exists(_ context.Context, address domain.Address)
 }
 
 // FindAll retrieves all addresses currently being monitored.
-func (r *InMemoryAddressRepo) FindAll(_ context.Context) ([]domain.Address, error) {
+func (r *InMemoryAddressRepo) FindAll(ctx context.Context) ([]domain.Address, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -54,3 +70,60 @@ func (r *InMemoryAddressRepo) FindAll(_ context.Context) ([]domain.Address, erro
 	}
 	return addrList, nil
 }
+
+// FindPage retrieves at most limit monitored addresses ordered by their string form, skipping the first offset.
+// A non-positive limit or an offset past the last address yields an empty page; a negative offset counts as 0.
+func (r *InMemoryAddressRepo) FindPage(ctx context.Context, limit, offset int) ([]domain.Address, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	addrList := make([]domain.Address, 0, len(r.addresses))
+	for addr := range r.addresses {
+		addrList = append(addrList, addr)
+	}
+	r.mu.RUnlock()
+
+	offset = max(offset, 0)
+	if limit <= 0 || offset >= len(addrList) {
+		return []domain.Address{}, nil
+	}
+	slices.SortFunc(addrList, func(a, b domain.Address) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	end := offset + min(limit, len(addrList)-offset)
+	return addrList[offset:end], nil
+}
+
+// Count returns the number of addresses currently being monitored.
+func (r *InMemoryAddressRepo) Count(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.addresses), nil
+}
+
+// FindAllWithDirection retrieves all monitored addresses mapped to their subscription direction.
+func (r *InMemoryAddressRepo) FindAllWithDirection(
+	ctx context.Context,
+) (map[domain.Address]domain.SubscriptionDirection, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	directions := make(map[domain.Address]domain.SubscriptionDirection, len(r.addresses))
+	for addr, direction := range r.addresses {
+		directions[addr] = direction
+	}
+	return directions, nil
+}
+
+// Ping reports only a done context: in-memory storage is always writable.
+func (r *InMemoryAddressRepo) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
@@ -33,7 +33,7 @@ func TestInMemoryAddressRepo_AddExistsFindAll(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, exists2)
 
-	err = repo.Add(ctx, addr1)
+	err = repo.Add(ctx, addr1, domain.SubscriptionDirectionBoth)
 	require.NoError(t, err)
 
 	exists1, err = repo.Exists(ctx, addr1)
@@ -45,10 +45,10 @@ func TestInMemoryAddressRepo_AddExistsFindAll(t *testing.T) {
 	assert.Len(t, addrsAfter1, 1)
 	assert.Contains(t, addrsAfter1, addr1)
 
-	err = repo.Add(ctx, addr2)
+	err = repo.Add(ctx, addr2, domain.SubscriptionDirectionBoth)
 	require.NoError(t, err)
 
-	err = repo.Add(ctx, addr1)
+	err = repo.Add(ctx, addr1, domain.SubscriptionDirectionBoth)
 	require.NoError(t, err)
 
 	exists1, err = repo.Exists(ctx, addr1)
@@ -62,4 +62,83 @@ func TestInMemoryAddressRepo_AddExistsFindAll(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, addrsAfter2, 2)
 	assert.ElementsMatch(t, []domain.Address{addr1, addr2}, addrsAfter2)
+
+	count, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "re-adding an address must not count it twice")
+}
+
+func TestInMemoryAddressRepo_CancelledContext(t *testing.T) {
+	repo := address.NewInMemoryAddressRepo()
+	addr, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, repo.Add(ctx, addr, domain.SubscriptionDirectionBoth), context.Canceled)
+	_, err = repo.Exists(ctx, addr)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.FindAll(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.FindAllWithDirection(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.Count(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, repo.Ping(ctx), context.Canceled)
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	exists, err := repo.Exists(context.Background(), addr)
+	require.NoError(t, err)
+	assert.False(t, exists, "a cancelled Add must not store the address")
+}
+
+func TestInMemoryAddressRepo_FindPage(t *testing.T) {
+	repo := address.NewInMemoryAddressRepo()
+	ctx := context.Background()
+
+	// Added out of order: pages must follow the string order regardless of insertion order.
+	var all []domain.Address
+	for _, s := range []string{
+		"0xcccccccccccccccccccccccccccccccccccccccc",
+		"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		"0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		"0xdddddddddddddddddddddddddddddddddddddddd",
+	} {
+		addr, err := domain.NewAddress(s)
+		require.NoError(t, err)
+		require.NoError(t, repo.Add(ctx, addr, domain.SubscriptionDirectionBoth))
+		all = append(all, addr)
+	}
+	sorted := []domain.Address{all[1], all[3], all[0], all[4], all[2]}
+
+	tests := []struct {
+		name   string
+		limit  int
+		offset int
+		want   []domain.Address
+	}{
+		{name: "First page", limit: 2, offset: 0, want: sorted[:2]},
+		{name: "Middle page", limit: 2, offset: 2, want: sorted[2:4]},
+		{name: "Last partial page", limit: 2, offset: 4, want: sorted[4:]},
+		{name: "Limit larger than the set", limit: 10, offset: 0, want: sorted},
+		{name: "Offset at the end", limit: 2, offset: 5, want: []domain.Address{}},
+		{name: "Offset past the end", limit: 2, offset: 50, want: []domain.Address{}},
+		{name: "Zero limit", limit: 0, offset: 0, want: []domain.Address{}},
+		{name: "Negative offset starts at the beginning", limit: 1, offset: -1, want: sorted[:1]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.FindPage(ctx, tt.limit, tt.offset)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := repo.FindPage(cancelled, 1, 0)
+	assert.ErrorIs(t, err, context.Canceled)
 }
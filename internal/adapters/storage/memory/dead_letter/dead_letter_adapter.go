@@ -0,0 +1,78 @@
+// Package dead_letter provides an in-memory implementation of the DeadLetterStore interface.
+package dead_letter
+
+import (
+	"context"
+	"sync"
+
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
+)
+
+// InMemoryDeadLetterStore is an in-memory implementation of DeadLetterStore.
+type InMemoryDeadLetterStore struct {
+	mu         sync.RWMutex
+	letters    []repository.DeadLetter
+	maxLetters int
+}
+
+// Compile-time check to ensure InMemoryDeadLetterStore implements repository.DeadLetterStore
+var _ repository.DeadLetterStore = (*InMemoryDeadLetterStore)(nil)
+
+// Option configures optional behavior of the InMemoryDeadLetterStore.
+type Option func(*InMemoryDeadLetterStore)
+
+// WithMaxLetters keeps at most limit dead letters; recording one more drops the oldest.
+// A limit of zero or less keeps every dead letter.
+func WithMaxLetters(limit int) Option {
+	return func(s *InMemoryDeadLetterStore) {
+		s.maxLetters = max(limit, 0)
+	}
+}
+
+// NewInMemoryDeadLetterStore creates a new InMemoryDeadLetterStore.
+func NewInMemoryDeadLetterStore(opts ...Option) *InMemoryDeadLetterStore {
+	s := &InMemoryDeadLetterStore{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Add records a transaction that could not be stored, dropping the oldest dead letter if the store is full.
+func (s *InMemoryDeadLetterStore) Add(_ context.Context, letter repository.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letter.Addresses = append([]domain.Address(nil), letter.Addresses...)
+	if s.maxLetters > 0 && len(s.letters) >= s.maxLetters {
+		// Shift in place rather than reslicing, so the backing array does not keep growing.
+		n := copy(s.letters, s.letters[len(s.letters)-s.maxLetters+1:])
+		s.letters = s.letters[:n]
+	}
+	s.letters = append(s.letters, letter)
+	return nil
+}
+
+// FindAll retrieves all recorded dead letters, oldest first.
+func (s *InMemoryDeadLetterStore) FindAll(_ context.Context) ([]repository.DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letters := make([]repository.DeadLetter, len(s.letters))
+	copy(letters, s.letters)
+	return letters, nil
+}
+
+// Ping always succeeds: in-memory storage is always writable.
+func (s *InMemoryDeadLetterStore) Ping(_ context.Context) error {
+	return nil
+}
+
+// Count returns the number of recorded dead letters.
+func (s *InMemoryDeadLetterStore) Count(_ context.Context) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.letters), nil
+}
@@ -0,0 +1,62 @@
+package dead_letter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/dead_letter"
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryDeadLetterStore_AddAndFindAll(t *testing.T) {
+	store := dead_letter.NewInMemoryDeadLetterStore()
+	ctx := context.Background()
+
+	letters, err := store.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+
+	addr, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	first := repository.DeadLetter{
+		Addresses: []domain.Address{addr}, Attempts: 3, Reason: "disk full", FailedAt: time.Unix(1, 0),
+	}
+	second := repository.DeadLetter{Attempts: 1, Reason: "timeout", FailedAt: time.Unix(2, 0)}
+	require.NoError(t, store.Add(ctx, first))
+	require.NoError(t, store.Add(ctx, second))
+
+	letters, err = store.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []repository.DeadLetter{first, second}, letters)
+	count, err := store.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	letters[0].Reason = "modified"
+	again, err := store.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "disk full", again[0].Reason, "FindAll must return a copy")
+}
+
+func TestInMemoryDeadLetterStore_WithMaxLetters_DropsOldest(t *testing.T) {
+	store := dead_letter.NewInMemoryDeadLetterStore(dead_letter.WithMaxLetters(2))
+	ctx := context.Background()
+
+	var added []repository.DeadLetter
+	for i := range 5 {
+		letter := repository.DeadLetter{Attempts: i + 1, Reason: "disk full", FailedAt: time.Unix(int64(i), 0)}
+		require.NoError(t, store.Add(ctx, letter))
+		added = append(added, letter)
+	}
+
+	letters, err := store.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, added[3:], letters, "only the most recent dead letters are kept")
+	count, err := store.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
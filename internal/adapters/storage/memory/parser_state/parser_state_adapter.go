@@ -9,10 +9,16 @@ import (
 	"trust_wallet_homework/internal/core/domain/repository"
 )
 
+// processedBlocksWindow is how many of the most recent block numbers are remembered as processed.
+const processedBlocksWindow = 1024
+
 // InMemoryParserStateRepo is an in-memory implementation of ParserStateRepository.
+// Every method returns the context error without doing any work if its context is already done.
 type InMemoryParserStateRepo struct {
 	mu               sync.RWMutex
 	lastScannedBlock *domain.BlockNumber
+	processedBlocks  map[int64]struct{}
+	highestProcessed int64
 }
 
 // Compile-time check to ensure InMemoryParserStateRepo implements repository.ParserStateRepository
@@ -20,11 +26,17 @@ var _ repository.ParserStateRepository = (*InMemoryParserStateRepo)(nil)
 
 // NewInMemoryParserStateRepo creates a new InMemoryParserStateRepo.
 func NewInMemoryParserStateRepo() *InMemoryParserStateRepo {
-	return &InMemoryParserStateRepo{}
+	return &InMemoryParserStateRepo{
+		processedBlocks:  make(map[int64]struct{}),
+		highestProcessed: -1,
+	}
 }
 
 // GetCurrentBlock retrieves the last scanned block number.
-func (r *InMemoryParserStateRepo) GetCurrentBlock(_ context.Context) (domain.BlockNumber, error) {
+func (r *InMemoryParserStateRepo) GetCurrentBlock(ctx context.Context) (domain.BlockNumber, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.BlockNumber{}, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -35,7 +47,10 @@ func (r *InMemoryParserStateRepo) GetCurrentBlock(_ context.Context) (domain.Blo
 }
 
 // SetCurrentBlock stores the last scanned block number.
-func (r *InMemoryParserStateRepo) SetCurrentBlock(_ context.Context, blockNumber domain.BlockNumber) error {
+func (r *InMemoryParserStateRepo) SetCurrentBlock(ctx context.Context, blockNumber domain.BlockNumber) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -43,3 +58,50 @@ func (r *InMemoryParserStateRepo) SetCurrentBlock(_ context.Context, blockNumber
 	r.lastScannedBlock = &bnCopy
 	return nil
 }
+
+// MarkBlockProcessed records the block as processed.
+// Only a trailing window of roughly processedBlocksWindow blocks below the highest processed block is remembered.
+func (r *InMemoryParserStateRepo) MarkBlockProcessed(ctx context.Context, blockNumber domain.BlockNumber) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	number := blockNumber.Value()
+	if number <= r.highestProcessed-processedBlocksWindow {
+		return nil
+	}
+	r.processedBlocks[number] = struct{}{}
+	if number > r.highestProcessed {
+		r.highestProcessed = number
+	}
+
+	// Pruning only once the map has doubled keeps the cost of each call amortized constant.
+	if int64(len(r.processedBlocks)) > 2*processedBlocksWindow {
+		oldestKept := r.highestProcessed - processedBlocksWindow + 1
+		for n := range r.processedBlocks {
+			if n < oldestKept {
+				delete(r.processedBlocks, n)
+			}
+		}
+	}
+	return nil
+}
+
+// IsBlockProcessed reports whether the block is remembered as processed.
+func (r *InMemoryParserStateRepo) IsBlockProcessed(ctx context.Context, blockNumber domain.BlockNumber) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.processedBlocks[blockNumber.Value()]
+	return ok, nil
+}
+
+// Ping reports only a done context: in-memory storage is always writable.
+func (r *InMemoryParserStateRepo) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
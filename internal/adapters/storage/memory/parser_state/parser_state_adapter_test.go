@@ -41,3 +41,73 @@ func TestInMemoryParserStateRepo_GetSetCurrentBlock(t *testing.T) {
 	require.NoError(t, err, "GetCurrentBlock() after set 2 failed")
 	assert.Equal(t, block2, gotBlock2, "GetCurrentBlock() after set 2 returned wrong block")
 }
+
+func TestInMemoryParserStateRepo_ProcessedBlocks(t *testing.T) {
+	repo := parser_state.NewInMemoryParserStateRepo()
+	ctx := context.Background()
+
+	block10, err := domain.NewBlockNumber(10)
+	require.NoError(t, err)
+	block11, err := domain.NewBlockNumber(11)
+	require.NoError(t, err)
+
+	processed, err := repo.IsBlockProcessed(ctx, block10)
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	require.NoError(t, repo.MarkBlockProcessed(ctx, block10))
+
+	processed, err = repo.IsBlockProcessed(ctx, block10)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	processed, err = repo.IsBlockProcessed(ctx, block11)
+	require.NoError(t, err)
+	assert.False(t, processed)
+}
+
+func TestInMemoryParserStateRepo_ProcessedBlocksWindowIsBounded(t *testing.T) {
+	repo := parser_state.NewInMemoryParserStateRepo()
+	ctx := context.Background()
+
+	for i := int64(0); i < 3000; i++ {
+		bn, err := domain.NewBlockNumber(i)
+		require.NoError(t, err)
+		require.NoError(t, repo.MarkBlockProcessed(ctx, bn))
+	}
+
+	oldest, err := domain.NewBlockNumber(0)
+	require.NoError(t, err)
+	processed, err := repo.IsBlockProcessed(ctx, oldest)
+	require.NoError(t, err)
+	assert.False(t, processed, "blocks outside the trailing window should be forgotten")
+
+	recent, err := domain.NewBlockNumber(2999)
+	require.NoError(t, err)
+	processed, err = repo.IsBlockProcessed(ctx, recent)
+	require.NoError(t, err)
+	assert.True(t, processed)
+}
+
+func TestInMemoryParserStateRepo_CancelledContext(t *testing.T) {
+	repo := parser_state.NewInMemoryParserStateRepo()
+	block, err := domain.NewBlockNumber(100)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, repo.SetCurrentBlock(ctx, block), context.Canceled)
+	_, err = repo.GetCurrentBlock(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, repo.MarkBlockProcessed(ctx, block), context.Canceled)
+	_, err = repo.IsBlockProcessed(ctx, block)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, repo.Ping(ctx), context.Canceled)
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	_, err = repo.GetCurrentBlock(context.Background())
+	assert.ErrorIs(t, err, repository.ErrStateNotInitialized, "a cancelled SetCurrentBlock must not store the block")
+	processed, err := repo.IsBlockProcessed(context.Background(), block)
+	require.NoError(t, err)
+	assert.False(t, processed)
+}
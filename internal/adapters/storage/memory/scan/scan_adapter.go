@@ -0,0 +1,82 @@
+// Package scan provides an in-memory implementation of the ScanRepository interface.
+package scan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
+)
+
+// InMemoryScanRepo combines a transaction repository and a parser state repository into a ScanRepository.
+// It is not transactional: StoreBlockResults writes the transactions first and the progress last, so a failure
+// in between leaves the block to be scanned again, which stores its transactions again harmlessly.
+type InMemoryScanRepo struct {
+	repository.TransactionRepository
+	repository.ParserStateRepository
+}
+
+// Compile-time check to ensure InMemoryScanRepo implements repository.ScanRepository
+var _ repository.ScanRepository = (*InMemoryScanRepo)(nil)
+
+// NewInMemoryScanRepo creates a new InMemoryScanRepo writing to the given repositories.
+func NewInMemoryScanRepo(
+	txRepo repository.TransactionRepository,
+	stateRepo repository.ParserStateRepository,
+) *InMemoryScanRepo {
+	return &InMemoryScanRepo{TransactionRepository: txRepo, ParserStateRepository: stateRepo}
+}
+
+// StoreBlockResults stores the transactions of the block, then advances the last scanned block to newHighWater
+// unless it is already higher. It stops at the first failure, before the progress is advanced.
+func (r *InMemoryScanRepo) StoreBlockResults(
+	ctx context.Context,
+	block domain.BlockNumber,
+	txs []repository.BlockTransaction,
+	newHighWater domain.BlockNumber,
+) error {
+	for _, blockTx := range txs {
+		if err := r.storeBlockTransaction(ctx, blockTx); err != nil {
+			return fmt.Errorf("failed to store transaction %s of block %d: %w",
+				blockTx.Transaction.Hash.String(), block.Value(), err)
+		}
+	}
+
+	current, err := r.GetCurrentBlock(ctx)
+	switch {
+	case errors.Is(err, repository.ErrStateNotInitialized):
+	case err != nil:
+		return fmt.Errorf("failed to get current block: %w", err)
+	case current.Value() >= newHighWater.Value():
+		return nil
+	}
+	if err := r.SetCurrentBlock(ctx, newHighWater); err != nil {
+		return fmt.Errorf("failed to advance current block to %d: %w", newHighWater.Value(), err)
+	}
+	return nil
+}
+
+// Ping checks both underlying repositories.
+func (r *InMemoryScanRepo) Ping(ctx context.Context) error {
+	if err := r.TransactionRepository.Ping(ctx); err != nil {
+		return err
+	}
+	return r.ParserStateRepository.Ping(ctx)
+}
+
+// storeBlockTransaction indexes a transaction under its participants and further addresses.
+func (r *InMemoryScanRepo) storeBlockTransaction(ctx context.Context, blockTx repository.BlockTransaction) error {
+	if blockTx.IndexParticipants {
+		if err := r.Store(ctx, blockTx.Transaction); err != nil {
+			return err
+		}
+	}
+	for _, address := range blockTx.Addresses {
+		if err := r.StoreForAddress(ctx, address, blockTx.Transaction); err != nil {
+			return fmt.Errorf("failed to store transaction for address %s: %w", address.String(), err)
+		}
+	}
+	return nil
+}
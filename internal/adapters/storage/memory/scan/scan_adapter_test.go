@@ -0,0 +1,106 @@
+package scan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/parser_state"
+	"trust_wallet_homework/internal/adapters/storage/memory/scan"
+	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingTransactionRepo fails to store transactions for addresses that are not participants.
+type failingTransactionRepo struct {
+	repository.TransactionRepository
+}
+
+func (failingTransactionRepo) StoreForAddress(context.Context, domain.Address, domain.Transaction) error {
+	return errors.New("disk full")
+}
+
+func mustAddress(t *testing.T, addr string) domain.Address {
+	t.Helper()
+	address, err := domain.NewAddress(addr)
+	require.NoError(t, err)
+	return address
+}
+
+func mustBlock(t *testing.T, number int64) domain.BlockNumber {
+	t.Helper()
+	block, err := domain.NewBlockNumber(number)
+	require.NoError(t, err)
+	return block
+}
+
+// blockTransaction returns a transfer from sender to recipient included in block 10.
+func blockTransaction(t *testing.T, sender, recipient domain.Address) domain.Transaction {
+	t.Helper()
+	hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	value, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	return domain.NewTransaction(hash, sender, recipient, value, mustBlock(t, 10), 1000)
+}
+
+func TestInMemoryScanRepo_StoreBlockResults(t *testing.T) {
+	ctx := context.Background()
+	sender := mustAddress(t, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	recipient := mustAddress(t, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	tokenRecipient := mustAddress(t, "0xcccccccccccccccccccccccccccccccccccccccc")
+	tx := blockTransaction(t, sender, recipient)
+
+	repo := scan.NewInMemoryScanRepo(transaction.NewInMemoryTransactionRepo(), parser_state.NewInMemoryParserStateRepo())
+	txs := []repository.BlockTransaction{{Transaction: tx, IndexParticipants: true, Addresses: []domain.Address{tokenRecipient}}}
+	require.NoError(t, repo.StoreBlockResults(ctx, mustBlock(t, 10), txs, mustBlock(t, 10)))
+
+	for _, address := range []domain.Address{sender, recipient, tokenRecipient} {
+		stored, err := repo.FindByAddress(ctx, address)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Transaction{tx}, stored, "stored for %s", address.String())
+	}
+	current, err := repo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), current.Value())
+
+	require.NoError(t, repo.StoreBlockResults(ctx, mustBlock(t, 11), nil, mustBlock(t, 11)))
+	current, err = repo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), current.Value(), "a block without matches still advances the progress")
+
+	require.NoError(t, repo.StoreBlockResults(ctx, mustBlock(t, 10), txs, mustBlock(t, 10)))
+	current, err = repo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), current.Value(), "rescanning an older block does not move the progress back")
+	count, err := repo.CountAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "storing the same results again does not duplicate them")
+}
+
+func TestInMemoryScanRepo_StoreBlockResults_FailureKeepsProgress(t *testing.T) {
+	ctx := context.Background()
+	sender := mustAddress(t, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	tokenRecipient := mustAddress(t, "0xcccccccccccccccccccccccccccccccccccccccc")
+	stateRepo := parser_state.NewInMemoryParserStateRepo()
+	require.NoError(t, stateRepo.SetCurrentBlock(ctx, mustBlock(t, 9)))
+
+	repo := scan.NewInMemoryScanRepo(
+		failingTransactionRepo{TransactionRepository: transaction.NewInMemoryTransactionRepo()},
+		stateRepo,
+	)
+	txs := []repository.BlockTransaction{{
+		Transaction: blockTransaction(t, sender, mustAddress(t, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")),
+		Addresses:   []domain.Address{tokenRecipient},
+	}}
+	err := repo.StoreBlockResults(ctx, mustBlock(t, 10), txs, mustBlock(t, 10))
+	require.ErrorContains(t, err, "disk full")
+
+	current, err := repo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), current.Value(), "the block is left to be scanned again")
+}
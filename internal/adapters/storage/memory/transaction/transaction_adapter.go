@@ -3,6 +3,7 @@ package transaction
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"trust_wallet_homework/internal/core/domain"
@@ -10,43 +11,90 @@ import (
 )
 
 // InMemoryTransactionRepo implements the TransactionRepository interface using in-memory storage.
+// Every method returns the context error without doing any work if its context is already done.
 type InMemoryTransactionRepo struct {
 	mu           sync.RWMutex
 	transactions map[string][]domain.Transaction
+	seenHashes   map[string]map[domain.TransactionHash]struct{}
+	byHash       map[domain.TransactionHash]domain.Transaction
+	byBlock      map[int64]map[domain.TransactionHash]struct{}
+	refs         map[domain.TransactionHash]int
+	maxPerAddr   int
 }
 
 // Compile-time check to ensure InMemoryTransactionRepo implements repository.TransactionRepository
 var _ repository.TransactionRepository = (*InMemoryTransactionRepo)(nil)
 
+// Option configures optional behavior of the InMemoryTransactionRepo.
+type Option func(*InMemoryTransactionRepo)
+
+// WithMaxPerAddress keeps at most limit transactions per address: the most recent ones in chain order
+// (see domain.CompareTransactions). A new transaction for a full address evicts its oldest one, unless the new
+// transaction is older still, as when a rescan stores an earlier block again; then it is not stored for that
+// address. A limit of zero or less keeps every transaction.
+func WithMaxPerAddress(limit int) Option {
+	return func(r *InMemoryTransactionRepo) {
+		r.maxPerAddr = max(limit, 0)
+	}
+}
+
 // NewInMemoryTransactionRepo creates a new in-memory transaction repository.
-func NewInMemoryTransactionRepo() *InMemoryTransactionRepo {
-	return &InMemoryTransactionRepo{
+func NewInMemoryTransactionRepo(opts ...Option) *InMemoryTransactionRepo {
+	r := &InMemoryTransactionRepo{
 		transactions: make(map[string][]domain.Transaction),
+		seenHashes:   make(map[string]map[domain.TransactionHash]struct{}),
+		byHash:       make(map[domain.TransactionHash]domain.Transaction),
+		byBlock:      make(map[int64]map[domain.TransactionHash]struct{}),
+		refs:         make(map[domain.TransactionHash]int),
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Store saves a transaction to the persistent storage.
-func (r *InMemoryTransactionRepo) Store(_ context.Context, tx domain.Transaction) error {
+// Storing a transaction that is already present for an address is a no-op for that address.
+func (r *InMemoryTransactionRepo) Store(ctx context.Context, tx domain.Transaction) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	fromAddr := tx.From.String()
-	r.transactions[fromAddr] = append(r.transactions[fromAddr], tx)
+	r.appendUnique(fromAddr, tx)
 
-	toAddr := tx.To.String()
-	if toAddr != "" && !tx.To.IsZero() {
-		if fromAddr != toAddr {
-			r.transactions[toAddr] = append(r.transactions[toAddr], tx)
+	if !tx.IsContractCreation() {
+		if toAddr := tx.To.String(); fromAddr != toAddr {
+			r.appendUnique(toAddr, tx)
 		}
 	}
 	return nil
 }
 
-// FindByAddress retrieves all stored transactions (both inbound and outbound)
+// StoreForAddress saves a transaction under an additional address.
+// Storing a transaction that is already present for the address is a no-op.
+func (r *InMemoryTransactionRepo) StoreForAddress(ctx context.Context, address domain.Address, tx domain.Transaction) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.appendUnique(address.String(), tx)
+	return nil
+}
+
+// FindByAddress retrieves all stored transactions (both inbound and outbound) in chain order.
+// With WithMaxPerAddress only the most recent transactions in chain order are retained.
 func (r *InMemoryTransactionRepo) FindByAddress(
-	_ context.Context,
+	ctx context.Context,
 	address domain.Address,
 ) ([]domain.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -58,6 +106,290 @@ func (r *InMemoryTransactionRepo) FindByAddress(
 
 	txCopy := make([]domain.Transaction, len(txs))
 	copy(txCopy, txs)
+	slices.SortFunc(txCopy, domain.CompareTransactions)
 
 	return txCopy, nil
 }
+
+// FindByAddressInBlockRange retrieves stored transactions for an address included in blocks from..to (inclusive),
+// in chain order.
+func (r *InMemoryTransactionRepo) FindByAddressInBlockRange(
+	ctx context.Context,
+	address domain.Address,
+	from, to domain.BlockNumber,
+) ([]domain.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.Transaction, 0)
+	for _, tx := range r.transactions[address.String()] {
+		if tx.BlockNumber.Value() >= from.Value() && tx.BlockNumber.Value() <= to.Value() {
+			result = append(result, tx)
+		}
+	}
+	slices.SortFunc(result, domain.CompareTransactions)
+	return result, nil
+}
+
+// FindByAddressFiltered retrieves stored transactions for an address that match the filter, in chain order.
+func (r *InMemoryTransactionRepo) FindByAddressFiltered(
+	ctx context.Context,
+	address domain.Address,
+	filter repository.TransactionFilter,
+) ([]domain.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.Transaction, 0)
+	for _, tx := range r.transactions[address.String()] {
+		block := tx.BlockNumber.Value()
+		if block < filter.FromBlock.Value() || block > filter.ToBlock.Value() {
+			continue
+		}
+		if tx.Value.Cmp(filter.MinValue) < 0 {
+			continue
+		}
+		result = append(result, tx)
+	}
+	slices.SortFunc(result, domain.CompareTransactions)
+	return result, nil
+}
+
+// FindByHash retrieves a stored transaction by its hash.
+func (r *InMemoryTransactionRepo) FindByHash(ctx context.Context, hash domain.TransactionHash) (domain.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Transaction{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tx, ok := r.byHash[hash]
+	if !ok {
+		return domain.Transaction{}, repository.ErrTransactionNotFound
+	}
+	return tx, nil
+}
+
+// FindByBlock retrieves every distinct stored transaction included in the block, ordered by
+// transaction index and then by hash.
+// A transaction stored for several addresses is returned once.
+func (r *InMemoryTransactionRepo) FindByBlock(ctx context.Context, block domain.BlockNumber) ([]domain.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hashes := r.byBlock[block.Value()]
+	result := make([]domain.Transaction, 0, len(hashes))
+	for hash := range hashes {
+		result = append(result, r.byHash[hash])
+	}
+	slices.SortFunc(result, domain.CompareTransactions)
+	return result, nil
+}
+
+// FindLatestByAddress retrieves the stored transaction of an address included in the highest block,
+// the one with the highest transaction index if there are several.
+func (r *InMemoryTransactionRepo) FindLatestByAddress(
+	ctx context.Context,
+	address domain.Address,
+) (domain.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Transaction{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	txs := r.transactions[address.String()]
+	if len(txs) == 0 {
+		return domain.Transaction{}, repository.ErrTransactionNotFound
+	}
+	return slices.MaxFunc(txs, domain.CompareTransactions), nil
+}
+
+// FindAddressesWithTransactions returns every address with at least one stored transaction,
+// ordered by their string form.
+func (r *InMemoryTransactionRepo) FindAddressesWithTransactions(ctx context.Context) ([]domain.Address, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	keys := make([]string, 0, len(r.transactions))
+	for addr, txs := range r.transactions {
+		if len(txs) > 0 {
+			keys = append(keys, addr)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.Sort(keys)
+	addresses := make([]domain.Address, 0, len(keys))
+	for _, key := range keys {
+		addr, err := domain.NewAddress(key)
+		if err != nil {
+			continue
+		}
+		addresses = append(addresses, addr)
+	}
+	return addresses, nil
+}
+
+// CountByAddress returns the number of stored transactions (both inbound and outbound) for an address.
+func (r *InMemoryTransactionRepo) CountByAddress(ctx context.Context, address domain.Address) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.transactions[address.String()]), nil
+}
+
+// CountAll returns the number of distinct stored transactions.
+func (r *InMemoryTransactionRepo) CountAll(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.byHash), nil
+}
+
+// Each calls fn for every distinct stored transaction in chain order (see domain.CompareTransactions).
+// fn runs on a snapshot taken when Each is called, without holding the lock,
+// so a slow consumer does not block writers.
+func (r *InMemoryTransactionRepo) Each(ctx context.Context, fn func(domain.Transaction) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.RLock()
+	snapshot := make([]domain.Transaction, 0, len(r.byHash))
+	for _, tx := range r.byHash {
+		snapshot = append(snapshot, tx)
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(snapshot, domain.CompareTransactions)
+
+	for _, tx := range snapshot {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prune removes every stored transaction included in a block below beforeBlock
+// and returns the number of distinct transactions removed.
+func (r *InMemoryTransactionRepo) Prune(ctx context.Context, beforeBlock domain.BlockNumber) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for addr, txs := range r.transactions {
+		kept := txs[:0]
+		for _, tx := range txs {
+			if tx.BlockNumber.Value() >= beforeBlock.Value() {
+				kept = append(kept, tx)
+				continue
+			}
+			delete(r.seenHashes[addr], tx.Hash)
+		}
+		if len(kept) == 0 {
+			delete(r.transactions, addr)
+			delete(r.seenHashes, addr)
+			continue
+		}
+		clear(txs[len(kept):])
+		r.transactions[addr] = kept
+	}
+
+	removed := 0
+	for _, tx := range r.byHash {
+		if tx.BlockNumber.Value() < beforeBlock.Value() {
+			r.forget(tx)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// Ping reports only a done context: in-memory storage is always writable.
+func (r *InMemoryTransactionRepo) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// appendUnique appends the transaction to the address bucket unless its hash is already stored there.
+// A full bucket drops its oldest transaction in chain order to make room, or refuses tx if that is older.
+// The caller must hold the write lock.
+func (r *InMemoryTransactionRepo) appendUnique(addr string, tx domain.Transaction) {
+	if _, seen := r.seenHashes[addr][tx.Hash]; seen {
+		return
+	}
+
+	txs := r.transactions[addr]
+	if r.maxPerAddr > 0 && len(txs) >= r.maxPerAddr {
+		oldest := 0
+		for i := range txs {
+			if domain.CompareTransactions(txs[i], txs[oldest]) < 0 {
+				oldest = i
+			}
+		}
+		if domain.CompareTransactions(tx, txs[oldest]) < 0 {
+			return
+		}
+		r.evict(addr, txs[oldest].Hash)
+		txs = slices.Delete(txs, oldest, oldest+1)
+	}
+
+	hashes, ok := r.seenHashes[addr]
+	if !ok {
+		hashes = make(map[domain.TransactionHash]struct{})
+		r.seenHashes[addr] = hashes
+	}
+	hashes[tx.Hash] = struct{}{}
+	if _, stored := r.byHash[tx.Hash]; !stored {
+		r.byHash[tx.Hash] = tx
+		block := tx.BlockNumber.Value()
+		if r.byBlock[block] == nil {
+			r.byBlock[block] = make(map[domain.TransactionHash]struct{})
+		}
+		r.byBlock[block][tx.Hash] = struct{}{}
+	}
+	r.refs[tx.Hash]++
+	r.transactions[addr] = append(txs, tx)
+}
+
+// evict forgets the given transaction for the address, dropping it from the
+// hash index once no address holds it anymore. The caller must hold the write lock.
+func (r *InMemoryTransactionRepo) evict(addr string, hash domain.TransactionHash) {
+	delete(r.seenHashes[addr], hash)
+	r.refs[hash]--
+	if r.refs[hash] <= 0 {
+		r.forget(r.byHash[hash])
+	}
+}
+
+// forget drops the transaction from the hash and block indexes. The caller must hold the write lock.
+func (r *InMemoryTransactionRepo) forget(tx domain.Transaction) {
+	delete(r.refs, tx.Hash)
+	delete(r.byHash, tx.Hash)
+	block := tx.BlockNumber.Value()
+	delete(r.byBlock[block], tx.Hash)
+	if len(r.byBlock[block]) == 0 {
+		delete(r.byBlock, block)
+	}
+}
@@ -2,10 +2,12 @@ package transaction_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
 
 	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -103,3 +105,680 @@ func TestInMemoryTransactionRepo_Store_FindByAddress(t *testing.T) {
 	require.NoError(t, err)
 	assert.ElementsMatch(t, []domain.Transaction{tx2, tx3}, txsAddr3AfterTx3)
 }
+
+func TestInMemoryTransactionRepo_FindByAddress_ChainOrder(t *testing.T) {
+	ctx := context.Background()
+	addr, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	counterparty, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+
+	newTx := func(hashHex string, block int64, index uint64) domain.Transaction {
+		hash, errHash := domain.NewTransactionHash(hashHex)
+		require.NoError(t, errHash)
+		blockNum, errBlock := domain.NewBlockNumber(block)
+		require.NoError(t, errBlock)
+		tx := domain.NewTransaction(hash, addr, counterparty, val, blockNum, 1000)
+		tx.TransactionIndex = index
+		return tx
+	}
+	// Chain order: by block, then index, then hash for transactions sharing both.
+	want := []domain.Transaction{
+		newTx("0x5555555555555555555555555555555555555555555555555555555555555555", 1, 0),
+		newTx("0x2222222222222222222222222222222222222222222222222222222222222222", 1, 3),
+		newTx("0x1111111111111111111111111111111111111111111111111111111111111111", 2, 1),
+		newTx("0x3333333333333333333333333333333333333333333333333333333333333333", 2, 1),
+		newTx("0x4444444444444444444444444444444444444444444444444444444444444444", 7, 0),
+	}
+
+	for _, storeOrder := range [][]int{{0, 1, 2, 3, 4}, {4, 3, 2, 1, 0}, {2, 4, 0, 3, 1}} {
+		repo := transaction.NewInMemoryTransactionRepo()
+		for _, i := range storeOrder {
+			require.NoError(t, repo.Store(ctx, want[i]))
+		}
+
+		txs, err := repo.FindByAddress(ctx, addr)
+		require.NoError(t, err)
+		assert.Equal(t, want, txs, "stored in order %v", storeOrder)
+
+		inRange, err := repo.FindByAddressInBlockRange(ctx, counterparty, want[0].BlockNumber, want[3].BlockNumber)
+		require.NoError(t, err)
+		assert.Equal(t, want[:4], inRange, "stored in order %v", storeOrder)
+	}
+}
+
+func TestInMemoryTransactionRepo_Store_Idempotent(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	addr1, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	addr2, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	txHash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	selfHash, err := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+
+	tx := domain.NewTransaction(txHash, addr1, addr2, val, block, 1000)
+	selfTx := domain.NewTransaction(selfHash, addr1, addr1, val, block, 1000)
+
+	require.NoError(t, repo.Store(ctx, tx))
+	require.NoError(t, repo.Store(ctx, tx))
+	require.NoError(t, repo.Store(ctx, selfTx))
+	require.NoError(t, repo.Store(ctx, selfTx))
+
+	txsAddr1, err := repo.FindByAddress(ctx, addr1)
+	require.NoError(t, err)
+	assert.Len(t, txsAddr1, 2)
+	assert.ElementsMatch(t, []domain.Transaction{tx, selfTx}, txsAddr1)
+
+	txsAddr2, err := repo.FindByAddress(ctx, addr2)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{tx}, txsAddr2)
+}
+
+func TestInMemoryTransactionRepo_CountByAddress(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	addr1, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	addr2, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	tx1Hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	tx2Hash, err := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+
+	count, err := repo.CountByAddress(ctx, addr1)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	require.NoError(t, repo.Store(ctx, domain.NewTransaction(tx1Hash, addr1, addr2, val, block, 1000)))
+	require.NoError(t, repo.Store(ctx, domain.NewTransaction(tx2Hash, addr1, addr1, val, block, 1000)))
+
+	count, err = repo.CountByAddress(ctx, addr1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = repo.CountByAddress(ctx, addr2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	total, err := repo.CountAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total, "a transaction stored under two addresses is counted once")
+}
+
+func TestInMemoryTransactionRepo_FindByAddressInBlockRange(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	addr1, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	addr2, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+
+	stored := make([]domain.Transaction, 0, 3)
+	for i, hashHex := range []string{
+		"0x1111111111111111111111111111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333333333333333333333333333",
+	} {
+		hash, errHash := domain.NewTransactionHash(hashHex)
+		require.NoError(t, errHash)
+		block, errBlock := domain.NewBlockNumber(int64(10 * (i + 1)))
+		require.NoError(t, errBlock)
+		tx := domain.NewTransaction(hash, addr1, addr2, val, block, 1000)
+		require.NoError(t, repo.Store(ctx, tx))
+		stored = append(stored, tx)
+	}
+
+	blockNum := func(n int64) domain.BlockNumber {
+		bn, errBn := domain.NewBlockNumber(n)
+		require.NoError(t, errBn)
+		return bn
+	}
+
+	tests := []struct {
+		name     string
+		from, to int64
+		want     []domain.Transaction
+	}{
+		{name: "Inclusive bounds", from: 10, to: 20, want: stored[:2]},
+		{name: "Single block", from: 30, to: 30, want: stored[2:]},
+		{name: "Whole history", from: 0, to: 100, want: stored},
+		{name: "Empty range", from: 11, to: 19, want: []domain.Transaction{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errFind := repo.FindByAddressInBlockRange(ctx, addr2, blockNum(tt.from), blockNum(tt.to))
+			require.NoError(t, errFind)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInMemoryTransactionRepo_FindByHash(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	related, err := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+	txHash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	relatedHash, err := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	unknownHash, err := domain.NewTransactionHash("0x3333333333333333333333333333333333333333333333333333333333333333")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+
+	tx := domain.NewTransaction(txHash, from, to, val, block, 1000)
+	relatedTx := domain.NewTransaction(relatedHash, from, to, val, block, 1000)
+	require.NoError(t, repo.Store(ctx, tx))
+	require.NoError(t, repo.StoreForAddress(ctx, related, relatedTx))
+
+	got, err := repo.FindByHash(ctx, txHash)
+	require.NoError(t, err)
+	assert.Equal(t, tx, got)
+
+	got, err = repo.FindByHash(ctx, relatedHash)
+	require.NoError(t, err, "transactions stored for an additional address are indexed by hash too")
+	assert.Equal(t, relatedTx, got)
+
+	_, err = repo.FindByHash(ctx, unknownHash)
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+
+	count, err := repo.CountAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestInMemoryTransactionRepo_FindByBlock(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	tokenRecipient, err := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(10)
+	require.NoError(t, err)
+	otherBlock, err := domain.NewBlockNumber(11)
+	require.NoError(t, err)
+	firstHash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	secondHash, err := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	otherHash, err := domain.NewTransactionHash("0x3333333333333333333333333333333333333333333333333333333333333333")
+	require.NoError(t, err)
+
+	first := domain.NewTransaction(firstHash, from, to, val, block, 1000)
+	first.TransactionIndex = 1
+	second := domain.NewTransaction(secondHash, to, from, val, block, 1000)
+	other := domain.NewTransaction(otherHash, from, to, val, otherBlock, 1012)
+	require.NoError(t, repo.Store(ctx, second))
+	require.NoError(t, repo.Store(ctx, first))
+	require.NoError(t, repo.StoreForAddress(ctx, tokenRecipient, first))
+	require.NoError(t, repo.Store(ctx, other))
+
+	txs, err := repo.FindByBlock(ctx, block)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{second, first}, txs, "each transaction once, ordered by index")
+
+	empty, err := domain.NewBlockNumber(12)
+	require.NoError(t, err)
+	txs, err = repo.FindByBlock(ctx, empty)
+	require.NoError(t, err)
+	assert.NotNil(t, txs)
+	assert.Empty(t, txs)
+
+	_, err = repo.Prune(ctx, otherBlock)
+	require.NoError(t, err)
+	txs, err = repo.FindByBlock(ctx, block)
+	require.NoError(t, err)
+	assert.Empty(t, txs, "pruned transactions are removed from the block index")
+	txs, err = repo.FindByBlock(ctx, otherBlock)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{other}, txs)
+}
+
+func TestInMemoryTransactionRepo_FindLatestByAddress(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	idle, err := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(10)
+	require.NoError(t, err)
+	laterBlock, err := domain.NewBlockNumber(11)
+	require.NoError(t, err)
+	firstHash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	secondHash, err := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	thirdHash, err := domain.NewTransactionHash("0x3333333333333333333333333333333333333333333333333333333333333333")
+	require.NoError(t, err)
+
+	latest := domain.NewTransaction(firstHash, from, to, val, laterBlock, 1012)
+	latest.TransactionIndex = 2
+	sameBlock := domain.NewTransaction(secondHash, from, to, val, laterBlock, 1012)
+	sameBlock.TransactionIndex = 1
+	older := domain.NewTransaction(thirdHash, to, from, val, block, 1000)
+	require.NoError(t, repo.Store(ctx, latest))
+	require.NoError(t, repo.Store(ctx, sameBlock))
+	require.NoError(t, repo.Store(ctx, older), "stored last but included in an earlier block")
+
+	tx, err := repo.FindLatestByAddress(ctx, from)
+	require.NoError(t, err)
+	assert.Equal(t, latest, tx)
+
+	_, err = repo.FindLatestByAddress(ctx, idle)
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+}
+
+func TestInMemoryTransactionRepo_FindAddressesWithTransactions(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	addresses, err := repo.FindAddressesWithTransactions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, addresses)
+
+	sender, err := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+	recipient, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	tokenRecipient, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	creator, err := domain.NewAddress("0xdddddddddddddddddddddddddddddddddddddddd")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	oldBlock, err := domain.NewBlockNumber(5)
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(10)
+	require.NoError(t, err)
+	firstHash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	secondHash, err := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+
+	transfer := domain.NewTransaction(firstHash, sender, recipient, val, block, 1000)
+	creation := domain.NewTransaction(secondHash, creator, domain.Address{}, val, oldBlock, 900)
+	require.NoError(t, repo.Store(ctx, transfer))
+	require.NoError(t, repo.StoreForAddress(ctx, tokenRecipient, transfer))
+	require.NoError(t, repo.Store(ctx, creation))
+
+	addresses, err = repo.FindAddressesWithTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Address{recipient, tokenRecipient, sender, creator}, addresses,
+		"sorted, without an entry for the missing recipient of the contract creation")
+
+	_, err = repo.Prune(ctx, block)
+	require.NoError(t, err)
+	addresses, err = repo.FindAddressesWithTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Address{recipient, tokenRecipient, sender}, addresses,
+		"addresses whose transactions were all pruned are not listed")
+}
+
+func TestInMemoryTransactionRepo_Prune(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	oldHash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	recentHash, err := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	oldBlock, err := domain.NewBlockNumber(5)
+	require.NoError(t, err)
+	recentBlock, err := domain.NewBlockNumber(10)
+	require.NoError(t, err)
+
+	oldTx := domain.NewTransaction(oldHash, from, to, val, oldBlock, 1000)
+	recentTx := domain.NewTransaction(recentHash, from, to, val, recentBlock, 2000)
+	oldOnlyAddr, err := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+	require.NoError(t, repo.Store(ctx, oldTx))
+	require.NoError(t, repo.Store(ctx, recentTx))
+	require.NoError(t, repo.StoreForAddress(ctx, oldOnlyAddr, oldTx))
+
+	removed, err := repo.Prune(ctx, recentBlock)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	for _, addr := range []domain.Address{from, to} {
+		txs, err := repo.FindByAddress(ctx, addr)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Transaction{recentTx}, txs)
+	}
+	txs, err := repo.FindByAddress(ctx, oldOnlyAddr)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+	_, err = repo.FindByHash(ctx, oldHash)
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+	count, err := repo.CountAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, repo.Store(ctx, oldTx))
+	txs, err = repo.FindByAddress(ctx, from)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []domain.Transaction{oldTx, recentTx}, txs, "a pruned transaction can be stored again")
+}
+
+func TestInMemoryTransactionRepo_FindByAddressFiltered(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	smallHash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	largeHash, err := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	small, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	large, err := domain.NewWeiValue("1000")
+	require.NoError(t, err)
+	block1, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+	block2, err := domain.NewBlockNumber(2)
+	require.NoError(t, err)
+
+	smallTx := domain.NewTransaction(smallHash, from, to, small, block1, 1000)
+	largeTx := domain.NewTransaction(largeHash, from, to, large, block2, 2000)
+	require.NoError(t, repo.Store(ctx, smallTx))
+	require.NoError(t, repo.Store(ctx, largeTx))
+
+	all, err := repo.FindByAddressFiltered(ctx, from, repository.TransactionFilter{FromBlock: block1, ToBlock: block2})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{smallTx, largeTx}, all, "the zero MinValue keeps every transaction")
+
+	threshold, err := domain.NewWeiValue("999")
+	require.NoError(t, err)
+	aboveThreshold, err := repo.FindByAddressFiltered(ctx, to,
+		repository.TransactionFilter{FromBlock: block1, ToBlock: block2, MinValue: threshold})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{largeTx}, aboveThreshold)
+
+	inFirstBlock, err := repo.FindByAddressFiltered(ctx, from, repository.TransactionFilter{FromBlock: block1, ToBlock: block1})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{smallTx}, inFirstBlock)
+}
+
+func TestInMemoryTransactionRepo_WithMaxPerAddress_EvictsOldest(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo(transaction.WithMaxPerAddress(2))
+	ctx := context.Background()
+
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	other, err := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+
+	stored := make([]domain.Transaction, 0, 4)
+	for i, hashHex := range []string{
+		"0x1111111111111111111111111111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333333333333333333333333333",
+		"0x4444444444444444444444444444444444444444444444444444444444444444",
+	} {
+		hash, errHash := domain.NewTransactionHash(hashHex)
+		require.NoError(t, errHash)
+		block, errBlock := domain.NewBlockNumber(int64(i + 1))
+		require.NoError(t, errBlock)
+		tx := domain.NewTransaction(hash, from, to, val, block, 1000)
+		require.NoError(t, repo.Store(ctx, tx))
+		stored = append(stored, tx)
+
+		txs, errFind := repo.FindByAddress(ctx, from)
+		require.NoError(t, errFind)
+		assert.Equal(t, stored[max(0, len(stored)-2):], txs, "after storing %d transactions", len(stored))
+	}
+
+	count, err := repo.CountByAddress(ctx, to)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	_, err = repo.FindByHash(ctx, stored[0].Hash)
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound, "a transaction evicted everywhere is forgotten")
+	inBlock, err := repo.FindByBlock(ctx, stored[0].BlockNumber)
+	require.NoError(t, err)
+	assert.Empty(t, inBlock, "a transaction evicted everywhere is removed from the block index")
+	total, err := repo.CountAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+
+	require.NoError(t, repo.StoreForAddress(ctx, other, stored[2]))
+	hash5, err := domain.NewTransactionHash("0x5555555555555555555555555555555555555555555555555555555555555555")
+	require.NoError(t, err)
+	block5, err := domain.NewBlockNumber(5)
+	require.NoError(t, err)
+	newest := domain.NewTransaction(hash5, from, to, val, block5, 1000)
+	require.NoError(t, repo.Store(ctx, newest))
+	_, err = repo.FindByHash(ctx, stored[2].Hash)
+	require.NoError(t, err, "a transaction still held by another address stays indexed by hash")
+
+	txs, err := repo.FindByAddress(ctx, from)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{stored[3], newest}, txs)
+}
+
+func TestInMemoryTransactionRepo_WithMaxPerAddress_KeepsMostRecentOnChain(t *testing.T) {
+	ctx := context.Background()
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+
+	txs := make([]domain.Transaction, 0, 3)
+	for i, hashHex := range []string{
+		"0x1111111111111111111111111111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333333333333333333333333333",
+	} {
+		hash, errHash := domain.NewTransactionHash(hashHex)
+		require.NoError(t, errHash)
+		block, errBlock := domain.NewBlockNumber(int64(i + 1))
+		require.NoError(t, errBlock)
+		txs = append(txs, domain.NewTransaction(hash, from, to, val, block, 1000))
+	}
+
+	tests := []struct {
+		name       string
+		storeOrder []int
+	}{
+		{name: "In chain order", storeOrder: []int{0, 1, 2}},
+		{name: "Newest first", storeOrder: []int{2, 1, 0}},
+		{name: "Tail rescan stores older blocks again", storeOrder: []int{0, 1, 2, 0, 1}},
+		{name: "Gap backfill", storeOrder: []int{0, 2, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := transaction.NewInMemoryTransactionRepo(transaction.WithMaxPerAddress(2))
+			for _, i := range tt.storeOrder {
+				require.NoError(t, repo.Store(ctx, txs[i]))
+			}
+
+			got, err := repo.FindByAddress(ctx, from)
+			require.NoError(t, err)
+			assert.Equal(t, txs[1:], got)
+			_, err = repo.FindByHash(ctx, txs[0].Hash)
+			assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+			count, err := repo.CountAll(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+		})
+	}
+}
+
+func TestInMemoryTransactionRepo_WithMaxPerAddress_DuplicateDoesNotEvict(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo(transaction.WithMaxPerAddress(1))
+	ctx := context.Background()
+
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+	tx := domain.NewTransaction(hash, from, to, val, block, 1000)
+
+	require.NoError(t, repo.Store(ctx, tx))
+	require.NoError(t, repo.Store(ctx, tx))
+
+	txs, err := repo.FindByAddress(ctx, from)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{tx}, txs)
+	got, err := repo.FindByHash(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, tx, got)
+}
+
+func TestInMemoryTransactionRepo_Each(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	ctx := context.Background()
+
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+
+	newTx := func(hashHex string, block int64) domain.Transaction {
+		hash, errHash := domain.NewTransactionHash(hashHex)
+		require.NoError(t, errHash)
+		blockNum, errBlock := domain.NewBlockNumber(block)
+		require.NoError(t, errBlock)
+		return domain.NewTransaction(hash, from, to, val, blockNum, 1000)
+	}
+	late := newTx("0x1111111111111111111111111111111111111111111111111111111111111111", 20)
+	earlyA := newTx("0x3333333333333333333333333333333333333333333333333333333333333333", 10)
+	earlyB := newTx("0x2222222222222222222222222222222222222222222222222222222222222222", 10)
+	earlyB.TransactionIndex = 1
+	for _, tx := range []domain.Transaction{late, earlyB, earlyA} {
+		require.NoError(t, repo.Store(ctx, tx))
+	}
+
+	var visited []domain.Transaction
+	require.NoError(t, repo.Each(ctx, func(tx domain.Transaction) error {
+		visited = append(visited, tx)
+		return nil
+	}))
+	assert.Equal(t, []domain.Transaction{earlyA, earlyB, late}, visited, "each transaction is visited once, in chain order")
+
+	stopErr := errors.New("stop")
+	calls := 0
+	err = repo.Each(ctx, func(domain.Transaction) error {
+		calls++
+		return stopErr
+	})
+	assert.ErrorIs(t, err, stopErr)
+	assert.Equal(t, 1, calls)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = repo.Each(cancelled, func(domain.Transaction) error {
+		t.Fatal("fn must not be called after the context is done")
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInMemoryTransactionRepo_CancelledContext(t *testing.T) {
+	repo := transaction.NewInMemoryTransactionRepo()
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	to, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	val, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(1)
+	require.NoError(t, err)
+	tx := domain.NewTransaction(hash, from, to, val, block, 1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, repo.Store(ctx, tx), context.Canceled)
+	assert.ErrorIs(t, repo.StoreForAddress(ctx, to, tx), context.Canceled)
+	_, err = repo.FindByAddress(ctx, from)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.FindByAddressInBlockRange(ctx, from, block, block)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.FindByAddressFiltered(ctx, from, repository.TransactionFilter{FromBlock: block, ToBlock: block})
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.FindByHash(ctx, hash)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.FindByBlock(ctx, block)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.FindLatestByAddress(ctx, from)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.CountByAddress(ctx, from)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.CountAll(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, repo.Each(ctx, func(domain.Transaction) error { return nil }), context.Canceled)
+	_, err = repo.Prune(ctx, block)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, repo.Ping(ctx), context.Canceled)
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	count, err := repo.CountAll(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count, "a cancelled Store must not store the transaction")
+}
@@ -0,0 +1,72 @@
+// Package storage constructs the repositories of the configured storage backend.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/address"
+	"trust_wallet_homework/internal/adapters/storage/memory/parser_state"
+	"trust_wallet_homework/internal/adapters/storage/memory/scan"
+	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain/repository"
+)
+
+var (
+	// ErrUnknownBackend indicates that the configured storage backend is not one of the supported backends.
+	ErrUnknownBackend = errors.New("unknown storage backend")
+
+	// ErrBackendNotAvailable indicates that the configured storage backend is supported by the configuration
+	// but has no implementation in this build yet.
+	ErrBackendNotAvailable = errors.New("storage backend is not available")
+)
+
+// Repositories groups the repositories the parser service is built on, all backed by the same storage.
+type Repositories struct {
+	State        repository.ParserStateRepository
+	Addresses    repository.MonitoredAddressRepository
+	Transactions repository.TransactionRepository
+	// Scan stores the results of a scanned block together with the scan progress, writing to State and
+	// Transactions.
+	Scan repository.ScanRepository
+}
+
+// NewRepositories returns the repositories of the storage backend selected in cfg.Storage.
+func NewRepositories(cfg *config.Config) (*Repositories, error) {
+	switch cfg.Storage.Backend {
+	case config.StorageBackendMemory:
+		return newMemoryRepositories(cfg), nil
+	case config.StorageBackendSQLite, config.StorageBackendPostgres:
+		return nil, fmt.Errorf("%w: %s", ErrBackendNotAvailable, cfg.Storage.Backend)
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownBackend, cfg.Storage.Backend)
+	}
+}
+
+// newMemoryRepositories returns in-memory repositories; their data is lost on restart.
+func newMemoryRepositories(cfg *config.Config) *Repositories {
+	state := parser_state.NewInMemoryParserStateRepo()
+	transactions := transaction.NewInMemoryTransactionRepo(
+		transaction.WithMaxPerAddress(cfg.AppService.MaxTransactionsPerAddr),
+	)
+	return &Repositories{
+		State:        state,
+		Addresses:    address.NewInMemoryAddressRepo(),
+		Transactions: transactions,
+		Scan:         scan.NewInMemoryScanRepo(transactions, state),
+	}
+}
+
+// Closers returns the repositories that hold resources to release on shutdown.
+func (r *Repositories) Closers() []io.Closer {
+	repos := []any{r.State, r.Addresses, r.Transactions}
+	closers := make([]io.Closer, 0, len(repos))
+	for _, repo := range repos {
+		if c, ok := repo.(io.Closer); ok {
+			closers = append(closers, c)
+		}
+	}
+	return closers
+}
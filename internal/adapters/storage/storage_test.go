@@ -0,0 +1,45 @@
+package storage_test
+
+import (
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/storage"
+	"trust_wallet_homework/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepositories_Memory(t *testing.T) {
+	repos, err := storage.NewRepositories(&config.Config{
+		Storage: config.StorageConfig{Backend: config.StorageBackendMemory},
+	})
+	require.NoError(t, err)
+
+	assert.NotNil(t, repos.State)
+	assert.NotNil(t, repos.Addresses)
+	assert.NotNil(t, repos.Transactions)
+	assert.NotNil(t, repos.Scan)
+	assert.Empty(t, repos.Closers(), "in-memory repositories hold nothing to close")
+}
+
+func TestNewRepositories_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		wantErr error
+	}{
+		{name: "Unknown backend", backend: "redis", wantErr: storage.ErrUnknownBackend},
+		{name: "Empty backend", backend: "", wantErr: storage.ErrUnknownBackend},
+		{name: "SQLite not available yet", backend: config.StorageBackendSQLite, wantErr: storage.ErrBackendNotAvailable},
+		{name: "Postgres not available yet", backend: config.StorageBackendPostgres, wantErr: storage.ErrBackendNotAvailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := storage.NewRepositories(&config.Config{Storage: config.StorageConfig{Backend: tt.backend}})
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, repos)
+		})
+	}
+}
@@ -13,29 +13,55 @@ import (
 func LoadConfig(filePath string) (*Config, error) {
 	cfg := Config{
 		Server: ServerConfig{
-			Port:                     DefaultServerPort,
-			ReadTimeoutSeconds:       DefaultServerReadTimeoutSeconds,
-			WriteTimeoutSeconds:      DefaultServerWriteTimeoutSeconds,
-			IdleTimeoutSeconds:       DefaultServerIdleTimeoutSeconds,
-			ReadHeaderTimeoutSeconds: DefaultServerReadHeaderTimeoutSeconds,
+			Port:                      DefaultServerPort,
+			ReadTimeoutSeconds:        DefaultServerReadTimeoutSeconds,
+			WriteTimeoutSeconds:       DefaultServerWriteTimeoutSeconds,
+			IdleTimeoutSeconds:        DefaultServerIdleTimeoutSeconds,
+			ReadHeaderTimeoutSeconds:  DefaultServerReadHeaderTimeoutSeconds,
+			ShutdownTimeoutSeconds:    DefaultServerShutdownTimeoutSeconds,
+			ContentTypeCharset:        DefaultServerContentTypeCharset,
+			GzipMinBytes:              DefaultServerGzipMinBytes,
+			MaxBodyBytes:              DefaultServerMaxBodyBytes,
+			StreamWriteTimeoutSeconds: DefaultServerStreamWriteTimeoutSeconds,
+			StreamHeartbeatSeconds:    DefaultServerStreamHeartbeatSeconds,
+			StreamBufferSize:          DefaultServerStreamBufferSize,
+			CORSAllowedMethods:        []string{"GET", "POST"},
+			CORSAllowedHeaders:        []string{"Content-Type"},
 		},
 		Logger: LoggerConfig{
 			Level:  DefaultLoggerLevel,
 			Format: DefaultLoggerFormat,
 		},
 		ETHClient: ETHClientConfig{
-			NodeURL:              DefaultEthNodeURL,
-			ClientTimeoutSeconds: DefaultEthClientTimeoutSeconds,
+			NodeURL:               DefaultEthNodeURL,
+			ClientTimeoutSeconds:  DefaultEthClientTimeoutSeconds,
+			RPCCallTimeoutSeconds: DefaultEthRPCCallTimeoutSeconds,
+			DebugLogMaxBytes:      DefaultEthDebugLogMaxBytes,
+			ParseMode:             DefaultEthParseMode,
 		},
 		AppService: ApplicationServiceConfig{
-			PollingIntervalSeconds: DefaultAppServicePollingIntervalSeconds,
+			PollingIntervalSeconds:  DefaultAppServicePollingIntervalSeconds,
+			MaxBlocksPerScan:        DefaultAppServiceMaxBlocksPerScan,
+			HeadBlockTag:            DefaultAppServiceHeadBlockTag,
+			ShutdownTimeoutSeconds:  DefaultAppServiceShutdownTimeoutSeconds,
+			MatchStrategy:           DefaultAppServiceMatchStrategy,
+			StoreRetryAttempts:      DefaultAppServiceStoreRetryAttempts,
+			StoreRetryBackoffMillis: DefaultAppServiceStoreRetryBackoffMs,
+			MaxDeadLetters:          DefaultAppServiceMaxDeadLetters,
+			PruneIntervalSeconds:    DefaultAppServicePruneIntervalSeconds,
+			Mode:                    DefaultAppServiceMode,
+			TimestampCheck:          DefaultAppServiceTimestampCheck,
+			MaxTimestampDriftSecs:   DefaultAppServiceMaxTimestampDriftSecs,
+		},
+		Storage: StorageConfig{
+			Backend: DefaultStorageBackend,
 		},
 	}
 
 	fileBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			fmt.Printf("Info: Config file '%s' not found, using default values for all settings.\n", filePath)
+			fmt.Fprintf(os.Stderr, "Info: Config file '%s' not found, using default values for all settings.\n", filePath)
 			if validationErr := cfg.Validate(); validationErr != nil {
 				return nil, fmt.Errorf("default configuration validation failed: %w", validationErr)
 			}
@@ -58,6 +84,6 @@ func LoadConfig(filePath string) (*Config, error) {
 		return nil, fmt.Errorf("loaded configuration validation failed: %w", err)
 	}
 
-	fmt.Printf("Info: Configuration successfully loaded from '%s'.\n", filePath)
+	fmt.Fprintf(os.Stderr, "Info: Configuration successfully loaded from '%s'.\n", filePath)
 	return &cfg, nil
 }
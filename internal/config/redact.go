@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue replaces secrets in a redacted configuration.
+const redactedValue = "REDACTED"
+
+// Redacted returns a copy of the configuration that is safe to expose: the bearer token and the PostgreSQL DSN
+// are replaced by a placeholder, and so are the credentials, path and query parameter values of the node and
+// proxy URLs, where node providers put API keys.
+func (c Config) Redacted() Config {
+	redacted := c
+	redacted.ETHClient.NodeURL = redactURL(c.ETHClient.NodeURL)
+	if c.ETHClient.FallbackNodeURLs != nil {
+		redacted.ETHClient.FallbackNodeURLs = make([]string, len(c.ETHClient.FallbackNodeURLs))
+		for i, u := range c.ETHClient.FallbackNodeURLs {
+			redacted.ETHClient.FallbackNodeURLs[i] = redactURL(u)
+		}
+	}
+	redacted.ETHClient.HTTPProxyURL = redactURL(c.ETHClient.HTTPProxyURL)
+	redacted.ETHClient.BearerToken = redactSecret(c.ETHClient.BearerToken)
+	redacted.Storage.Postgres.DSN = redactSecret(c.Storage.Postgres.DSN)
+	return redacted
+}
+
+// Dump returns the redacted configuration as nested maps keyed by the YAML field names, ready to be encoded
+// as JSON.
+func (c Config) Dump() (map[string]any, error) {
+	raw, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var dump map[string]any
+	if err := yaml.Unmarshal(raw, &dump); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return dump, nil
+}
+
+// redactSecret replaces a non-empty secret by the placeholder, so it is still visible whether one is set.
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// redactURL keeps the scheme and host of a URL and replaces its credentials, path and query parameter values
+// by the placeholder. A URL that cannot be parsed is replaced as a whole.
+func redactURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return redactedValue
+	}
+	if parsed.User != nil {
+		parsed.User = url.User(redactedValue)
+	}
+	if parsed.Path != "" && parsed.Path != "/" {
+		parsed.Path = "/" + redactedValue
+		parsed.RawPath = ""
+	}
+	if parsed.RawQuery != "" {
+		query := parsed.Query()
+		for key := range query {
+			query[key] = []string{redactedValue}
+		}
+		parsed.RawQuery = query.Encode()
+	}
+	parsed.Fragment = ""
+	return parsed.String()
+}
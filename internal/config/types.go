@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
 	"strings"
 )
 
@@ -16,8 +18,70 @@ const (
 	DefaultServerWriteTimeoutSeconds        = 30
 	DefaultServerIdleTimeoutSeconds         = 60
 	DefaultServerReadHeaderTimeoutSeconds   = 30
+	DefaultServerShutdownTimeoutSeconds     = 15
+	DefaultServerContentTypeCharset         = "utf-8"
+	DefaultServerGzipMinBytes               = 1024
+	DefaultServerMaxBodyBytes               = 8192
+	DefaultServerStreamWriteTimeoutSeconds  = 10
+	DefaultServerStreamHeartbeatSeconds     = 15
+	DefaultServerStreamBufferSize           = 64
 	DefaultEthClientTimeoutSeconds          = 20
+	DefaultEthRPCCallTimeoutSeconds         = 10
+	DefaultEthDebugLogMaxBytes              = 2048
+	DefaultEthParseMode                     = ParseModeLenient
 	DefaultAppServicePollingIntervalSeconds = 10
+	DefaultAppServiceMaxBlocksPerScan       = 100
+	DefaultAppServiceHeadBlockTag           = "latest"
+	DefaultAppServiceShutdownTimeoutSeconds = 10
+	DefaultAppServiceMatchStrategy          = "exact"
+	DefaultAppServiceStoreRetryAttempts     = 3
+	DefaultAppServiceStoreRetryBackoffMs    = 100
+	DefaultAppServiceMaxDeadLetters         = 1000
+	DefaultAppServiceMode                   = AppServiceModeFollow
+	DefaultAppServicePruneIntervalSeconds   = 60
+	DefaultAppServiceTimestampCheck         = TimestampCheckWarn
+	DefaultAppServiceMaxTimestampDriftSecs  = 900
+	DefaultStorageBackend                   = StorageBackendMemory
+)
+
+// MinScanTimeoutSeconds is the smallest time budget of a scan iteration. A shorter budget rarely suffices to
+// fetch even a single block, so the parser would make no progress.
+const MinScanTimeoutSeconds = 2
+
+// Defines the supported parser modes.
+const (
+	// AppServiceModeFollow keeps scanning new blocks as the chain grows.
+	AppServiceModeFollow = "follow"
+	// AppServiceModeBackfill scans a fixed range of blocks and then stops.
+	AppServiceModeBackfill = "backfill"
+)
+
+// Defines how strictly blocks received from the node are parsed.
+const (
+	// ParseModeLenient leaves malformed transactions out of a block, counting and logging them.
+	ParseModeLenient = "lenient"
+	// ParseModeStrict fails the whole block when any of its fields or transactions is malformed.
+	ParseModeStrict = "strict"
+)
+
+// Defines the supported storage backends.
+const (
+	// StorageBackendMemory keeps all data in process memory; it is lost on restart.
+	StorageBackendMemory = "memory"
+	// StorageBackendSQLite stores data in a local SQLite database file.
+	StorageBackendSQLite = "sqlite"
+	// StorageBackendPostgres stores data in a PostgreSQL database.
+	StorageBackendPostgres = "postgres"
+)
+
+// Defines how implausible block timestamps are handled.
+const (
+	// TimestampCheckOff accepts every block timestamp.
+	TimestampCheckOff = "off"
+	// TimestampCheckWarn logs a warning and processes the block anyway.
+	TimestampCheckWarn = "warn"
+	// TimestampCheckReject refuses the block, so it is fetched again on the next poll.
+	TimestampCheckReject = "reject"
 )
 
 // LogLevel defines the type for logger levels.
@@ -46,27 +110,66 @@ type Config struct {
 	Logger     LoggerConfig             `yaml:"logger"`
 	ETHClient  ETHClientConfig          `yaml:"eth_client"`
 	AppService ApplicationServiceConfig `yaml:"app_service"`
+	Storage    StorageConfig            `yaml:"storage"`
 }
 
 // ServerConfig holds all configuration related to the HTTP server.
 type ServerConfig struct {
-	Port                     string `yaml:"port"`
-	ReadTimeoutSeconds       int    `yaml:"read_timeout_seconds"`
-	WriteTimeoutSeconds      int    `yaml:"write_timeout_seconds"`
-	IdleTimeoutSeconds       int    `yaml:"idle_timeout_seconds"`
-	ReadHeaderTimeoutSeconds int    `yaml:"read_header_timeout_seconds"`
+	Port                     string   `yaml:"port"`
+	ReadTimeoutSeconds       int      `yaml:"read_timeout_seconds"`
+	WriteTimeoutSeconds      int      `yaml:"write_timeout_seconds"`
+	IdleTimeoutSeconds       int      `yaml:"idle_timeout_seconds"`
+	ReadHeaderTimeoutSeconds int      `yaml:"read_header_timeout_seconds"`
+	ShutdownTimeoutSeconds   int      `yaml:"shutdown_timeout_seconds"`
+	ContentTypeCharset       string   `yaml:"content_type_charset"`
+	GzipMinBytes             int      `yaml:"gzip_min_bytes"`
+	MaxBodyBytes             int64    `yaml:"max_body_bytes"`
+	MaxConcurrentRequests    int      `yaml:"max_concurrent_requests"`
+	WaitForFirstScan         bool     `yaml:"wait_for_first_scan"`
+	AdminEnabled             bool     `yaml:"admin_enabled"`
+	TLSCertFile              string   `yaml:"tls_cert_file"`
+	TLSKeyFile               string   `yaml:"tls_key_file"`
+	CORSAllowedOrigins       []string `yaml:"cors_allowed_origins"`
+	CORSAllowedMethods       []string `yaml:"cors_allowed_methods"`
+	CORSAllowedHeaders       []string `yaml:"cors_allowed_headers"`
+	// Server-Sent Events streams: each write must complete within StreamWriteTimeoutSeconds, idle streams get
+	// a keepalive every StreamHeartbeatSeconds, and a client more than StreamBufferSize events behind is dropped.
+	StreamWriteTimeoutSeconds int `yaml:"stream_write_timeout_seconds"`
+	StreamHeartbeatSeconds    int `yaml:"stream_heartbeat_seconds"`
+	StreamBufferSize          int `yaml:"stream_buffer_size"`
 }
 
 // LoggerConfig holds all configuration related to logging.
+// Attributes are attached to every log line, e.g. to tell apart instances of the service.
 type LoggerConfig struct {
-	Level  LogLevel  `yaml:"level"`
-	Format LogFormat `yaml:"format"`
+	Level      LogLevel          `yaml:"level"`
+	Format     LogFormat         `yaml:"format"`
+	AddSource  bool              `yaml:"add_source"`
+	Attributes map[string]string `yaml:"attributes"`
 }
 
 // ETHClientConfig holds all configuration related to the Ethereum client.
 type ETHClientConfig struct {
-	NodeURL              string `yaml:"node_url"`
-	ClientTimeoutSeconds int    `yaml:"client_timeout_seconds"`
+	NodeURL               string   `yaml:"node_url"`
+	FallbackNodeURLs      []string `yaml:"fallback_node_urls"`
+	ClientTimeoutSeconds  int      `yaml:"client_timeout_seconds"`
+	RPCCallTimeoutSeconds int      `yaml:"rpc_call_timeout_seconds"`
+	BlockCacheSize        int      `yaml:"block_cache_size"`
+	BearerToken           string   `yaml:"bearer_token"`
+	DebugLogPayloads      bool     `yaml:"debug_log_payloads"`
+	DebugLogMaxBytes      int      `yaml:"debug_log_max_bytes"`
+	ExpectedChainID       int64    `yaml:"expected_chain_id"`
+	ParseMode             string   `yaml:"parse_mode"`
+	HTTPProxyURL          string   `yaml:"http_proxy_url"`
+	AllowedMethods        []string `yaml:"allowed_methods"`
+	LatencyReportSeconds  int      `yaml:"latency_report_seconds"`
+}
+
+// NodeURLs returns the primary node URL followed by the fallback URLs, in order of preference.
+func (c ETHClientConfig) NodeURLs() []string {
+	urls := make([]string, 0, 1+len(c.FallbackNodeURLs))
+	urls = append(urls, c.NodeURL)
+	return append(urls, c.FallbackNodeURLs...)
 }
 
 // ApplicationConfig holds all configuration related to the Ethereum client.
@@ -77,7 +180,53 @@ type ApplicationConfig struct {
 
 // ApplicationServiceConfig holds configuration for the core application service (parser).
 type ApplicationServiceConfig struct {
-	PollingIntervalSeconds int `yaml:"polling_interval_seconds"`
+	PollingIntervalSeconds  int    `yaml:"polling_interval_seconds"`
+	PollingJitterPercent    int    `yaml:"polling_jitter_percent"`
+	CatchupPollingSeconds   int    `yaml:"catchup_polling_interval_seconds"`
+	ScanTimeoutSeconds      int    `yaml:"scan_timeout_seconds"`
+	MaxBlocksPerScan        int64  `yaml:"max_blocks_per_scan"`
+	RescanTailBlocks        int64  `yaml:"rescan_tail_blocks"`
+	StartOnNodeError        bool   `yaml:"start_on_node_error"`
+	HeadBlockTag            string `yaml:"head_block_tag"`
+	SkipProcessedBlocks     bool   `yaml:"skip_processed_blocks"`
+	ShutdownTimeoutSeconds  int    `yaml:"shutdown_timeout_seconds"`
+	BackfillGaps            bool   `yaml:"backfill_gaps"`
+	MatchStrategy           string `yaml:"match_strategy"`
+	FetchReceipts           bool   `yaml:"fetch_receipts"`
+	StoreRetryAttempts      int    `yaml:"store_retry_attempts"`
+	StoreRetryBackoffMillis int    `yaml:"store_retry_backoff_ms"`
+	MaxDeadLetters          int    `yaml:"max_dead_letters"`
+	RetentionBlocks         int64  `yaml:"retention_blocks"`
+	PruneIntervalSeconds    int    `yaml:"prune_interval_seconds"`
+	MaxTransactionsPerAddr  int    `yaml:"max_transactions_per_address"`
+	Mode                    string `yaml:"mode"`
+	BackfillFromBlock       int64  `yaml:"backfill_from_block"`
+	BackfillToBlock         int64  `yaml:"backfill_to_block"`
+	TimestampCheck          string `yaml:"timestamp_check"`
+	MaxTimestampDriftSecs   int    `yaml:"max_timestamp_drift_seconds"`
+	IdempotentSubscribe     bool   `yaml:"idempotent_subscribe"`
+	MaxSubscriptions        int    `yaml:"max_subscriptions"`
+	StreamBlocks            bool   `yaml:"stream_blocks"`
+}
+
+// StorageConfig holds all configuration related to the storage backend.
+// Only the sub-configuration of the selected backend is used.
+type StorageConfig struct {
+	Backend           string                `yaml:"backend"`
+	AtomicBlockWrites bool                  `yaml:"atomic_block_writes"`
+	SQLite            SQLiteStorageConfig   `yaml:"sqlite"`
+	Postgres          PostgresStorageConfig `yaml:"postgres"`
+}
+
+// SQLiteStorageConfig holds the configuration of the SQLite storage backend.
+type SQLiteStorageConfig struct {
+	Path string `yaml:"path"`
+}
+
+// PostgresStorageConfig holds the configuration of the PostgreSQL storage backend.
+type PostgresStorageConfig struct {
+	DSN          string `yaml:"dsn"`
+	MaxOpenConns int    `yaml:"max_open_conns"`
 }
 
 // Validate checks if the configuration values are valid.
@@ -94,13 +243,52 @@ func (c *Config) Validate() error {
 	if !validFormats[c.Logger.Format] {
 		return fmt.Errorf("logger.format: '%s' is invalid; must be one of: json, text", c.Logger.Format)
 	}
+	for key := range c.Logger.Attributes {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("logger.attributes: keys cannot be empty")
+		}
+	}
 
 	if c.ETHClient.NodeURL == "" {
 		return errors.New("eth_client.node_url: cannot be empty")
 	}
+	for i, u := range c.ETHClient.FallbackNodeURLs {
+		if u == "" {
+			return fmt.Errorf("eth_client.fallback_node_urls[%d]: cannot be empty", i)
+		}
+	}
+	for i, m := range c.ETHClient.AllowedMethods {
+		if strings.TrimSpace(m) == "" {
+			return fmt.Errorf("eth_client.allowed_methods[%d]: cannot be empty", i)
+		}
+	}
+	if c.ETHClient.HTTPProxyURL != "" {
+		proxyURL, err := url.Parse(c.ETHClient.HTTPProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return errors.New("eth_client.http_proxy_url: must be an absolute URL such as http://proxy.example.com:3128")
+		}
+	}
 	if c.ETHClient.ClientTimeoutSeconds <= 0 {
 		return errors.New("eth_client.client_timeout_seconds must be > 0")
 	}
+	if c.ETHClient.RPCCallTimeoutSeconds < 0 {
+		return errors.New("eth_client.rpc_call_timeout_seconds cannot be negative")
+	}
+	if c.ETHClient.BlockCacheSize < 0 {
+		return errors.New("eth_client.block_cache_size cannot be negative")
+	}
+	if c.ETHClient.ExpectedChainID < 0 {
+		return errors.New("eth_client.expected_chain_id cannot be negative")
+	}
+	if c.ETHClient.LatencyReportSeconds < 0 {
+		return errors.New("eth_client.latency_report_seconds cannot be negative")
+	}
+	if c.ETHClient.DebugLogMaxBytes <= 0 {
+		return errors.New("eth_client.debug_log_max_bytes must be > 0")
+	}
+	if c.ETHClient.ParseMode != ParseModeLenient && c.ETHClient.ParseMode != ParseModeStrict {
+		return fmt.Errorf("eth_client.parse_mode: '%s' is invalid; must be one of: lenient, strict", c.ETHClient.ParseMode)
+	}
 
 	if c.Server.ReadTimeoutSeconds < 0 {
 		return errors.New("server.read_timeout_seconds cannot be negative")
@@ -114,10 +302,151 @@ func (c *Config) Validate() error {
 	if c.Server.ReadHeaderTimeoutSeconds < 0 {
 		return errors.New("server.read_header_timeout_seconds cannot be negative")
 	}
+	if c.Server.ShutdownTimeoutSeconds <= 0 {
+		return errors.New("server.shutdown_timeout_seconds must be > 0")
+	}
+	if c.Server.GzipMinBytes < 0 {
+		return errors.New("server.gzip_min_bytes cannot be negative")
+	}
+	if c.Server.MaxBodyBytes <= 0 {
+		return errors.New("server.max_body_bytes must be > 0")
+	}
+	if c.Server.MaxConcurrentRequests < 0 {
+		return errors.New("server.max_concurrent_requests cannot be negative")
+	}
+	if c.Server.StreamWriteTimeoutSeconds <= 0 {
+		return errors.New("server.stream_write_timeout_seconds must be > 0")
+	}
+	if c.Server.StreamHeartbeatSeconds <= 0 {
+		return errors.New("server.stream_heartbeat_seconds must be > 0")
+	}
+	if c.Server.StreamBufferSize <= 0 {
+		return errors.New("server.stream_buffer_size must be > 0")
+	}
+	for i, origin := range c.Server.CORSAllowedOrigins {
+		if origin == "" {
+			return fmt.Errorf("server.cors_allowed_origins[%d]: cannot be empty", i)
+		}
+	}
+	if len(c.Server.CORSAllowedOrigins) > 0 && len(c.Server.CORSAllowedMethods) == 0 {
+		return errors.New("server.cors_allowed_methods cannot be empty when cors_allowed_origins is set")
+	}
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		return errors.New("server.tls_cert_file and server.tls_key_file must be set together")
+	}
+	for key, path := range map[string]string{
+		"server.tls_cert_file": c.Server.TLSCertFile,
+		"server.tls_key_file":  c.Server.TLSKeyFile,
+	} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
 
 	if c.AppService.PollingIntervalSeconds <= 0 {
 		return errors.New("app_service.polling_interval_seconds must be > 0")
 	}
+	if c.AppService.PollingJitterPercent < 0 || c.AppService.PollingJitterPercent >= 100 {
+		return errors.New("app_service.polling_jitter_percent must be between 0 and 99")
+	}
+	if c.AppService.CatchupPollingSeconds < 0 {
+		return errors.New("app_service.catchup_polling_interval_seconds cannot be negative")
+	}
+	if c.AppService.CatchupPollingSeconds > c.AppService.PollingIntervalSeconds {
+		return errors.New("app_service.catchup_polling_interval_seconds cannot be longer than polling_interval_seconds")
+	}
+	if c.AppService.ScanTimeoutSeconds < 0 {
+		return errors.New("app_service.scan_timeout_seconds cannot be negative")
+	}
+	if c.AppService.ScanTimeoutSeconds > 0 && c.AppService.ScanTimeoutSeconds < MinScanTimeoutSeconds {
+		return fmt.Errorf("app_service.scan_timeout_seconds must be 0 (derived from polling_interval_seconds) or at least %d",
+			MinScanTimeoutSeconds)
+	}
+	if c.AppService.MaxBlocksPerScan < 0 {
+		return errors.New("app_service.max_blocks_per_scan cannot be negative")
+	}
+	if c.AppService.RescanTailBlocks < 0 {
+		return errors.New("app_service.rescan_tail_blocks cannot be negative")
+	}
+	if c.AppService.StoreRetryAttempts < 1 {
+		return errors.New("app_service.store_retry_attempts must be >= 1")
+	}
+	if c.AppService.StoreRetryBackoffMillis < 0 {
+		return errors.New("app_service.store_retry_backoff_ms cannot be negative")
+	}
+	if c.AppService.MaxDeadLetters < 0 {
+		return errors.New("app_service.max_dead_letters cannot be negative")
+	}
+	if c.AppService.RetentionBlocks < 0 {
+		return errors.New("app_service.retention_blocks cannot be negative")
+	}
+	if c.AppService.PruneIntervalSeconds <= 0 {
+		return errors.New("app_service.prune_interval_seconds must be > 0")
+	}
+	if c.AppService.MaxTransactionsPerAddr < 0 {
+		return errors.New("app_service.max_transactions_per_address cannot be negative")
+	}
+	if c.AppService.MaxSubscriptions < 0 {
+		return errors.New("app_service.max_subscriptions cannot be negative")
+	}
+	if c.AppService.ShutdownTimeoutSeconds <= 0 {
+		return errors.New("app_service.shutdown_timeout_seconds must be > 0")
+	}
+	validMatchStrategies := map[string]bool{"exact": true, "input": true}
+	if !validMatchStrategies[c.AppService.MatchStrategy] {
+		return fmt.Errorf("app_service.match_strategy: '%s' is invalid; must be one of: exact, input",
+			c.AppService.MatchStrategy)
+	}
+	switch c.AppService.Mode {
+	case AppServiceModeFollow:
+	case AppServiceModeBackfill:
+		if c.AppService.BackfillFromBlock < 1 {
+			return errors.New("app_service.backfill_from_block must be >= 1")
+		}
+		if c.AppService.BackfillToBlock < c.AppService.BackfillFromBlock {
+			return errors.New("app_service.backfill_to_block must be >= app_service.backfill_from_block")
+		}
+	default:
+		return fmt.Errorf("app_service.mode: '%s' is invalid; must be one of: follow, backfill", c.AppService.Mode)
+	}
+	validTimestampChecks := map[string]bool{TimestampCheckOff: true, TimestampCheckWarn: true, TimestampCheckReject: true}
+	if !validTimestampChecks[c.AppService.TimestampCheck] {
+		return fmt.Errorf("app_service.timestamp_check: '%s' is invalid; must be one of: off, warn, reject",
+			c.AppService.TimestampCheck)
+	}
+	if c.AppService.MaxTimestampDriftSecs <= 0 {
+		return errors.New("app_service.max_timestamp_drift_seconds must be > 0")
+	}
+	validHeadTags := map[string]bool{"latest": true, "safe": true, "finalized": true}
+	if !validHeadTags[c.AppService.HeadBlockTag] {
+		return fmt.Errorf("app_service.head_block_tag: '%s' is invalid; must be one of: latest, safe, finalized",
+			c.AppService.HeadBlockTag)
+	}
+
+	return c.Storage.validate()
+}
+
+// validate checks that the selected storage backend is known and has the settings it needs.
+func (c *StorageConfig) validate() error {
+	switch c.Backend {
+	case StorageBackendMemory:
+	case StorageBackendSQLite:
+		if strings.TrimSpace(c.SQLite.Path) == "" {
+			return errors.New("storage.sqlite.path is required when storage.backend is sqlite")
+		}
+	case StorageBackendPostgres:
+		if strings.TrimSpace(c.Postgres.DSN) == "" {
+			return errors.New("storage.postgres.dsn is required when storage.backend is postgres")
+		}
+		if c.Postgres.MaxOpenConns < 0 {
+			return errors.New("storage.postgres.max_open_conns cannot be negative")
+		}
+	default:
+		return fmt.Errorf("storage.backend: '%s' is invalid; must be one of: memory, sqlite, postgres", c.Backend)
+	}
 
 	return nil
 }
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"hash/maphash"
+	"math/bits"
+
+	"trust_wallet_homework/internal/core/domain"
+)
+
+// Sizing of the address Bloom filter: with 16 bits per address and 4 probes the false positive rate is ~0.2%.
+const (
+	bloomBitsPerAddress = 16
+	bloomProbes         = 4
+	bloomMinBits        = 64
+)
+
+// addressBloom is a Bloom filter over monitored addresses. It can report false positives but never
+// false negatives, so a negative answer proves that an address is not monitored.
+type addressBloom struct {
+	bits []uint64
+	mask uint64
+	seed maphash.Seed
+}
+
+// newAddressBloom builds a filter containing the given addresses.
+func newAddressBloom(addresses map[domain.Address]struct{}) *addressBloom {
+	size := uint64(bloomMinBits)
+	for size < uint64(len(addresses))*bloomBitsPerAddress {
+		size <<= 1
+	}
+	b := &addressBloom{bits: make([]uint64, size/64), mask: size - 1, seed: maphash.MakeSeed()}
+	for addr := range addresses {
+		b.add(addr)
+	}
+	return b
+}
+
+// add inserts the address into the filter.
+func (b *addressBloom) add(addr domain.Address) {
+	if addr.IsZero() {
+		return
+	}
+	h1, h2 := b.probeHashes(addr)
+	for i := uint64(0); i < bloomProbes; i++ {
+		pos := (h1 + i*h2) & b.mask
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// mayContain reports whether the address may be in the filter.
+func (b *addressBloom) mayContain(addr domain.Address) bool {
+	if addr.IsZero() {
+		return false
+	}
+	h1, h2 := b.probeHashes(addr)
+	for i := uint64(0); i < bloomProbes; i++ {
+		pos := (h1 + i*h2) & b.mask
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// probeHashes derives the two hashes used for double hashing from a single 64-bit hash of the address.
+func (b *addressBloom) probeHashes(addr domain.Address) (h1, h2 uint64) {
+	h := maphash.String(b.seed, addr.String())
+	return h, bits.RotateLeft64(h, 32) | 1
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unfilteredMatcher hides the participantMatcher marker of the wrapped matcher, disabling the Bloom prefilter.
+type unfilteredMatcher struct {
+	inner TransactionMatcher
+}
+
+func (m unfilteredMatcher) Match(tx domain.Transaction, monitored map[domain.Address]struct{}) []domain.Address {
+	return m.inner.Match(tx, monitored)
+}
+
+func randomAddress(t testing.TB, rng *rand.Rand) domain.Address {
+	t.Helper()
+	addr, err := domain.NewAddress(fmt.Sprintf("0x%016x%016x%08x", rng.Uint64(), rng.Uint64(), rng.Uint32()))
+	require.NoError(t, err)
+	return addr
+}
+
+func TestAddressBloom_NoFalseNegatives(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	set := make(map[domain.Address]struct{})
+	for range 5000 {
+		set[randomAddress(t, rng)] = struct{}{}
+	}
+	bloom := newAddressBloom(set)
+
+	for addr := range set {
+		require.True(t, bloom.mayContain(addr), "monitored address %s must never be filtered out", addr)
+	}
+
+	falsePositives := 0
+	const probes = 20000
+	for range probes {
+		if bloom.mayContain(randomAddress(t, rng)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, probes/100, "false positive rate should stay well below 1%%")
+	assert.False(t, bloom.mayContain(domain.Address{}), "zero address is never monitored")
+}
+
+func TestMatchBlock_BloomPrefilterMissesNoMatch(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+
+	directions := make(map[domain.Address]domain.SubscriptionDirection)
+	monitoredList := make([]domain.Address, 0, 200)
+	for range 200 {
+		addr := randomAddress(t, rng)
+		directions[addr] = domain.SubscriptionDirectionBoth
+		monitoredList = append(monitoredList, addr)
+	}
+	monitored := newMonitoredAddresses(directions)
+
+	blockNum, _ := domain.NewBlockNumber(1)
+	value, _ := domain.NewWeiValue("0x1")
+	txs := make([]domain.Transaction, 0, 2000)
+	for i := range 2000 {
+		hash, err := domain.NewTransactionHash(fmt.Sprintf("0x%064x", i+1))
+		require.NoError(t, err)
+		from, to := randomAddress(t, rng), randomAddress(t, rng)
+		switch i % 50 {
+		case 0:
+			from = monitoredList[rng.IntN(len(monitoredList))]
+		case 1:
+			to = monitoredList[rng.IntN(len(monitoredList))]
+		case 2:
+			to = domain.Address{}
+		}
+		txs = append(txs, domain.NewTransaction(hash, from, to, value, blockNum, 1000))
+	}
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, txs)
+
+	filtered, err := service.matchBlock(context.Background(), &block, monitored)
+	require.NoError(t, err)
+
+	service.matcher = unfilteredMatcher{inner: ExactMatcher{}}
+	unfiltered, err := service.matchBlock(context.Background(), &block, monitored)
+	require.NoError(t, err)
+
+	assert.Len(t, unfiltered, 80)
+	assert.Equal(t, unfiltered, filtered)
+}
+
+func BenchmarkMatchBlock_NoMonitoredActivity(b *testing.B) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	blockNum, _ := domain.NewBlockNumber(1)
+	value, _ := domain.NewWeiValue("0x1")
+	txs := make([]domain.Transaction, 0, 500)
+	for i := range 500 {
+		hash, _ := domain.NewTransactionHash(fmt.Sprintf("0x%064x", i+1))
+		txs = append(txs, domain.NewTransaction(hash, randomAddress(b, rng), randomAddress(b, rng), value, blockNum, 1000))
+	}
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, txs)
+
+	for _, size := range []int{1_000, 100_000} {
+		directions := make(map[domain.Address]domain.SubscriptionDirection, size)
+		for range size {
+			directions[randomAddress(b, rng)] = domain.SubscriptionDirectionBoth
+		}
+		monitored := newMonitoredAddresses(directions)
+
+		for _, bc := range []struct {
+			name    string
+			matcher TransactionMatcher
+		}{
+			{name: "bloom", matcher: ExactMatcher{}},
+			{name: "no_bloom", matcher: unfilteredMatcher{inner: ExactMatcher{}}},
+		} {
+			b.Run(fmt.Sprintf("addresses=%d/%s", size, bc.name), func(b *testing.B) {
+				service := &ParserServiceImpl{matcher: bc.matcher}
+				ctx := context.Background()
+				b.ReportAllocs()
+				for b.Loop() {
+					if _, err := service.matchBlock(ctx, &block, monitored); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -6,13 +6,74 @@ import (
 )
 
 // mapDomainToAPITransaction converts an internal domain Transaction to the public API Transaction DTO.
-func mapDomainToAPITransaction(domainTx domain.Transaction) ethparser.Transaction {
+// The direction is computed relative to the given address; a zero address leaves it empty.
+func mapDomainToAPITransaction(domainTx domain.Transaction, relativeTo domain.Address) ethparser.Transaction {
 	return ethparser.Transaction{
-		Hash:        domainTx.Hash.String(),
-		From:        domainTx.From.String(),
-		To:          domainTx.To.String(),
-		Value:       domainTx.Value.String(),
-		BlockNumber: domainTx.BlockNumber.Value(),
-		Timestamp:   domainTx.Timestamp,
+		Hash:             domainTx.Hash.String(),
+		From:             domainTx.From.String(),
+		To:               transactionRecipient(domainTx),
+		Value:            domainTx.Value.String(),
+		BlockNumber:      domainTx.BlockNumber.Value(),
+		Timestamp:        domainTx.Timestamp,
+		TransactionIndex: domainTx.TransactionIndex,
+		Direction:        transactionDirection(domainTx, relativeTo),
+		Status:           transactionStatus(domainTx.Status),
+	}
+}
+
+// transactionRecipient returns the recipient address, or nil for a contract creation.
+func transactionRecipient(domainTx domain.Transaction) *string {
+	if domainTx.IsContractCreation() {
+		return nil
+	}
+	to := domainTx.To.String()
+	return &to
+}
+
+// transactionStatus converts a known receipt status to its API value, or nil if the status is unknown.
+func transactionStatus(status domain.TransactionStatus) *int {
+	var apiStatus int
+	switch status {
+	case domain.TransactionStatusSuccess:
+		apiStatus = ethparser.StatusSuccess
+	case domain.TransactionStatusFailed:
+		apiStatus = ethparser.StatusFailed
+	default:
+		return nil
+	}
+	return &apiStatus
+}
+
+// transactionDirection reports whether the transaction is inbound, outbound or a self-transfer for the address.
+func transactionDirection(domainTx domain.Transaction, address domain.Address) string {
+	if address.IsZero() {
+		return ""
+	}
+	isSender := domainTx.From.Equals(address)
+	isRecipient := domainTx.To.Equals(address)
+	switch {
+	case isSender && isRecipient:
+		return ethparser.DirectionSelf
+	case isSender:
+		return ethparser.DirectionOut
+	case isRecipient:
+		return ethparser.DirectionIn
+	default:
+		return ""
+	}
+}
+
+// mapDomainToAPIBlock converts an internal domain Block to the public API Block DTO.
+func mapDomainToAPIBlock(domainBlock *domain.Block) *ethparser.Block {
+	txs := make([]ethparser.Transaction, 0, len(domainBlock.Transactions))
+	for _, domainTx := range domainBlock.Transactions {
+		txs = append(txs, mapDomainToAPITransaction(domainTx, domain.Address{}))
+	}
+	return &ethparser.Block{
+		Number:           domainBlock.Number.Value(),
+		Hash:             domainBlock.Hash.String(),
+		Timestamp:        domainBlock.Timestamp,
+		TransactionCount: len(txs),
+		Transactions:     txs,
 	}
 }
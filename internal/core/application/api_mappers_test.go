@@ -0,0 +1,43 @@
+package application
+
+import (
+	"encoding/json"
+	"testing"
+
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapDomainToAPITransaction_ContractCreation(t *testing.T) {
+	sender, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	value, _ := domain.NewWeiValue("0x0")
+	blockNum, _ := domain.NewBlockNumber(10)
+	creation := domain.NewTransaction(hash, sender, domain.Address{}, value, blockNum, 1000)
+
+	apiTx := mapDomainToAPITransaction(creation, sender)
+	assert.Nil(t, apiTx.To)
+	assert.Equal(t, "out", apiTx.Direction)
+
+	payload, err := json.Marshal(apiTx)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(payload, &fields))
+	require.Contains(t, fields, "to")
+	assert.Nil(t, fields["to"], "contract creations are serialized with a null recipient")
+}
+
+func TestMapDomainToAPITransaction_Recipient(t *testing.T) {
+	sender, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	recipient, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+
+	apiTx := mapDomainToAPITransaction(domain.NewTransaction(hash, sender, recipient, value, blockNum, 1000), recipient)
+	require.NotNil(t, apiTx.To)
+	assert.Equal(t, recipient.String(), *apiTx.To)
+	assert.Equal(t, "in", apiTx.Direction)
+}
@@ -6,22 +6,57 @@ import (
 	"fmt"
 	"time"
 
+	"trust_wallet_homework/internal/config"
 	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/client"
+	"trust_wallet_homework/internal/core/domain/repository"
+	"trust_wallet_homework/internal/logger"
 )
 
+// shutdownDrainTimeout bounds the work that continues after the scan context is cancelled: storing the
+// matched transactions of the block being processed, and recording the last fully processed block.
+const shutdownDrainTimeout = 5 * time.Second
+
+// drainContext returns a context that is not cancelled with ctx but expires after shutdownDrainTimeout.
+// It lets a block whose transactions are being stored finish, so a shutdown never leaves it half stored.
+func drainContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), shutdownDrainTimeout)
+}
+
 // pollBlocks is the main background loop for scanning the blockchain.
+// Each tick is rescheduled with a fresh jittered interval, so instances sharing a node do not poll in lockstep.
 func (s *ParserServiceImpl) pollBlocks() {
 	defer close(s.stopChan)
-	ticker := time.NewTicker(s.pollingInterval)
-	defer ticker.Stop()
+	interval := s.nextPollInterval()
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	pruneC, stopPruning := s.pruneTicks()
+	defer stopPruning()
 
 	s.logger.Info("Polling loop started.")
 
-	s.scanBlockRange(s.lastKnownBlock)
+	if s.startBlockPending {
+		s.resolveStartBlock()
+	} else {
+		started := s.now()
+		s.scanBlockRange(s.lastKnownBlock)
+		s.skipMissedTick(timer, interval, started)
+		s.rescheduleIfCatchingUp(timer)
+	}
+	if s.finishBackfillIfComplete() {
+		return
+	}
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
+			interval = s.nextPollInterval()
+			timer.Reset(interval)
+			s.applyPendingRewind()
+			if s.startBlockPending {
+				s.resolveStartBlock()
+				continue
+			}
 			currentBlockFromState, err := s.stateRepo.GetCurrentBlock(s.pollCtx)
 			if err != nil {
 				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
@@ -31,7 +66,20 @@ func (s *ParserServiceImpl) pollBlocks() {
 				s.logger.Error("Failed to get current block from state before polling tick scan", "error", err)
 				continue
 			}
+			started := s.now()
 			s.scanBlockRange(currentBlockFromState)
+			s.skipMissedTick(timer, interval, started)
+			s.rescheduleIfCatchingUp(timer)
+			if s.finishBackfillIfComplete() {
+				return
+			}
+		case reply := <-s.scanRequests:
+			reply <- s.runRequestedScan()
+			if s.finishBackfillIfComplete() {
+				return
+			}
+		case <-pruneC:
+			s.pruneOldTransactions()
 		case <-s.pollCtx.Done():
 			s.logger.Info("Polling loop stopping due to context cancellation.")
 			return
@@ -39,13 +87,93 @@ func (s *ParserServiceImpl) pollBlocks() {
 	}
 }
 
+// finishBackfillIfComplete closes the Done channel once a backfill has scanned through its last block.
+func (s *ParserServiceImpl) finishBackfillIfComplete() bool {
+	if !s.backfill || !s.lastKnownBlockSet || s.lastKnownBlock.Value() < s.backfillTo {
+		return false
+	}
+	s.doneOnce.Do(func() {
+		s.logger.Info("Backfill complete", "fromBlock", s.backfillFrom, "toBlock", s.backfillTo)
+		close(s.done)
+	})
+	return true
+}
+
+// nextPollInterval returns the polling interval randomly shifted by up to ±pollingJitter of its length.
+// While the last scan left the parser behind the head, the catch-up interval is used if one is configured.
+func (s *ParserServiceImpl) nextPollInterval() time.Duration {
+	interval := s.pollingInterval
+	if s.catchingUp && s.catchupInterval > 0 {
+		interval = s.catchupInterval
+	}
+	if s.pollingJitter <= 0 {
+		return interval
+	}
+	factor := 1 + s.pollingJitter*(2*s.randFloat()-1)
+	return time.Duration(float64(interval) * factor)
+}
+
+// skipMissedTick restarts the timer with a fresh interval when the scan that began at started outlasted the
+// interval the timer was set to. The tick that came due during the scan is dropped instead of starting
+// the next scan right away, so a scan slower than the polling interval does not run back to back.
+func (s *ParserServiceImpl) skipMissedTick(timer *time.Timer, interval time.Duration, started time.Time) {
+	elapsed := s.now().Sub(started)
+	if elapsed < interval {
+		return
+	}
+	s.logger.Warn("Scan took longer than the polling interval, skipping the missed tick",
+		"scanDuration", elapsed.String(), "pollingInterval", interval.String())
+	timer.Reset(s.nextPollInterval())
+}
+
+// rescheduleIfCatchingUp moves the next poll forward to the catch-up interval after a scan that left the
+// parser behind the head. Once caught up, the timer set at the start of the tick keeps the regular interval.
+func (s *ParserServiceImpl) rescheduleIfCatchingUp(timer *time.Timer) {
+	if s.catchingUp && s.catchupInterval > 0 {
+		timer.Reset(s.nextPollInterval())
+	}
+}
+
+// resolveStartBlock retries fetching the starting point that could not be determined at startup.
+func (s *ParserServiceImpl) resolveStartBlock() {
+	latestNetBlock, err := s.fetchHeadBlockNumber(s.pollCtx)
+	if err != nil {
+		if !(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			s.logger.Warn("Starting block still unknown, node unavailable; will retry on next tick", "error", err)
+		}
+		return
+	}
+
+	s.setLastKnownBlock(latestNetBlock)
+	s.startBlockPending = false
+	s.logger.Info("Resolved deferred starting block from network", "blockNumber", s.lastKnownBlock.Value())
+	s.storeInitialBlock(s.pollCtx)
+}
+
+// detectGap reports the blocks between the last block this scanner persisted and the stored parser state,
+// which indicate that the state was moved forward without those blocks being scanned.
+func (s *ParserServiceImpl) detectGap(currentParsedBlock domain.BlockNumber) (gapStart, gapEnd int64, found bool) {
+	if !s.lastKnownBlockSet || currentParsedBlock.Value() <= s.lastKnownBlock.Value() {
+		return 0, 0, false
+	}
+	return s.lastKnownBlock.Value() + 1, currentParsedBlock.Value(), true
+}
+
+// fetchHeadBlockNumber returns the block treated as the chain head, according to the configured head block tag.
+func (s *ParserServiceImpl) fetchHeadBlockNumber(ctx context.Context) (domain.BlockNumber, error) {
+	if s.headBlockTag == "" || s.headBlockTag == client.BlockTagLatest {
+		return s.ethClient.GetLatestBlockNumber(ctx)
+	}
+	return s.ethClient.GetBlockNumberByTag(ctx, s.headBlockTag)
+}
+
 // getScanRange determines the block range to scan in the current iteration.
 func (s *ParserServiceImpl) getScanRange(
 	ctx context.Context,
 	currentParsedBlock domain.BlockNumber,
 ) (start, end int64, scanNeeded bool, err error) {
 	logger := s.logger.With("currentParsedBlock", currentParsedBlock.Value())
-	latestBlock, fetchErr := s.ethClient.GetLatestBlockNumber(ctx)
+	latestBlock, fetchErr := s.fetchHeadBlockNumber(ctx)
 	if fetchErr != nil {
 		if errors.Is(fetchErr, context.Canceled) || errors.Is(fetchErr, context.DeadlineExceeded) {
 			logger.Info("Context cancelled while fetching latest block number in getScanRange.", "error", fetchErr)
@@ -55,11 +183,36 @@ func (s *ParserServiceImpl) getScanRange(
 		return 0, 0, false, fmt.Errorf("error getting latest block number: %w", fetchErr)
 	}
 
-	start = currentParsedBlock.Value() + 1
+	firstNewBlock := currentParsedBlock.Value() + 1
 	end = latestBlock.Value()
+	if s.backfill {
+		end = min(end, s.backfillTo)
+	}
+
+	s.catchingUp = false
+	if s.maxBlocksPerScan > 0 && end-firstNewBlock+1 > s.maxBlocksPerScan {
+		s.catchingUp = true
+		end = firstNewBlock + s.maxBlocksPerScan - 1
+		logger.Info("Capping scan range to max blocks per scan",
+			"latestBlockOnNode", latestBlock.Value(),
+			"maxBlocksPerScan", s.maxBlocksPerScan,
+			"cappedEnd", end)
+	}
+
+	start = firstNewBlock
+	if s.rescanTailBlocks > 0 {
+		start = max(0, currentParsedBlock.Value()-s.rescanTailBlocks+1)
+	}
 
-	if end > latestBlock.Value() {
-		end = latestBlock.Value()
+	if gapStart, gapEnd, found := s.detectGap(currentParsedBlock); found {
+		logger.Warn("Detected gap in scanned block sequence; blocks in the gap were never scanned by this parser",
+			"gapStart", gapStart,
+			"gapEnd", gapEnd,
+			"lastKnownBlock", s.lastKnownBlock.Value(),
+			"backfill", s.backfillGaps)
+		if s.backfillGaps {
+			start = min(start, gapStart)
+		}
 	}
 
 	if start > end {
@@ -70,14 +223,71 @@ func (s *ParserServiceImpl) getScanRange(
 	return start, end, true, nil
 }
 
+// monitoredAddresses is the snapshot of subscriptions used during one scan iteration.
+type monitoredAddresses struct {
+	set        map[domain.Address]struct{}
+	directions map[domain.Address]domain.SubscriptionDirection
+	bloom      *addressBloom
+}
+
+// newMonitoredAddresses builds the snapshot from the subscription directions of the monitored addresses.
+func newMonitoredAddresses(directions map[domain.Address]domain.SubscriptionDirection) monitoredAddresses {
+	set := make(map[domain.Address]struct{}, len(directions))
+	for addr := range directions {
+		set[addr] = struct{}{}
+	}
+	return monitoredAddresses{set: set, directions: directions, bloom: newAddressBloom(set)}
+}
+
+// mayInvolve reports whether the sender or recipient of the transaction may be monitored.
+// A false result is definite; a true result must be confirmed by the matcher.
+func (m monitoredAddresses) mayInvolve(tx domain.Transaction) bool {
+	return m.bloom.mayContain(tx.From) || (!tx.IsContractCreation() && m.bloom.mayContain(tx.To))
+}
+
+// prefilter returns a copy of the block without the transactions that definitely involve no monitored address.
+func (m monitoredAddresses) prefilter(block domain.Block) domain.Block {
+	var candidates []domain.Transaction
+	for _, tx := range block.Transactions {
+		if m.mayInvolve(tx) {
+			candidates = append(candidates, tx)
+		}
+	}
+	block.Transactions = candidates
+	return block
+}
+
+// filterByDirection keeps the matched addresses whose subscription direction accepts the transaction.
+// excluded reports whether the sender or recipient was matched but rejected by its direction filter.
+func (m monitoredAddresses) filterByDirection(
+	tx domain.Transaction,
+	matched []domain.Address,
+) (accepted []domain.Address, excluded bool) {
+	accepted = matched[:0:0]
+	for _, addr := range matched {
+		if m.directions[addr].Accepts(tx, addr) {
+			accepted = append(accepted, addr)
+		} else if tx.InvolvesAddress(addr) {
+			excluded = true
+		}
+	}
+	return accepted, excluded
+}
+
 // processBlock fetches a single block, finds relevant transactions based on monitored addresses,
+// and stores those accepted by the subscription direction of the matched addresses.
 func (s *ParserServiceImpl) processBlock(
 	ctx context.Context,
 	blockNum domain.BlockNumber,
-	monitoredAddresses map[string]struct{},
+	monitored monitoredAddresses,
 ) error {
 	logger := s.logger.With("blockNumber", blockNum.Value())
 	logger.Debug("Processing block")
+	started := s.now()
+
+	if streamer, ok := s.ethClient.(client.BlockStreamer); ok && s.streamBlocks {
+		return s.processBlockStreaming(ctx, streamer, blockNum, monitored, logger, started)
+	}
 
 	block, err := s.ethClient.GetBlockWithTransactions(ctx, blockNum)
 	if err != nil {
@@ -95,51 +305,411 @@ func (s *ParserServiceImpl) processBlock(
 	}
 
 	logger = logger.With("blockHash", block.Hash.String(), "txCount", len(block.Transactions))
-	foundTxs := 0
-	for _, tx := range block.Transactions {
-		select {
-		case <-ctx.Done():
-			logger.Info("Context cancelled during transaction processing loop.", "error", ctx.Err())
-			return ctx.Err()
-		default:
-		}
+	if err := s.checkBlockTimestamp(block, logger); err != nil {
+		return err
+	}
+
+	matches, err := s.matchBlock(ctx, block, monitored)
+	if err != nil {
+		logger.Info("Context cancelled during transaction processing loop.", "error", err)
+		return err
+	}
+
+	return s.storeBlockMatches(ctx, blockNum, len(block.Transactions), matches, logger, started)
+}
 
-		storeTx := false
-		if _, ok := monitoredAddresses[tx.From.String()]; ok {
-			storeTx = true
+// processBlockStreaming is processBlock for a client that streams block transactions: each transaction is
+// matched as it is decoded and only the matches are kept, so a large block is never held in memory as a whole.
+func (s *ParserServiceImpl) processBlockStreaming(
+	ctx context.Context,
+	streamer client.BlockStreamer,
+	blockNum domain.BlockNumber,
+	monitored monitoredAddresses,
+	blockLogger logger.AppLogger,
+	started time.Time,
+) error {
+	_, participantsOnly := s.matcher.(participantMatcher)
+	var matches []blockMatch
+	txCount := 0
+	block, err := streamer.StreamBlockTransactions(ctx, blockNum, func(tx domain.Transaction) error {
+		txCount++
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-		if !tx.To.IsZero() {
-			if _, ok := monitoredAddresses[tx.To.String()]; ok {
-				storeTx = true
-			}
+		if participantsOnly && !(monitored.mayInvolve(tx) && tx.InvolvesAnyAddress(monitored.set)) {
+			return nil
+		}
+		if match, ok := s.matchTransaction(tx, monitored); ok {
+			matches = append(matches, match)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			blockLogger.Info("Context cancelled while streaming block transactions.", "error", err)
+			return err
 		}
+		blockLogger.Error("Failed to stream block transactions", "error", err)
+		return fmt.Errorf("failed to get block %d: %w", blockNum.Value(), err)
+	}
 
-		if storeTx {
-			if err := s.txRepo.Store(ctx, tx); err != nil {
-				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-					logger.Info("Context cancelled while storing transaction.", "error", err)
-					return err
-				}
-				logger.Error("Failed to store transaction", "txHash", tx.Hash.String(), "error", err)
-			} else {
-				foundTxs++
+	if block == nil {
+		blockLogger.Warn("Received nil block, skipping")
+		return nil
+	}
+
+	blockLogger = blockLogger.With("blockHash", block.Hash.String(), "txCount", txCount)
+	if err := s.checkBlockTimestamp(block, blockLogger); err != nil {
+		return err
+	}
+
+	return s.storeBlockMatches(ctx, blockNum, txCount, matches, blockLogger, started)
+}
+
+// storeBlockMatches stores the matched transactions of a block, reports the block as processed and records
+// how long it took since started. With a scan repository the block is also recorded as scanned.
+// Once storing starts, cancelling ctx no longer interrupts it: the stores are drained within
+// shutdownDrainTimeout. If they cannot complete, an error is returned so the block is not recorded as scanned.
+func (s *ParserServiceImpl) storeBlockMatches(
+	ctx context.Context,
+	blockNum domain.BlockNumber,
+	txCount int,
+	matches []blockMatch,
+	blockLogger logger.AppLogger,
+	started time.Time,
+) error {
+	if s.fetchReceipts && len(matches) > 0 {
+		if err := s.attachReceiptStatuses(ctx, matches); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				blockLogger.Info("Context cancelled while fetching transaction receipts.", "error", err)
+				return err
 			}
+			blockLogger.Warn("Failed to fetch transaction receipts, storing transactions without status", "error", err)
+		}
+	}
+
+	storeCtx, cancelStore := drainContext(ctx)
+	defer cancelStore()
+
+	store := s.storeMatches
+	if s.scanRepo != nil {
+		store = s.storeBlockResults
+	}
+	foundTxs, err := store(storeCtx, blockNum, matches, blockLogger)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			blockLogger.Warn("Could not finish storing block transactions before the drain timeout", "error", err)
 		}
+		return err
 	}
 	if foundTxs > 0 {
-		logger.Info("Stored transactions from block", "storedTxCount", foundTxs)
+		blockLogger.Info("Stored transactions from block", "storedTxCount", foundTxs)
+	}
+	duration := s.now().Sub(started)
+	blockLogger.Debug("Block processed", "duration", duration.String())
+	s.metrics.ObserveBlockProcessing(blockNum, txCount, duration)
+	s.events.Publish(BlockProcessedEvent{Block: blockNum, StoredTransactions: foundTxs})
+
+	if s.skipProcessed {
+		if err := s.stateRepo.MarkBlockProcessed(storeCtx, blockNum); err != nil {
+			blockLogger.Warn("Failed to record block as processed", "error", err)
+		}
 	}
 
 	return nil
 }
 
-// scanBlockRange performs a single scan iteration.
+// storeMatches stores the matched transactions of a block one by one and returns how many were stored.
+// A transaction that cannot be stored is recorded in the dead-letter store and the others are still stored.
+func (s *ParserServiceImpl) storeMatches(
+	ctx context.Context,
+	_ domain.BlockNumber,
+	matches []blockMatch,
+	blockLogger logger.AppLogger,
+) (int, error) {
+	stored := 0
+	for _, match := range matches {
+		if err := s.storeWithRetry(ctx, match); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return stored, err
+			}
+			blockLogger.Error("Failed to store transaction", "txHash", match.tx.Hash.String(), "error", err)
+			s.deadLetter(ctx, match, err)
+			continue
+		}
+		stored++
+	}
+	return stored, nil
+}
+
+// storeBlockResults stores the matched transactions of a block and records the block as scanned in a single
+// unit of work of the scan repository, retrying failed attempts with a growing delay, and returns how many
+// transactions were stored. The unit succeeds or fails as a whole, so instead of dead-lettering transactions
+// a failure leaves the block to be scanned again.
+func (s *ParserServiceImpl) storeBlockResults(
+	ctx context.Context,
+	blockNum domain.BlockNumber,
+	matches []blockMatch,
+	blockLogger logger.AppLogger,
+) (int, error) {
+	txs := make([]repository.BlockTransaction, len(matches))
+	indexed := make([][]domain.Address, len(matches))
+	for i, match := range matches {
+		txs[i], indexed[i] = newBlockTransaction(match)
+	}
+
+	err := s.retryStore(ctx, blockLogger, func() error {
+		return s.scanRepo.StoreBlockResults(ctx, blockNum, txs, blockNum)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to store results of block %d: %w", blockNum.Value(), err)
+	}
+	for i, blockTx := range txs {
+		s.events.Publish(TransactionStoredEvent{Transaction: blockTx.Transaction, Addresses: indexed[i]})
+	}
+	return len(txs), nil
+}
+
+// checkBlockTimestamp applies the configured timestamp sanity check to a fetched block.
+// In reject mode an implausible timestamp fails the block, so it is fetched again on the next poll;
+// in warn mode it is only logged.
+func (s *ParserServiceImpl) checkBlockTimestamp(block *domain.Block, blockLogger logger.AppLogger) error {
+	if s.timestampCheck != config.TimestampCheckWarn && s.timestampCheck != config.TimestampCheckReject {
+		return nil
+	}
+	err := block.CheckTimestamp(s.now(), s.maxTimestampDrift)
+	if err == nil {
+		return nil
+	}
+	if s.timestampCheck == config.TimestampCheckReject {
+		blockLogger.Warn("Rejecting block with implausible timestamp", "timestamp", block.Timestamp, "error", err)
+		return fmt.Errorf("block %d rejected: %w", block.Number.Value(), err)
+	}
+	blockLogger.Warn("Block has an implausible timestamp", "timestamp", block.Timestamp, "error", err)
+	return nil
+}
+
+// matchBlock returns the transactions of the block that concern monitored addresses.
+// With a matcher that only inspects senders and recipients, only the transactions the block reports as
+// involving a monitored address are matched. Transactions whose participants are not in the address Bloom
+// filter are dropped before that check, so irrelevant blocks cost little.
+func (s *ParserServiceImpl) matchBlock(
+	ctx context.Context,
+	block *domain.Block,
+	monitored monitoredAddresses,
+) ([]blockMatch, error) {
+	candidates := block.Transactions
+	if _, participantsOnly := s.matcher.(participantMatcher); participantsOnly {
+		candidates = monitored.prefilter(*block).TransactionsInvolving(monitored.set)
+	}
+
+	var matches []blockMatch
+	for _, tx := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if match, ok := s.matchTransaction(tx, monitored); ok {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// matchTransaction matches a transaction against the monitored addresses and their subscription directions.
+func (s *ParserServiceImpl) matchTransaction(tx domain.Transaction, monitored monitoredAddresses) (blockMatch, bool) {
+	matched, excluded := monitored.filterByDirection(tx, s.matcher.Match(tx, monitored.set))
+	if len(matched) == 0 {
+		return blockMatch{}, false
+	}
+	return blockMatch{tx: tx, addresses: matched, excluded: excluded}, true
+}
+
+// blockMatch is a transaction of the block being processed together with the monitored addresses it concerns.
+type blockMatch struct {
+	tx        domain.Transaction
+	addresses []domain.Address
+	excluded  bool
+}
+
+// attachReceiptStatuses fetches the receipts of the matched transactions in one batch and records their status.
+func (s *ParserServiceImpl) attachReceiptStatuses(ctx context.Context, matches []blockMatch) error {
+	hashes := make([]domain.TransactionHash, len(matches))
+	for i, match := range matches {
+		hashes[i] = match.tx.Hash
+	}
+
+	statuses, err := s.ethClient.GetTransactionStatuses(ctx, hashes)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction statuses: %w", err)
+	}
+	for i := range matches {
+		matches[i].tx.Status = statuses[matches[i].tx.Hash]
+	}
+	return nil
+}
+
+// storeWithRetry stores a matched transaction, retrying failed attempts with a growing delay.
+// Storing is idempotent, so a partially stored transaction is safely stored again.
+func (s *ParserServiceImpl) storeWithRetry(ctx context.Context, match blockMatch) error {
+	return s.retryStore(ctx, s.logger.With("txHash", match.tx.Hash.String()), func() error {
+		return s.storeMatchedTransaction(ctx, match)
+	})
+}
+
+// retryStore calls store until it succeeds, up to the configured number of attempts, waiting a growing delay
+// between them. Context errors are returned without retrying.
+func (s *ParserServiceImpl) retryStore(ctx context.Context, retryLogger logger.AppLogger, store func() error) error {
+	attempts := max(s.storeAttempts, 1)
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = store()
+		if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+		retryLogger.Warn("Failed to store, retrying", "attempt", attempt, "error", err)
+		if errWait := sleepCtx(ctx, s.storeRetryDelay*time.Duration(attempt)); errWait != nil {
+			return errWait
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
+
+// deadLetter records a transaction that could not be stored, so it is not silently lost.
+func (s *ParserServiceImpl) deadLetter(ctx context.Context, match blockMatch, cause error) {
+	if s.deadLetters == nil {
+		return
+	}
+	letter := repository.DeadLetter{
+		Transaction: match.tx,
+		Addresses:   match.addresses,
+		Attempts:    max(s.storeAttempts, 1),
+		Reason:      cause.Error(),
+		FailedAt:    s.now(),
+	}
+	if err := s.deadLetters.Add(ctx, letter); err != nil {
+		s.logger.Error("Failed to record transaction in dead-letter store",
+			"txHash", match.tx.Hash.String(), "error", err)
+		return
+	}
+	s.logger.Warn("Transaction recorded in dead-letter store", "txHash", match.tx.Hash.String())
+}
+
+// sleepCtx waits for the duration or until the context is done, whichever comes first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// storeMatchedTransaction stores a matched transaction as described by newBlockTransaction, then publishes
+// a TransactionStoredEvent for all the addresses it was stored for.
+func (s *ParserServiceImpl) storeMatchedTransaction(ctx context.Context, match blockMatch) error {
+	blockTx, indexed := newBlockTransaction(match)
+	if blockTx.IndexParticipants {
+		if err := s.txRepo.Store(ctx, blockTx.Transaction); err != nil {
+			return err
+		}
+	}
+	for _, address := range blockTx.Addresses {
+		if err := s.txRepo.StoreForAddress(ctx, address, blockTx.Transaction); err != nil {
+			return fmt.Errorf("failed to store transaction for address %s: %w", address.String(), err)
+		}
+	}
+	s.events.Publish(TransactionStoredEvent{Transaction: blockTx.Transaction, Addresses: indexed})
+	return nil
+}
+
+// newBlockTransaction describes how a matched transaction is stored and returns all the addresses it is stored
+// for: its sender and recipient, and additionally the matched addresses that are neither.
+// When a direction filter excluded the sender or recipient, the transaction is stored for the matched addresses only.
+func newBlockTransaction(match blockMatch) (blockTx repository.BlockTransaction, indexed []domain.Address) {
+	tx := match.tx
+	if match.excluded {
+		return repository.BlockTransaction{Transaction: tx, Addresses: match.addresses}, match.addresses
+	}
+
+	blockTx = repository.BlockTransaction{Transaction: tx, IndexParticipants: true}
+	indexed = []domain.Address{tx.From}
+	if !tx.IsContractCreation() && !tx.To.Equals(tx.From) {
+		indexed = append(indexed, tx.To)
+	}
+	for _, address := range match.addresses {
+		if tx.InvolvesAddress(address) {
+			continue
+		}
+		blockTx.Addresses = append(blockTx.Addresses, address)
+		indexed = append(indexed, address)
+	}
+	return blockTx, indexed
+}
+
+// isAlreadyProcessed reports whether the block can be skipped because it was fully processed before.
+// Lookup failures are logged and treated as not processed, so the block is scanned again.
+// Blocks up to reprocessThrough are always scanned again, as requested by a rewind.
+func (s *ParserServiceImpl) isAlreadyProcessed(ctx context.Context, blockNum domain.BlockNumber) bool {
+	if !s.skipProcessed || blockNum.Value() <= s.reprocessThrough {
+		return false
+	}
+	processed, err := s.stateRepo.IsBlockProcessed(ctx, blockNum)
+	if err != nil {
+		s.logger.Warn("Failed to check whether block was processed", "blockNumber", blockNum.Value(), "error", err)
+		return false
+	}
+	return processed
+}
+
+// minScanBudget is the smallest time a scan iteration is given, however short the polling interval.
+const minScanBudget = time.Duration(config.MinScanTimeoutSeconds) * time.Second
+
+// scanBudget returns how long a scan iteration may run: the configured scan timeout or, without one,
+// a second less than the polling interval, but never less than minScanBudget.
+func (s *ParserServiceImpl) scanBudget() time.Duration {
+	return max(s.requestedScanBudget(), minScanBudget)
+}
+
+// requestedScanBudget returns the scan budget implied by the configuration, before minScanBudget is applied.
+func (s *ParserServiceImpl) requestedScanBudget() time.Duration {
+	if s.scanTimeout > 0 {
+		return s.scanTimeout
+	}
+	return s.pollingInterval - time.Second
+}
+
+// warnOnShortScanBudget logs when the configured scan budget is raised to minScanBudget. Scans may then outlast
+// the polling interval, in which case the ticks that came due meanwhile are skipped.
+func (s *ParserServiceImpl) warnOnShortScanBudget() {
+	requested := s.requestedScanBudget()
+	if requested >= minScanBudget {
+		return
+	}
+	s.logger.Warn("Scan budget is too short to make progress, using the minimum instead",
+		"requestedScanBudget", requested.String(),
+		"scanBudget", minScanBudget.String(),
+		"pollingInterval", s.pollingInterval.String(),
+		"hint", "set app_service.scan_timeout_seconds or raise app_service.polling_interval_seconds")
+}
+
+// scanBlockRange performs a single scan iteration. It returns without scanning if another iteration is
+// still in progress.
 func (s *ParserServiceImpl) scanBlockRange(currentBlockFromState domain.BlockNumber) {
-	scanTimeout := s.pollingInterval - time.Second
-	if scanTimeout <= 0 {
-		scanTimeout = time.Millisecond * 500
+	if !s.scanMu.TryLock() {
+		s.logger.Warn("Previous scan is still in progress, skipping this scan iteration")
+		return
 	}
-	scanCtx, cancelScan := context.WithTimeout(s.pollCtx, scanTimeout)
+	defer s.scanMu.Unlock()
+
+	scanCtx, cancelScan := context.WithTimeout(s.pollCtx, s.scanBudget())
 	defer cancelScan()
 
 	logger := s.logger.With("method", "scanBlockRange")
@@ -157,27 +727,25 @@ func (s *ParserServiceImpl) scanBlockRange(currentBlockFromState domain.BlockNum
 	}
 
 	if !scanNeeded {
+		s.markFirstScanCompleted()
 		logger.Info("Scan not needed in this iteration.")
 		return
 	}
 
 	logger.Info("Scanning blocks", "from", start, "to", end)
 
-	monitoredAddressList, err := s.addressRepo.FindAll(scanCtx)
+	directions, err := s.addressRepo.FindAllWithDirection(scanCtx)
 	if err != nil {
 		if !(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
 			logger.Error("Failed to get monitored addresses", "error", err)
 		}
 		return
 	}
+	monitored := newMonitoredAddresses(directions)
 
-	monitoredAddressesMap := make(map[string]struct{}, len(monitoredAddressList))
-	for _, addr := range monitoredAddressList {
-		monitoredAddressesMap[addr.String()] = struct{}{}
-	}
-
-	if len(monitoredAddressesMap) == 0 {
-		logger.Info("No addresses are currently subscribed for monitoring. Skipping transaction processing until subscribed.")
+	if len(monitored.set) == 0 {
+		s.skippedScans.Add(1)
+		s.logEmptyAddressSet()
 	}
 
 	lastSuccessfullyProcessedBlock := currentBlockFromState.Value()
@@ -185,40 +753,67 @@ func (s *ParserServiceImpl) scanBlockRange(currentBlockFromState domain.BlockNum
 	for i := start; i <= end; i++ {
 		select {
 		case <-scanCtx.Done():
+			s.catchingUp = true
 			logger.Warn("Scan block range context done during block processing loop",
 				"lastProcessed", lastSuccessfullyProcessedBlock,
 				"error", scanCtx.Err())
-			finalBlockNum, _ := domain.NewBlockNumber(lastSuccessfullyProcessedBlock)
-			if updateErr := s.stateRepo.SetCurrentBlock(s.pollCtx, finalBlockNum); updateErr != nil {
-				logger.Error("Failed to update current block state on scan interruption",
-					"blockNumber", lastSuccessfullyProcessedBlock,
-					"error", updateErr)
-			}
+			s.saveScanProgress(logger, lastSuccessfullyProcessedBlock,
+				"Failed to update current block state on scan interruption")
 			return
 		default:
 			blockNumToProcess, _ := domain.NewBlockNumber(i)
-			if err := s.processBlock(scanCtx, blockNumToProcess, monitoredAddressesMap); err != nil {
+			if s.isAlreadyProcessed(scanCtx, blockNumToProcess) {
+				logger.Debug("Skipping already processed block", "blockNumber", i)
+				if i > lastSuccessfullyProcessedBlock {
+					lastSuccessfullyProcessedBlock = i
+				}
+				continue
+			}
+			if err := s.processBlock(scanCtx, blockNumToProcess, monitored); err != nil {
 				if !(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
 					logger.Error("Failed to process block, stopping current scan iteration", "blockNumber", i, "error", err)
 				}
-				finalBlockNum, _ := domain.NewBlockNumber(lastSuccessfullyProcessedBlock)
-				if updateErr := s.stateRepo.SetCurrentBlock(s.pollCtx, finalBlockNum); updateErr != nil {
-					logger.Error("Failed to update current block state after processing error",
-						"blockNumber", lastSuccessfullyProcessedBlock,
-						"error", updateErr)
-				}
+				s.saveScanProgress(logger, lastSuccessfullyProcessedBlock,
+					"Failed to update current block state after processing error")
 				return
 			}
-			lastSuccessfullyProcessedBlock = i
+			if i > lastSuccessfullyProcessedBlock {
+				lastSuccessfullyProcessedBlock = i
+			}
 		}
 	}
 
-	finalBlockNum, _ := domain.NewBlockNumber(lastSuccessfullyProcessedBlock)
-	if err := s.stateRepo.SetCurrentBlock(s.pollCtx, finalBlockNum); err != nil {
-		logger.Error("Failed to update current block state after scan range completion",
-			"blockNumber", lastSuccessfullyProcessedBlock,
-			"error", err)
-	} else {
+	if s.saveScanProgress(logger, lastSuccessfullyProcessedBlock,
+		"Failed to update current block state after scan range completion") {
+		s.markFirstScanCompleted()
 		logger.Info("Successfully scanned and updated current block", "processedUpToBlock", lastSuccessfullyProcessedBlock)
 	}
 }
+
+// saveScanProgress records block as the last scanned block, logging failureMsg if that fails.
+// The state is written even after shutdown cancelled the polling context, so the blocks completed before the
+// cancellation are not scanned again and an interrupted block is reprocessed from its start.
+func (s *ParserServiceImpl) saveScanProgress(scanLogger logger.AppLogger, block int64, failureMsg string) bool {
+	blockNum, _ := domain.NewBlockNumber(block)
+	ctx, cancel := drainContext(s.pollCtx)
+	defer cancel()
+	if err := s.stateRepo.SetCurrentBlock(ctx, blockNum); err != nil {
+		scanLogger.Error(failureMsg, "blockNumber", block, "error", err)
+		return false
+	}
+	s.setLastKnownBlock(blockNum)
+	return true
+}
+
+// FirstScanCompleted reports whether a scan iteration has finished successfully since the parser was started,
+// either by processing its whole block range or by finding the parser already at the network head.
+func (s *ParserServiceImpl) FirstScanCompleted() bool {
+	return s.firstScanDone.Load()
+}
+
+// markFirstScanCompleted records that a scan iteration finished successfully, logging the first time it happens.
+func (s *ParserServiceImpl) markFirstScanCompleted() {
+	if s.firstScanDone.CompareAndSwap(false, true) {
+		s.logger.Info("First scan completed, stored data is up to date")
+	}
+}
@@ -0,0 +1,1057 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/address"
+	"trust_wallet_homework/internal/adapters/storage/memory/parser_state"
+	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/application/mocks/mock_client"
+	"trust_wallet_homework/internal/core/domain"
+	applogger "trust_wallet_homework/internal/logger"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetScanRange_CapsToMaxBlocksPerScan(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		MaxBlocksPerScan:       10,
+	})
+	ctx := context.Background()
+
+	latest, _ := domain.NewBlockNumber(1000)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+
+	current, _ := domain.NewBlockNumber(100)
+	start, end, scanNeeded, err := service.getScanRange(ctx, current)
+	require.NoError(t, err)
+	assert.True(t, scanNeeded)
+	assert.Equal(t, int64(101), start)
+	assert.Equal(t, int64(110), end)
+}
+
+func TestGetScanRange_NoCapWhenDisabled(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+	})
+	ctx := context.Background()
+
+	latest, _ := domain.NewBlockNumber(1000)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+
+	current, _ := domain.NewBlockNumber(100)
+	start, end, scanNeeded, err := service.getScanRange(ctx, current)
+	require.NoError(t, err)
+	assert.True(t, scanNeeded)
+	assert.Equal(t, int64(101), start)
+	assert.Equal(t, int64(1000), end)
+}
+
+func TestGetScanRange_UsesConfiguredHeadBlockTag(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		HeadBlockTag:           "finalized",
+	})
+	ctx := context.Background()
+
+	finalized, _ := domain.NewBlockNumber(150)
+	mockEthClient.On("GetBlockNumberByTag", mock.Anything, "finalized").Return(finalized, nil)
+
+	current, _ := domain.NewBlockNumber(100)
+	start, end, scanNeeded, err := service.getScanRange(ctx, current)
+	require.NoError(t, err)
+	assert.True(t, scanNeeded)
+	assert.Equal(t, int64(101), start)
+	assert.Equal(t, int64(150), end)
+	mockEthClient.AssertNotCalled(t, "GetLatestBlockNumber", mock.Anything)
+}
+
+func TestGetScanRange_RescanTailBlocks(t *testing.T) {
+	tests := []struct {
+		name      string
+		tail      int64
+		current   int64
+		latest    int64
+		wantStart int64
+		wantEnd   int64
+	}{
+		{name: "Start pulled back by tail", tail: 3, current: 100, latest: 105, wantStart: 98, wantEnd: 105},
+		{name: "Tail re-scanned when caught up", tail: 3, current: 105, latest: 105, wantStart: 103, wantEnd: 105},
+		{name: "Start never negative", tail: 5, current: 1, latest: 3, wantStart: 0, wantEnd: 3},
+		{name: "Tail of one re-scans current block", tail: 1, current: 100, latest: 100, wantStart: 100, wantEnd: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+				PollingIntervalSeconds: 5,
+				RescanTailBlocks:       tt.tail,
+			})
+
+			latest, _ := domain.NewBlockNumber(tt.latest)
+			mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+
+			current, _ := domain.NewBlockNumber(tt.current)
+			start, end, scanNeeded, err := service.getScanRange(context.Background(), current)
+			require.NoError(t, err)
+			assert.True(t, scanNeeded)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestGetScanRange_RescanTailDoesNotConsumeBlockCap(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		MaxBlocksPerScan:       2,
+		RescanTailBlocks:       5,
+	})
+
+	latest, _ := domain.NewBlockNumber(200)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+
+	current, _ := domain.NewBlockNumber(100)
+	start, end, scanNeeded, err := service.getScanRange(context.Background(), current)
+	require.NoError(t, err)
+	assert.True(t, scanNeeded)
+	assert.Equal(t, int64(96), start)
+	assert.Equal(t, int64(102), end)
+}
+
+func TestScanBlockRange_ProgressAccumulatesAcrossIterations(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		MaxBlocksPerScan:       3,
+	})
+	ctx := context.Background()
+	service.pollCtx = ctx
+
+	latest, _ := domain.NewBlockNumber(107)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+			return &block, nil
+		})
+
+	start, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, start))
+
+	for _, want := range []int64{103, 106, 107, 107} {
+		current, err := service.stateRepo.GetCurrentBlock(ctx)
+		require.NoError(t, err)
+		service.scanBlockRange(current)
+
+		got, err := service.stateRepo.GetCurrentBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, got.Value())
+	}
+	mockEthClient.AssertNumberOfCalls(t, "GetBlockWithTransactions", 7)
+}
+
+func TestStart_BackfillScansRangeAndSignalsDone(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		MaxBlocksPerScan:       2,
+		Mode:                   config.AppServiceModeBackfill,
+		BackfillFromBlock:      5,
+		BackfillToBlock:        7,
+	})
+	service.pollingInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	to, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, service.addressRepo.Add(ctx, to, domain.SubscriptionDirectionBoth))
+	value, _ := domain.NewWeiValue("0x1")
+
+	latest, _ := domain.NewBlockNumber(20)
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(1), nil)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			assert.GreaterOrEqual(t, num.Value(), int64(5))
+			assert.LessOrEqual(t, num.Value(), int64(7))
+			hash, _ := domain.NewTransactionHash(fmt.Sprintf("0x%064x", num.Value()))
+			tx := domain.NewTransaction(hash, from, to, value, num, 1000)
+			block := domain.NewBlock(num, domain.BlockHash{}, 1000, []domain.Transaction{tx})
+			return &block, nil
+		})
+
+	require.NoError(t, service.Start(ctx))
+
+	select {
+	case <-service.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("backfill did not signal completion")
+	}
+	select {
+	case <-service.stopChan:
+	case <-time.After(time.Second):
+		t.Fatal("polling loop kept running after the backfill completed")
+	}
+
+	current, err := service.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), current)
+	mockEthClient.AssertNumberOfCalls(t, "GetBlockWithTransactions", 3)
+	stored, err := service.txRepo.FindByAddress(ctx, to)
+	require.NoError(t, err)
+	assert.Len(t, stored, 3)
+}
+
+func TestDone_NotClosedInFollowMode(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	select {
+	case <-service.Done():
+		t.Fatal("Done closed without a backfill")
+	default:
+	}
+}
+
+func TestScanBlockRange_ThrottlesEmptyAddressSetLog(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	var logs bytes.Buffer
+	service.logger = applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(&logs, nil)))
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return clock }
+	ctx := context.Background()
+	service.pollCtx = ctx
+
+	latest := int64(100)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(
+		func(context.Context) (domain.BlockNumber, error) {
+			latest++
+			return domain.NewBlockNumber(latest)
+		})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+			return &block, nil
+		})
+
+	start, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, start))
+	scan := func() {
+		current, err := service.stateRepo.GetCurrentBlock(ctx)
+		require.NoError(t, err)
+		service.scanBlockRange(current)
+	}
+	countEmptySetLogs := func() int {
+		return strings.Count(logs.String(), "No addresses are currently subscribed")
+	}
+
+	for i := 0; i < 3; i++ {
+		scan()
+		clock = clock.Add(10 * time.Second)
+	}
+	assert.Equal(t, 1, countEmptySetLogs(), "empty-set message should be logged once per window")
+
+	clock = clock.Add(emptySetLogInterval)
+	scan()
+	assert.Equal(t, 2, countEmptySetLogs(), "empty-set message should be logged again after the window")
+
+	assert.Equal(t, int64(4), service.skippedScans.Load())
+}
+
+func TestScanBlockRange_CountsOnlyScansWithoutSubscriptionsAsSkipped(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	ctx := context.Background()
+	service.pollCtx = ctx
+
+	addr, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, service.addressRepo.Add(ctx, addr, domain.SubscriptionDirectionBoth))
+
+	latest, _ := domain.NewBlockNumber(101)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, latest).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+			return &block, nil
+		})
+
+	start, _ := domain.NewBlockNumber(100)
+	service.scanBlockRange(start)
+	service.scanBlockRange(latest)
+
+	assert.Zero(t, service.skippedScans.Load(), "scans with subscribers are not skipped, even without new blocks")
+}
+
+func TestScanBlockRange_SkipsProcessedBlocks(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		RescanTailBlocks:       3,
+		SkipProcessedBlocks:    true,
+	})
+	ctx := context.Background()
+	service.pollCtx = ctx
+
+	for _, n := range []int64{98, 99, 100} {
+		bn, _ := domain.NewBlockNumber(n)
+		require.NoError(t, service.stateRepo.MarkBlockProcessed(ctx, bn))
+	}
+
+	latest, _ := domain.NewBlockNumber(101)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, latest).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+			return &block, nil
+		}).Once()
+
+	current, _ := domain.NewBlockNumber(100)
+	service.scanBlockRange(current)
+	service.scanBlockRange(latest)
+
+	got, err := service.stateRepo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(101), got.Value())
+	processed, err := service.stateRepo.IsBlockProcessed(ctx, latest)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	mockEthClient.AssertNumberOfCalls(t, "GetBlockWithTransactions", 1)
+}
+
+func TestScanBlockRange_RescansProcessedBlocksWhenSkipDisabled(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		RescanTailBlocks:       2,
+	})
+	ctx := context.Background()
+	service.pollCtx = ctx
+
+	processedBlock, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.MarkBlockProcessed(ctx, processedBlock))
+
+	latest, _ := domain.NewBlockNumber(101)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+			return &block, nil
+		})
+
+	service.scanBlockRange(processedBlock)
+
+	mockEthClient.AssertNumberOfCalls(t, "GetBlockWithTransactions", 3)
+}
+
+func TestScanBlockRange_DetectsStateGap(t *testing.T) {
+	tests := []struct {
+		name          string
+		backfill      bool
+		wantFetches   int
+		wantFirstScan int64
+	}{
+		{name: "Gap logged only", backfill: false, wantFetches: 1, wantFirstScan: 106},
+		{name: "Gap backfilled", backfill: true, wantFetches: 6, wantFirstScan: 101},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+				PollingIntervalSeconds: 5,
+				BackfillGaps:           tt.backfill,
+			})
+			var logs bytes.Buffer
+			service.logger = applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(&logs, nil)))
+			ctx := context.Background()
+			service.pollCtx = ctx
+
+			lastScanned, _ := domain.NewBlockNumber(100)
+			service.setLastKnownBlock(lastScanned)
+			jumped, _ := domain.NewBlockNumber(105)
+			require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, jumped))
+
+			latest, _ := domain.NewBlockNumber(106)
+			mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+			var fetched []int64
+			mockEthClient.On("GetBlockWithTransactions", mock.Anything, mock.Anything).Return(
+				func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+					fetched = append(fetched, num.Value())
+					block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+					return &block, nil
+				})
+
+			service.scanBlockRange(jumped)
+
+			assert.Contains(t, logs.String(), "Detected gap in scanned block sequence")
+			assert.Contains(t, logs.String(), "gapStart=101")
+			assert.Contains(t, logs.String(), "gapEnd=105")
+			require.Len(t, fetched, tt.wantFetches)
+			assert.Equal(t, tt.wantFirstScan, fetched[0])
+
+			got, err := service.stateRepo.GetCurrentBlock(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, int64(106), got.Value())
+
+			logs.Reset()
+			service.scanBlockRange(got)
+			assert.NotContains(t, logs.String(), "Detected gap", "gap must not be reported once the scanner caught up")
+		})
+	}
+}
+
+func TestProcessBlock_InputStrategyStoresForInputRecipient(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		MatchStrategy:          MatchStrategyInput,
+	})
+	ctx := context.Background()
+
+	from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	token, _ := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	recipient, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	value, _ := domain.NewWeiValue("0x0")
+	blockNum, _ := domain.NewBlockNumber(10)
+	tx := domain.NewTransaction(hash, from, token, value, blockNum, 1000)
+	tx.Input = "0xa9059cbb" +
+		"000000000000000000000000bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb" +
+		"00000000000000000000000000000000000000000000000000000000000003e8"
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{tx})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+
+	monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+		recipient: domain.SubscriptionDirectionBoth,
+	})
+	require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+
+	stored, err := service.txRepo.FindByAddress(ctx, recipient)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{tx}, stored)
+}
+
+// blockObservation is one call to Metrics.ObserveBlockProcessing.
+type blockObservation struct {
+	block    domain.BlockNumber
+	txCount  int
+	duration time.Duration
+}
+
+// metricsRecorder is a Metrics that records its observations.
+type metricsRecorder struct {
+	observations []blockObservation
+}
+
+func (m *metricsRecorder) ObserveBlockProcessing(block domain.BlockNumber, txCount int, duration time.Duration) {
+	m.observations = append(m.observations, blockObservation{block: block, txCount: txCount, duration: duration})
+}
+
+func TestProcessBlock_ObservesProcessingTime(t *testing.T) {
+	metrics := &metricsRecorder{}
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5},
+		WithMetrics(metrics))
+	ctx := context.Background()
+	clock := time.Unix(1700000000, 0)
+	service.now = func() time.Time {
+		clock = clock.Add(250 * time.Millisecond)
+		return clock
+	}
+
+	from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	to, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	firstHash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	secondHash, _ := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{
+		domain.NewTransaction(firstHash, from, to, value, blockNum, 1000),
+		domain.NewTransaction(secondHash, to, from, value, blockNum, 1000),
+	})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+
+	monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+		to: domain.SubscriptionDirectionBoth,
+	})
+	require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+
+	assert.Equal(t, []blockObservation{{block: blockNum, txCount: 2, duration: 250 * time.Millisecond}}, metrics.observations)
+}
+
+func TestProcessBlock_NotifiesTransactionWatchers(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	to, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	tx := domain.NewTransaction(hash, from, to, value, blockNum, 1000)
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{tx})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+
+	require.NoError(t, service.addressRepo.Add(ctx, to, domain.SubscriptionDirectionBoth))
+	watched, err := service.WatchTransactions(ctx, to.String())
+	require.NoError(t, err)
+
+	monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+		to: domain.SubscriptionDirectionBoth,
+	})
+	require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+
+	select {
+	case got := <-watched:
+		assert.Equal(t, mapDomainToAPITransaction(tx, to), got)
+		assert.Equal(t, ethparser.DirectionIn, got.Direction)
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not receive the stored transaction")
+	}
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, open := <-watched
+		return !open
+	}, time.Second, 10*time.Millisecond, "watcher channel should be closed after context cancellation")
+}
+
+func TestProcessBlock_DirectionFilter(t *testing.T) {
+	wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	inHash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	outHash, _ := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	inbound := domain.NewTransaction(inHash, other, wallet, value, blockNum, 1000)
+	outbound := domain.NewTransaction(outHash, wallet, other, value, blockNum, 1000)
+
+	tests := []struct {
+		name      string
+		direction domain.SubscriptionDirection
+		want      []domain.Transaction
+	}{
+		{name: "Both", direction: domain.SubscriptionDirectionBoth, want: []domain.Transaction{inbound, outbound}},
+		{name: "In", direction: domain.SubscriptionDirectionIn, want: []domain.Transaction{inbound}},
+		{name: "Out", direction: domain.SubscriptionDirectionOut, want: []domain.Transaction{outbound}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+			ctx := context.Background()
+			block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{inbound, outbound})
+			mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+
+			monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{wallet: tt.direction})
+			require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+
+			stored, err := service.txRepo.FindByAddress(ctx, wallet)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, stored)
+
+			count, err := service.txRepo.CountAll(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.want), count)
+		})
+	}
+}
+
+func TestProcessBlock_DirectionFilterKeepsCounterparty(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	ctx := context.Background()
+
+	sender, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	recipient, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	tx := domain.NewTransaction(hash, sender, recipient, value, blockNum, 1000)
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{tx})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+
+	monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+		sender:    domain.SubscriptionDirectionIn,
+		recipient: domain.SubscriptionDirectionBoth,
+	})
+	require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+
+	senderTxs, err := service.txRepo.FindByAddress(ctx, sender)
+	require.NoError(t, err)
+	assert.Empty(t, senderTxs, "outbound transaction must not be stored for an inbound-only subscription")
+
+	recipientTxs, err := service.txRepo.FindByAddress(ctx, recipient)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{tx}, recipientTxs)
+}
+
+func TestProcessBlock_FetchReceiptsRecordsStatus(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		FetchReceipts:          true,
+	})
+	ctx := context.Background()
+
+	wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	stranger, _ := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	successHash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	revertedHash, _ := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	unrelatedHash, _ := domain.NewTransactionHash("0x3333333333333333333333333333333333333333333333333333333333333333")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	succeeded := domain.NewTransaction(successHash, other, wallet, value, blockNum, 1000)
+	reverted := domain.NewTransaction(revertedHash, wallet, other, value, blockNum, 1000)
+	unrelated := domain.NewTransaction(unrelatedHash, stranger, other, value, blockNum, 1000)
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{succeeded, unrelated, reverted})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+	mockEthClient.On("GetTransactionStatuses", mock.Anything, []domain.TransactionHash{successHash, revertedHash}).
+		Return(map[domain.TransactionHash]domain.TransactionStatus{
+			successHash:  domain.TransactionStatusSuccess,
+			revertedHash: domain.TransactionStatusFailed,
+		}, nil).Once()
+
+	monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+		wallet: domain.SubscriptionDirectionBoth,
+	})
+	require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+
+	stored, err := service.txRepo.FindByAddress(ctx, wallet)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, domain.TransactionStatusSuccess, stored[0].Status)
+	assert.Equal(t, domain.TransactionStatusFailed, stored[1].Status)
+
+	apiSucceeded := mapDomainToAPITransaction(stored[0], wallet)
+	require.NotNil(t, apiSucceeded.Status)
+	assert.Equal(t, ethparser.StatusSuccess, *apiSucceeded.Status)
+	apiReverted := mapDomainToAPITransaction(stored[1], wallet)
+	require.NotNil(t, apiReverted.Status)
+	assert.Equal(t, ethparser.StatusFailed, *apiReverted.Status)
+}
+
+func TestProcessBlock_ReceiptFailureStoresWithoutStatus(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		FetchReceipts:          true,
+	})
+	ctx := context.Background()
+
+	wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	tx := domain.NewTransaction(hash, other, wallet, value, blockNum, 1000)
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{tx})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+	mockEthClient.On("GetTransactionStatuses", mock.Anything, mock.Anything).Return(nil, errors.New("node down"))
+
+	monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+		wallet: domain.SubscriptionDirectionBoth,
+	})
+	require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+
+	stored, err := service.txRepo.FindByAddress(ctx, wallet)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{tx}, stored)
+	assert.Nil(t, mapDomainToAPITransaction(stored[0], wallet).Status)
+}
+
+func TestProcessBlock_TimestampCheck(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name       string
+		mode       string
+		timestamp  uint64
+		wantErr    error
+		wantStored bool
+	}{
+		{name: "Normal timestamp", mode: config.TimestampCheckReject, timestamp: 1_699_999_988, wantStored: true},
+		{name: "Zero timestamp rejected", mode: config.TimestampCheckReject, timestamp: 0, wantErr: domain.ErrZeroBlockTimestamp},
+		{name: "Future timestamp rejected", mode: config.TimestampCheckReject, timestamp: 1_700_003_600, wantErr: domain.ErrFutureBlockTimestamp},
+		{name: "Zero timestamp only warned", mode: config.TimestampCheckWarn, timestamp: 0, wantStored: true},
+		{name: "Future timestamp only warned", mode: config.TimestampCheckWarn, timestamp: 1_700_003_600, wantStored: true},
+		{name: "Check disabled", mode: config.TimestampCheckOff, timestamp: 0, wantStored: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+				PollingIntervalSeconds: 5,
+				TimestampCheck:         tt.mode,
+				MaxTimestampDriftSecs:  900,
+			})
+			service.now = func() time.Time { return now }
+			ctx := context.Background()
+
+			wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+			other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+			hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+			value, _ := domain.NewWeiValue("0x1")
+			blockNum, _ := domain.NewBlockNumber(10)
+			tx := domain.NewTransaction(hash, other, wallet, value, blockNum, tt.timestamp)
+			block := domain.NewBlock(blockNum, domain.BlockHash{}, tt.timestamp, []domain.Transaction{tx})
+			mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+
+			monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+				wallet: domain.SubscriptionDirectionBoth,
+			})
+			err := service.processBlock(ctx, blockNum, monitored)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			stored, err := service.txRepo.FindByAddress(ctx, wallet)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStored, len(stored) == 1)
+		})
+	}
+}
+
+// newScannerTestService builds a service backed by in-memory repositories and a mocked Ethereum client.
+func newScannerTestService(
+	t *testing.T,
+	cfg config.ApplicationServiceConfig,
+	opts ...ServiceOption,
+) (*ParserServiceImpl, *mock_client.EthereumClient) {
+	t.Helper()
+	mockEthClient := mock_client.NewEthereumClient(t)
+	discardLogger := applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	service, err := NewParserService(
+		parser_state.NewInMemoryParserStateRepo(),
+		address.NewInMemoryAddressRepo(),
+		transaction.NewInMemoryTransactionRepo(),
+		mockEthClient,
+		discardLogger,
+		cfg,
+		opts...,
+	)
+	require.NoError(t, err)
+	return service, mockEthClient
+}
+
+func TestScanBlockRange_MarksFirstScanCompleted(t *testing.T) {
+	tests := []struct {
+		name      string
+		processOK bool
+		wantDone  bool
+	}{
+		{name: "Successful scan", processOK: true, wantDone: true},
+		{name: "Failed scan", processOK: false, wantDone: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+			service.pollCtx = context.Background()
+
+			latest, _ := domain.NewBlockNumber(101)
+			mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+			mockEthClient.On("GetBlockWithTransactions", mock.Anything, latest).Return(
+				func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+					if !tt.processOK {
+						return nil, errors.New("node error")
+					}
+					block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+					return &block, nil
+				})
+
+			assert.False(t, service.FirstScanCompleted())
+			start, _ := domain.NewBlockNumber(100)
+			service.scanBlockRange(start)
+			assert.Equal(t, tt.wantDone, service.FirstScanCompleted())
+		})
+	}
+}
+
+func TestScanBlockRange_FirstScanCompletedWhenAlreadyAtHead(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	service.pollCtx = context.Background()
+
+	head, _ := domain.NewBlockNumber(100)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(head, nil)
+
+	service.scanBlockRange(head)
+	assert.True(t, service.FirstScanCompleted())
+}
+
+// streamingClient is an EthereumClient that streams the transactions of a fixed block.
+type streamingClient struct {
+	*mock_client.EthereumClient
+	block domain.Block
+}
+
+func (c *streamingClient) StreamBlockTransactions(
+	_ context.Context,
+	_ domain.BlockNumber,
+	fn func(domain.Transaction) error,
+) (*domain.Block, error) {
+	for _, tx := range c.block.Transactions {
+		if err := fn(tx); err != nil {
+			return nil, err
+		}
+	}
+	header := c.block
+	header.Transactions = nil
+	return &header, nil
+}
+
+func TestProcessBlock_StreamingMatchesFullBlock(t *testing.T) {
+	monitoredAddr, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	var txs []domain.Transaction
+	for i := 0; i < 6; i++ {
+		hash, _ := domain.NewTransactionHash(fmt.Sprintf("0x%064x", i+1))
+		from, to := other, other
+		switch i % 3 {
+		case 0:
+			from = monitoredAddr
+		case 1:
+			to = monitoredAddr
+		}
+		tx := domain.NewTransaction(hash, from, to, value, blockNum, 1000)
+		tx.TransactionIndex = uint64(i)
+		txs = append(txs, tx)
+	}
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, txs)
+	monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+		monitoredAddr: domain.SubscriptionDirectionBoth,
+	})
+
+	storedWith := func(t *testing.T, stream bool) []domain.Transaction {
+		service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+			PollingIntervalSeconds: 5,
+			StreamBlocks:           stream,
+		})
+		if stream {
+			service.ethClient = &streamingClient{EthereumClient: mockEthClient, block: block}
+		} else {
+			mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+		}
+		require.NoError(t, service.processBlock(context.Background(), blockNum, monitored))
+
+		stored, err := service.txRepo.FindByAddress(context.Background(), monitoredAddr)
+		require.NoError(t, err)
+		return stored
+	}
+
+	full := storedWith(t, false)
+	require.Len(t, full, 4)
+	assert.Equal(t, full, storedWith(t, true))
+}
+
+func TestScanBlockRange_ShutdownDuringBlockKeepsStateAtPriorBlock(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	pollCtx, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+	service.pollCtx = pollCtx
+	ctx := context.Background()
+
+	latest, _ := domain.NewBlockNumber(103)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			if num.Value() == 102 {
+				shutdown()
+				return nil, context.Canceled
+			}
+			block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+			return &block, nil
+		})
+
+	start, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, start))
+	service.scanBlockRange(start)
+
+	got, err := service.stateRepo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(101), got.Value(), "the interrupted block must be scanned again")
+	assert.False(t, service.FirstScanCompleted())
+}
+
+func TestScanBlockRange_ShutdownWhileStoringDrainsBlock(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		FetchReceipts:          true,
+	})
+	pollCtx, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+	service.pollCtx = pollCtx
+	ctx := context.Background()
+
+	wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(101)
+	var txs []domain.Transaction
+	for i := 1; i <= 3; i++ {
+		hash, _ := domain.NewTransactionHash(fmt.Sprintf("0x%064x", i))
+		txs = append(txs, domain.NewTransaction(hash, wallet, other, value, blockNum, 1000))
+	}
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, txs)
+	require.NoError(t, service.addressRepo.Add(ctx, wallet, domain.SubscriptionDirectionBoth))
+
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(blockNum, nil)
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+	mockEthClient.On("GetTransactionStatuses", mock.Anything, mock.Anything).Return(
+		func(context.Context, []domain.TransactionHash) (map[domain.TransactionHash]domain.TransactionStatus, error) {
+			shutdown()
+			return nil, nil
+		})
+
+	start, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, start))
+	service.scanBlockRange(start)
+
+	stored, err := service.txRepo.FindByAddress(ctx, wallet)
+	require.NoError(t, err)
+	assert.Len(t, stored, 3, "stores started before the shutdown are completed")
+	got, err := service.stateRepo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(101), got.Value())
+}
+
+func TestScanBlockRange_SkipsWhileScanInProgress(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	service.pollCtx = context.Background()
+
+	current, _ := domain.NewBlockNumber(100)
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(
+		func(context.Context) (domain.BlockNumber, error) {
+			close(entered)
+			<-release
+			return current, nil
+		}).Once()
+
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		service.scanBlockRange(current)
+	}()
+	<-entered
+
+	service.scanBlockRange(current)
+	mockEthClient.AssertNumberOfCalls(t, "GetLatestBlockNumber", 1)
+
+	close(release)
+	<-firstDone
+}
+
+func TestScanBlockRange_ScanTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		pollSeconds int
+		scanSeconds int
+		want        time.Duration
+	}{
+		{name: "Derived from a short polling interval", pollSeconds: 1, want: minScanBudget},
+		{name: "Derived at the minimum", pollSeconds: 3, want: minScanBudget},
+		{name: "Derived from the polling interval", pollSeconds: 10, want: 9 * time.Second},
+		{name: "Configured, longer than the polling interval", pollSeconds: 1, scanSeconds: 5, want: 5 * time.Second},
+		{name: "Configured, shorter than the polling interval", pollSeconds: 10, scanSeconds: 3, want: 3 * time.Second},
+		{name: "Configured below the minimum", pollSeconds: 10, scanSeconds: 1, want: minScanBudget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+				PollingIntervalSeconds: tt.pollSeconds,
+				ScanTimeoutSeconds:     tt.scanSeconds,
+			})
+			service.pollCtx = context.Background()
+
+			current, _ := domain.NewBlockNumber(100)
+			var budget time.Duration
+			started := time.Now()
+			mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(
+				func(ctx context.Context) (domain.BlockNumber, error) {
+					deadline, ok := ctx.Deadline()
+					require.True(t, ok)
+					budget = deadline.Sub(started)
+					return current, nil
+				})
+
+			service.scanBlockRange(current)
+			assert.InDelta(t, tt.want, budget, float64(100*time.Millisecond))
+		})
+	}
+}
+
+func TestWarnOnShortScanBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		pollSeconds int
+		scanSeconds int
+		wantWarning bool
+	}{
+		{name: "Short polling interval", pollSeconds: 1, wantWarning: true},
+		{name: "Configured below the minimum", pollSeconds: 10, scanSeconds: 1, wantWarning: true},
+		{name: "Derived budget at the minimum", pollSeconds: 3},
+		{name: "Configured budget", pollSeconds: 1, scanSeconds: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newScannerTestService(t, config.ApplicationServiceConfig{
+				PollingIntervalSeconds: tt.pollSeconds,
+				ScanTimeoutSeconds:     tt.scanSeconds,
+			})
+			var logs bytes.Buffer
+			service.logger = applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(&logs, nil)))
+
+			service.warnOnShortScanBudget()
+			if tt.wantWarning {
+				assert.Contains(t, logs.String(), "Scan budget is too short")
+				assert.Contains(t, logs.String(), "scanBudget="+minScanBudget.String())
+			} else {
+				assert.Empty(t, logs.String())
+			}
+		})
+	}
+}
+
+func TestSkipMissedTick(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	tests := []struct {
+		name         string
+		scanDuration time.Duration
+		wantTick     bool
+	}{
+		{name: "Scan within the interval keeps the tick", scanDuration: interval / 2, wantTick: true},
+		{name: "Scan longer than the interval skips the tick", scanDuration: time.Minute, wantTick: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+			started := time.Now()
+			service.now = func() time.Time { return started.Add(tt.scanDuration) }
+
+			timer := time.NewTimer(interval)
+			defer timer.Stop()
+			time.Sleep(2 * interval)
+			service.skipMissedTick(timer, interval, started)
+
+			select {
+			case <-timer.C:
+				assert.True(t, tt.wantTick, "the missed tick should have been skipped")
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, tt.wantTick, "the due tick should still fire")
+			}
+		})
+	}
+}
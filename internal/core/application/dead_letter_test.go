@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/address"
+	"trust_wallet_homework/internal/adapters/storage/memory/dead_letter"
+	"trust_wallet_homework/internal/adapters/storage/memory/parser_state"
+	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/application/mocks/mock_client"
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyTransactionRepo fails the first failures calls to Store and delegates afterwards.
+type flakyTransactionRepo struct {
+	repository.TransactionRepository
+	failures int64
+	calls    atomic.Int64
+}
+
+func (r *flakyTransactionRepo) Store(ctx context.Context, tx domain.Transaction) error {
+	if r.calls.Add(1) <= r.failures {
+		return errors.New("storage unavailable")
+	}
+	return r.TransactionRepository.Store(ctx, tx)
+}
+
+func TestProcessBlock_StoreRetriesAndDeadLetters(t *testing.T) {
+	tests := []struct {
+		name           string
+		failures       int64
+		attempts       int
+		wantStored     bool
+		wantStoreCalls int64
+	}{
+		{name: "Stored on first attempt", failures: 0, attempts: 3, wantStored: true, wantStoreCalls: 1},
+		{name: "Stored after retries", failures: 2, attempts: 3, wantStored: true, wantStoreCalls: 3},
+		{name: "Dead-lettered when retries are exhausted", failures: 5, attempts: 3, wantStored: false, wantStoreCalls: 3},
+		{name: "Zero attempts still tries once", failures: 5, attempts: 0, wantStored: false, wantStoreCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEthClient := mock_client.NewEthereumClient(t)
+			txRepo := &flakyTransactionRepo{
+				TransactionRepository: transaction.NewInMemoryTransactionRepo(),
+				failures:              tt.failures,
+			}
+			deadLetters := dead_letter.NewInMemoryDeadLetterStore()
+			service, err := NewParserService(
+				parser_state.NewInMemoryParserStateRepo(),
+				address.NewInMemoryAddressRepo(),
+				txRepo,
+				mockEthClient,
+				discardAppLogger(),
+				config.ApplicationServiceConfig{PollingIntervalSeconds: 5, StoreRetryAttempts: tt.attempts},
+				WithDeadLetterStore(deadLetters),
+			)
+			require.NoError(t, err)
+			ctx := context.Background()
+
+			from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+			to, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+			hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+			value, _ := domain.NewWeiValue("0x1")
+			blockNum, _ := domain.NewBlockNumber(10)
+			tx := domain.NewTransaction(hash, from, to, value, blockNum, 1000)
+			block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{tx})
+			mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+
+			monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+				to: domain.SubscriptionDirectionBoth,
+			})
+			require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+
+			assert.Equal(t, tt.wantStoreCalls, txRepo.calls.Load())
+			stored, err := txRepo.FindByAddress(ctx, to)
+			require.NoError(t, err)
+			letters, err := deadLetters.FindAll(ctx)
+			require.NoError(t, err)
+
+			if tt.wantStored {
+				assert.Equal(t, []domain.Transaction{tx}, stored)
+				assert.Empty(t, letters)
+				return
+			}
+			assert.Empty(t, stored)
+			require.Len(t, letters, 1)
+			assert.Equal(t, tx, letters[0].Transaction)
+			assert.Equal(t, []domain.Address{to}, letters[0].Addresses)
+			assert.Equal(t, max(tt.attempts, 1), letters[0].Attempts)
+			assert.Contains(t, letters[0].Reason, "storage unavailable")
+		})
+	}
+}
+
+func TestStoreWithRetry_StopsOnContextCancellation(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds:  5,
+		StoreRetryAttempts:      5,
+		StoreRetryBackoffMillis: 60_000,
+	})
+	service.txRepo = &flakyTransactionRepo{TransactionRepository: service.txRepo, failures: 100}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	err := service.storeWithRetry(ctx, blockMatch{tx: domain.Transaction{From: from}})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,169 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/logger"
+)
+
+// eventBusBufferSize is the number of pending events buffered per subscriber.
+const eventBusBufferSize = 64
+
+// ErrEventBusClosed indicates that an event could not be published because the event bus was closed.
+var ErrEventBusClosed = errors.New("event bus is closed")
+
+// Event is a notification published by the parser after it changed its state.
+type Event interface {
+	// EventName returns a short identifier of the event type, used in logs.
+	EventName() string
+}
+
+// TransactionStoredEvent is published after a transaction was stored for the monitored addresses.
+type TransactionStoredEvent struct {
+	Transaction domain.Transaction
+	// Addresses lists every address the transaction was indexed for.
+	Addresses []domain.Address
+}
+
+// EventName implements Event.
+func (TransactionStoredEvent) EventName() string { return "transaction_stored" }
+
+// TransactionReplayedEvent is published when a transaction stored earlier is replayed for an address,
+// so subscribers added later can catch up on its history. Replayed transactions are not stored again.
+type TransactionReplayedEvent struct {
+	Transaction domain.Transaction
+	// Address is the address whose history is replayed.
+	Address domain.Address
+}
+
+// EventName implements Event.
+func (TransactionReplayedEvent) EventName() string { return "transaction_replayed" }
+
+// BlockProcessedEvent is published after every transaction of a block was matched and stored.
+type BlockProcessedEvent struct {
+	Block              domain.BlockNumber
+	StoredTransactions int
+}
+
+// EventName implements Event.
+func (BlockProcessedEvent) EventName() string { return "block_processed" }
+
+// EventSubscriber consumes events published on the EventBus.
+// HandleEvent is called from the subscriber's own goroutine, one event at a time.
+type EventSubscriber interface {
+	// Name identifies the subscriber in logs.
+	Name() string
+	// HandleEvent processes a single event.
+	HandleEvent(event Event)
+}
+
+// EventBus fans events out to its subscribers asynchronously.
+// Each subscriber has a bounded queue; when it is full the event is dropped for that subscriber,
+// so a slow subscriber never blocks the publisher.
+type EventBus struct {
+	mu          sync.RWMutex
+	closed      bool
+	subscribers []*eventBusSubscriber
+	logger      logger.AppLogger
+	wg          sync.WaitGroup
+}
+
+// eventBusSubscriber pairs a subscriber with its event queue.
+type eventBusSubscriber struct {
+	subscriber EventSubscriber
+	events     chan Event
+}
+
+// NewEventBus creates an event bus and starts one delivery goroutine per subscriber.
+func NewEventBus(appLogger logger.AppLogger, subscribers ...EventSubscriber) *EventBus {
+	b := &EventBus{logger: appLogger}
+	for _, subscriber := range subscribers {
+		if subscriber == nil {
+			continue
+		}
+		sub := &eventBusSubscriber{subscriber: subscriber, events: make(chan Event, eventBusBufferSize)}
+		b.subscribers = append(b.subscribers, sub)
+		b.wg.Add(1)
+		go b.deliver(sub)
+	}
+	return b
+}
+
+// Publish queues the event for every subscriber without blocking.
+func (b *EventBus) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+	for _, sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			b.logger.Warn("Event subscriber is full, dropping event",
+				"subscriber", sub.subscriber.Name(),
+				"event", event.EventName())
+		}
+	}
+}
+
+// PublishWait queues the event for every subscriber, waiting for room in full queues instead of dropping the
+// event, until ctx is done. It is meant for bulk publishing, such as replays, that must not lose events.
+func (b *EventBus) PublishWait(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrEventBusClosed
+	}
+	for _, sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Close stops accepting events and waits until the subscribers have handled the queued ones.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, sub := range b.subscribers {
+		close(sub.events)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+// deliver hands queued events to the subscriber until the bus is closed.
+// A panicking subscriber is logged and keeps receiving subsequent events.
+func (b *EventBus) deliver(sub *eventBusSubscriber) {
+	defer b.wg.Done()
+	for event := range sub.events {
+		b.handle(sub.subscriber, event)
+	}
+}
+
+// handle calls the subscriber, recovering from panics so one bad event does not stop delivery.
+func (b *EventBus) handle(subscriber EventSubscriber, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event subscriber panicked",
+				"subscriber", subscriber.Name(),
+				"event", event.EventName(),
+				"panic", r)
+		}
+	}()
+	subscriber.HandleEvent(event)
+}
@@ -0,0 +1,208 @@
+package application
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/address"
+	"trust_wallet_homework/internal/adapters/storage/memory/parser_state"
+	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/application/mocks/mock_client"
+	"trust_wallet_homework/internal/core/domain"
+	applogger "trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSubscriber collects the events it receives. An optional gate blocks HandleEvent until closed.
+type recordingSubscriber struct {
+	name string
+	gate chan struct{}
+
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recordingSubscriber) Name() string { return r.name }
+
+func (r *recordingSubscriber) HandleEvent(event Event) {
+	if r.gate != nil {
+		<-r.gate
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recordingSubscriber) received() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// panickingSubscriber panics on its first event and records the following ones.
+type panickingSubscriber struct {
+	recordingSubscriber
+	panicked bool
+}
+
+func (p *panickingSubscriber) HandleEvent(event Event) {
+	if !p.panicked {
+		p.panicked = true
+		panic("boom")
+	}
+	p.recordingSubscriber.HandleEvent(event)
+}
+
+func discardAppLogger() applogger.AppLogger {
+	return applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestEventBus_FansOutToAllSubscribers(t *testing.T) {
+	first := &recordingSubscriber{name: "first"}
+	second := &recordingSubscriber{name: "second"}
+	bus := NewEventBus(discardAppLogger(), first, nil, second)
+
+	blockNum, _ := domain.NewBlockNumber(7)
+	bus.Publish(BlockProcessedEvent{Block: blockNum, StoredTransactions: 2})
+	bus.Publish(BlockProcessedEvent{Block: blockNum, StoredTransactions: 3})
+	bus.Close()
+
+	want := []Event{
+		BlockProcessedEvent{Block: blockNum, StoredTransactions: 2},
+		BlockProcessedEvent{Block: blockNum, StoredTransactions: 3},
+	}
+	assert.Equal(t, want, first.received())
+	assert.Equal(t, want, second.received())
+}
+
+func TestEventBus_SlowSubscriberDoesNotBlockPublisher(t *testing.T) {
+	slow := &recordingSubscriber{name: "slow", gate: make(chan struct{})}
+	fast := &recordingSubscriber{name: "fast"}
+	bus := NewEventBus(discardAppLogger(), slow, fast)
+
+	const published = eventBusBufferSize * 3
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < published; i++ {
+			bus.Publish(BlockProcessedEvent{StoredTransactions: i})
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a slow subscriber")
+	}
+
+	close(slow.gate)
+	bus.Close()
+
+	assert.Less(t, len(slow.received()), published, "events beyond the slow subscriber's buffer are dropped")
+	assert.NotEmpty(t, slow.received())
+	assert.NotEmpty(t, fast.received())
+}
+
+func TestEventBus_PublishWaitDeliversEveryEvent(t *testing.T) {
+	slow := &recordingSubscriber{name: "slow", gate: make(chan struct{})}
+	bus := NewEventBus(discardAppLogger(), slow)
+	ctx := context.Background()
+
+	const published = eventBusBufferSize * 3
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < published; i++ {
+			if err := bus.PublishWait(ctx, BlockProcessedEvent{StoredTransactions: i}); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	close(slow.gate)
+
+	require.NoError(t, <-done)
+	bus.Close()
+	assert.Len(t, slow.received(), published, "no event is dropped")
+	assert.ErrorIs(t, bus.PublishWait(ctx, BlockProcessedEvent{}), ErrEventBusClosed)
+}
+
+func TestEventBus_PublishWaitStopsWhenContextDone(t *testing.T) {
+	stuck := &recordingSubscriber{name: "stuck", gate: make(chan struct{})}
+	bus := NewEventBus(discardAppLogger(), stuck)
+	defer bus.Close()
+	defer close(stuck.gate)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var err error
+	for i := 0; i <= eventBusBufferSize+1 && err == nil; i++ {
+		err = bus.PublishWait(ctx, BlockProcessedEvent{StoredTransactions: i})
+	}
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestEventBus_RecoversFromSubscriberPanic(t *testing.T) {
+	sub := &panickingSubscriber{recordingSubscriber: recordingSubscriber{name: "panicky"}}
+	bus := NewEventBus(discardAppLogger(), sub)
+
+	bus.Publish(BlockProcessedEvent{StoredTransactions: 1})
+	bus.Publish(BlockProcessedEvent{StoredTransactions: 2})
+	bus.Close()
+
+	assert.Equal(t, []Event{BlockProcessedEvent{StoredTransactions: 2}}, sub.received())
+}
+
+func TestEventBus_PublishAfterCloseIsIgnored(t *testing.T) {
+	sub := &recordingSubscriber{name: "sub"}
+	bus := NewEventBus(discardAppLogger(), sub)
+	bus.Close()
+	bus.Close()
+
+	assert.NotPanics(t, func() { bus.Publish(BlockProcessedEvent{}) })
+	assert.Empty(t, sub.received())
+}
+
+func TestProcessBlock_PublishesEventsToSubscribers(t *testing.T) {
+	mockEthClient := mock_client.NewEthereumClient(t)
+	sub := &recordingSubscriber{name: "recorder"}
+	service, err := NewParserService(
+		parser_state.NewInMemoryParserStateRepo(),
+		address.NewInMemoryAddressRepo(),
+		transaction.NewInMemoryTransactionRepo(),
+		mockEthClient,
+		discardAppLogger(),
+		config.ApplicationServiceConfig{PollingIntervalSeconds: 5},
+		WithEventSubscribers(sub),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	to, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	tx := domain.NewTransaction(hash, from, to, value, blockNum, 1000)
+	block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, []domain.Transaction{tx})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+
+	monitored := newMonitoredAddresses(map[domain.Address]domain.SubscriptionDirection{
+		to: domain.SubscriptionDirectionBoth,
+	})
+	require.NoError(t, service.processBlock(ctx, blockNum, monitored))
+	service.events.Close()
+
+	assert.Equal(t, []Event{
+		TransactionStoredEvent{Transaction: tx, Addresses: []domain.Address{from, to}},
+		BlockProcessedEvent{Block: blockNum, StoredTransactions: 1},
+	}, sub.received())
+}
@@ -0,0 +1,28 @@
+package application
+
+import (
+	"time"
+
+	"trust_wallet_homework/internal/core/domain"
+)
+
+// Metrics receives measurements taken by the parser service, to be exported to a monitoring system.
+// Implementations must be safe for concurrent use and must not block.
+type Metrics interface {
+	// ObserveBlockProcessing records the wall-clock time a block took from being fetched until its last
+	// matching transaction was stored, together with the number of transactions in the block.
+	ObserveBlockProcessing(block domain.BlockNumber, txCount int, duration time.Duration)
+}
+
+// noopMetrics discards every measurement; it is used when no Metrics is configured.
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveBlockProcessing(domain.BlockNumber, int, time.Duration) {}
+
+// WithMetrics sets where the service reports its measurements.
+// Without it measurements are only logged at debug level.
+func WithMetrics(m Metrics) ServiceOption {
+	return func(o *serviceOptions) {
+		o.metrics = m
+	}
+}
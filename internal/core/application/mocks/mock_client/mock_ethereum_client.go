@@ -14,6 +14,94 @@ type EthereumClient struct {
 	mock.Mock
 }
 
+// GetBlockByHash provides a mock function with given fields: ctx, hash
+func (_m *EthereumClient) GetBlockByHash(ctx context.Context, hash domain.BlockHash) (*domain.Block, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByHash")
+	}
+
+	var r0 *domain.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockHash) (*domain.Block, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockHash) *domain.Block); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BlockHash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByTag provides a mock function with given fields: ctx, tag, full
+func (_m *EthereumClient) GetBlockByTag(ctx context.Context, tag string, full bool) (*domain.Block, error) {
+	ret := _m.Called(ctx, tag, full)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByTag")
+	}
+
+	var r0 *domain.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*domain.Block, error)); ok {
+		return rf(ctx, tag, full)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *domain.Block); ok {
+		r0 = rf(ctx, tag, full)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, tag, full)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockNumberByTag provides a mock function with given fields: ctx, tag
+func (_m *EthereumClient) GetBlockNumberByTag(ctx context.Context, tag string) (domain.BlockNumber, error) {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockNumberByTag")
+	}
+
+	var r0 domain.BlockNumber
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.BlockNumber, error)); ok {
+		return rf(ctx, tag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.BlockNumber); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		r0 = ret.Get(0).(domain.BlockNumber)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockWithTransactions provides a mock function with given fields: ctx, blockNumber
 func (_m *EthereumClient) GetBlockWithTransactions(ctx context.Context, blockNumber domain.BlockNumber) (*domain.Block, error) {
 	ret := _m.Called(ctx, blockNumber)
@@ -44,6 +132,34 @@ func (_m *EthereumClient) GetBlockWithTransactions(ctx context.Context, blockNum
 	return r0, r1
 }
 
+// GetChainID provides a mock function with given fields: ctx
+func (_m *EthereumClient) GetChainID(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChainID")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLatestBlockNumber provides a mock function with given fields: ctx
 func (_m *EthereumClient) GetLatestBlockNumber(ctx context.Context) (domain.BlockNumber, error) {
 	ret := _m.Called(ctx)
@@ -72,6 +188,66 @@ func (_m *EthereumClient) GetLatestBlockNumber(ctx context.Context) (domain.Bloc
 	return r0, r1
 }
 
+// GetTransactionByHash provides a mock function with given fields: ctx, hash
+func (_m *EthereumClient) GetTransactionByHash(ctx context.Context, hash domain.TransactionHash) (*domain.Transaction, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByHash")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionHash) (*domain.Transaction, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionHash) *domain.Transaction); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransactionHash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionStatuses provides a mock function with given fields: ctx, hashes
+func (_m *EthereumClient) GetTransactionStatuses(ctx context.Context, hashes []domain.TransactionHash) (map[domain.TransactionHash]domain.TransactionStatus, error) {
+	ret := _m.Called(ctx, hashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionStatuses")
+	}
+
+	var r0 map[domain.TransactionHash]domain.TransactionStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.TransactionHash) (map[domain.TransactionHash]domain.TransactionStatus, error)); ok {
+		return rf(ctx, hashes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.TransactionHash) map[domain.TransactionHash]domain.TransactionStatus); ok {
+		r0 = rf(ctx, hashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[domain.TransactionHash]domain.TransactionStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.TransactionHash) error); ok {
+		r1 = rf(ctx, hashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewEthereumClient creates a new instance of EthereumClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEthereumClient(t interface {
@@ -14,17 +14,17 @@ type MonitoredAddressRepository struct {
 	mock.Mock
 }
 
-// Add provides a mock function with given fields: ctx, address
-func (_m *MonitoredAddressRepository) Add(ctx context.Context, address domain.Address) error {
-	ret := _m.Called(ctx, address)
+// Add provides a mock function with given fields: ctx, address, direction
+func (_m *MonitoredAddressRepository) Add(ctx context.Context, address domain.Address, direction domain.SubscriptionDirection) error {
+	ret := _m.Called(ctx, address, direction)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Add")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) error); ok {
-		r0 = rf(ctx, address)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, domain.SubscriptionDirection) error); ok {
+		r0 = rf(ctx, address, direction)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,6 +32,34 @@ func (_m *MonitoredAddressRepository) Add(ctx context.Context, address domain.Ad
 	return r0
 }
 
+// Count provides a mock function with given fields: ctx
+func (_m *MonitoredAddressRepository) Count(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Exists provides a mock function with given fields: ctx, address
 func (_m *MonitoredAddressRepository) Exists(ctx context.Context, address domain.Address) (bool, error) {
 	ret := _m.Called(ctx, address)
@@ -90,6 +118,84 @@ func (_m *MonitoredAddressRepository) FindAll(ctx context.Context) ([]domain.Add
 	return r0, r1
 }
 
+// FindAllWithDirection provides a mock function with given fields: ctx
+func (_m *MonitoredAddressRepository) FindAllWithDirection(ctx context.Context) (map[domain.Address]domain.SubscriptionDirection, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllWithDirection")
+	}
+
+	var r0 map[domain.Address]domain.SubscriptionDirection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[domain.Address]domain.SubscriptionDirection, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[domain.Address]domain.SubscriptionDirection); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[domain.Address]domain.SubscriptionDirection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPage provides a mock function with given fields: ctx, limit, offset
+func (_m *MonitoredAddressRepository) FindPage(ctx context.Context, limit int, offset int) ([]domain.Address, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 []domain.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]domain.Address, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.Address); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *MonitoredAddressRepository) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMonitoredAddressRepository creates a new instance of MonitoredAddressRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMonitoredAddressRepository(t interface {
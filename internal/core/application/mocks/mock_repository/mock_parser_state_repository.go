@@ -42,6 +42,70 @@ func (_m *ParserStateRepository) GetCurrentBlock(ctx context.Context) (domain.Bl
 	return r0, r1
 }
 
+// IsBlockProcessed provides a mock function with given fields: ctx, blockNumber
+func (_m *ParserStateRepository) IsBlockProcessed(ctx context.Context, blockNumber domain.BlockNumber) (bool, error) {
+	ret := _m.Called(ctx, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlockProcessed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockNumber) (bool, error)); ok {
+		return rf(ctx, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockNumber) bool); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BlockNumber) error); ok {
+		r1 = rf(ctx, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkBlockProcessed provides a mock function with given fields: ctx, blockNumber
+func (_m *ParserStateRepository) MarkBlockProcessed(ctx context.Context, blockNumber domain.BlockNumber) error {
+	ret := _m.Called(ctx, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkBlockProcessed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockNumber) error); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *ParserStateRepository) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetCurrentBlock provides a mock function with given fields: ctx, blockNumber
 func (_m *ParserStateRepository) SetCurrentBlock(ctx context.Context, blockNumber domain.BlockNumber) error {
 	ret := _m.Called(ctx, blockNumber)
@@ -7,6 +7,8 @@ import (
 	domain "trust_wallet_homework/internal/core/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	repository "trust_wallet_homework/internal/core/domain/repository"
 )
 
 // TransactionRepository is an autogenerated mock type for the TransactionRepository type
@@ -14,6 +16,110 @@ type TransactionRepository struct {
 	mock.Mock
 }
 
+// CountAll provides a mock function with given fields: ctx
+func (_m *TransactionRepository) CountAll(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAll")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByAddress provides a mock function with given fields: ctx, address
+func (_m *TransactionRepository) CountByAddress(ctx context.Context, address domain.Address) (int, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByAddress")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) (int, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) int); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Each provides a mock function with given fields: ctx, fn
+func (_m *TransactionRepository) Each(ctx context.Context, fn func(domain.Transaction) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Each")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(domain.Transaction) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAddressesWithTransactions provides a mock function with given fields: ctx
+func (_m *TransactionRepository) FindAddressesWithTransactions(ctx context.Context) ([]domain.Address, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAddressesWithTransactions")
+	}
+
+	var r0 []domain.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Address, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Address); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByAddress provides a mock function with given fields: ctx, address
 func (_m *TransactionRepository) FindByAddress(ctx context.Context, address domain.Address) ([]domain.Transaction, error) {
 	ret := _m.Called(ctx, address)
@@ -44,6 +150,198 @@ func (_m *TransactionRepository) FindByAddress(ctx context.Context, address doma
 	return r0, r1
 }
 
+// FindByAddressFiltered provides a mock function with given fields: ctx, address, filter
+func (_m *TransactionRepository) FindByAddressFiltered(ctx context.Context, address domain.Address, filter repository.TransactionFilter) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, address, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAddressFiltered")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, repository.TransactionFilter) ([]domain.Transaction, error)); ok {
+		return rf(ctx, address, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, repository.TransactionFilter) []domain.Transaction); ok {
+		r0 = rf(ctx, address, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address, repository.TransactionFilter) error); ok {
+		r1 = rf(ctx, address, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByAddressInBlockRange provides a mock function with given fields: ctx, address, from, to
+func (_m *TransactionRepository) FindByAddressInBlockRange(ctx context.Context, address domain.Address, from domain.BlockNumber, to domain.BlockNumber) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, address, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAddressInBlockRange")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, domain.BlockNumber, domain.BlockNumber) ([]domain.Transaction, error)); ok {
+		return rf(ctx, address, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, domain.BlockNumber, domain.BlockNumber) []domain.Transaction); ok {
+		r0 = rf(ctx, address, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address, domain.BlockNumber, domain.BlockNumber) error); ok {
+		r1 = rf(ctx, address, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByBlock provides a mock function with given fields: ctx, block
+func (_m *TransactionRepository) FindByBlock(ctx context.Context, block domain.BlockNumber) ([]domain.Transaction, error) {
+	ret := _m.Called(ctx, block)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByBlock")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockNumber) ([]domain.Transaction, error)); ok {
+		return rf(ctx, block)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockNumber) []domain.Transaction); ok {
+		r0 = rf(ctx, block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BlockNumber) error); ok {
+		r1 = rf(ctx, block)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByHash provides a mock function with given fields: ctx, hash
+func (_m *TransactionRepository) FindByHash(ctx context.Context, hash domain.TransactionHash) (domain.Transaction, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByHash")
+	}
+
+	var r0 domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionHash) (domain.Transaction, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TransactionHash) domain.Transaction); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.TransactionHash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatestByAddress provides a mock function with given fields: ctx, address
+func (_m *TransactionRepository) FindLatestByAddress(ctx context.Context, address domain.Address) (domain.Transaction, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestByAddress")
+	}
+
+	var r0 domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) (domain.Transaction, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address) domain.Transaction); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *TransactionRepository) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Prune provides a mock function with given fields: ctx, beforeBlock
+func (_m *TransactionRepository) Prune(ctx context.Context, beforeBlock domain.BlockNumber) (int, error) {
+	ret := _m.Called(ctx, beforeBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prune")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockNumber) (int, error)); ok {
+		return rf(ctx, beforeBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BlockNumber) int); ok {
+		r0 = rf(ctx, beforeBlock)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BlockNumber) error); ok {
+		r1 = rf(ctx, beforeBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Store provides a mock function with given fields: ctx, tx
 func (_m *TransactionRepository) Store(ctx context.Context, tx domain.Transaction) error {
 	ret := _m.Called(ctx, tx)
@@ -62,6 +360,24 @@ func (_m *TransactionRepository) Store(ctx context.Context, tx domain.Transactio
 	return r0
 }
 
+// StoreForAddress provides a mock function with given fields: ctx, address, tx
+func (_m *TransactionRepository) StoreForAddress(ctx context.Context, address domain.Address, tx domain.Transaction) error {
+	ret := _m.Called(ctx, address, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StoreForAddress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Address, domain.Transaction) error); ok {
+		r0 = rf(ctx, address, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTransactionRepository creates a new instance of TransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTransactionRepository(t interface {
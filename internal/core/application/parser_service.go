@@ -2,9 +2,14 @@
 package application
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"trust_wallet_homework/internal/config"
@@ -22,17 +27,133 @@ type ParserServiceImpl struct {
 	txRepo      repository.TransactionRepository
 	ethClient   client.EthereumClient
 	logger      logger.AppLogger
+	txFeed      *transactionFeed
+	events      *EventBus
+	deadLetters repository.DeadLetterStore
+	scanRepo    repository.ScanRepository
+	matcher     TransactionMatcher
+	metrics     Metrics
 
-	pollingInterval time.Duration
-	lastKnownBlock  domain.BlockNumber
+	pollingInterval   time.Duration
+	pollingJitter     float64
+	catchupInterval   time.Duration
+	scanTimeout       time.Duration
+	catchingUp        bool
+	maxBlocksPerScan  int64
+	rescanTailBlocks  int64
+	startOnNodeError  bool
+	headBlockTag      string
+	skipProcessed     bool
+	startBlockPending bool
+	lastKnownBlock    domain.BlockNumber
+	lastKnownBlockSet bool
+	backfillGaps      bool
+	fetchReceipts     bool
+	streamBlocks      bool
+	storeAttempts     int
+	storeRetryDelay   time.Duration
+	expectedChainID   int64
+	reprocessThrough  int64
+	retentionBlocks   int64
+	pruneInterval     time.Duration
+	backfill          bool
+	backfillFrom      int64
+	backfillTo        int64
+	timestampCheck    string
+	maxTimestampDrift time.Duration
 
-	pollCtx  context.Context
-	stopChan chan struct{}
+	idempotentSubscribe bool
+	maxSubscriptions    int
+	// subscribeMu serializes subscriptions, so the subscription limit cannot be exceeded by concurrent requests.
+	subscribeMu sync.Mutex
+
+	// scanMu is held while a scan iteration runs, so scans never overlap.
+	scanMu sync.Mutex
+
+	// skippedScans counts scan iterations that found no subscribed addresses to match transactions against.
+	skippedScans    atomic.Int64
+	firstScanDone   atomic.Bool
+	lastEmptySetLog time.Time
+	now             func() time.Time
+	randFloat       func() float64
+	// startedAtNanos is the Unix time in nanoseconds of the last Start, or 0 before it. It is atomic, as Stats
+	// reads it without holding lifecycleMu.
+	startedAtNanos atomic.Int64
+	headCache      networkHeadCache
+	pendingRewind  atomic.Pointer[domain.BlockNumber]
+	// scanRequests carries scans requested by TriggerScan to the polling loop, with the channel for the result.
+	scanRequests chan chan error
+
+	lifecycleMu sync.Mutex
+	state       serviceState
+	pollCtx     context.Context
+	stopChan    chan struct{}
+	done        chan struct{}
+	doneOnce    sync.Once
 }
 
+// serviceState is the lifecycle state of the polling process.
+type serviceState int
+
+// Lifecycle states of the polling process. Start moves idle to running; Stop moves running to stopping
+// and, once the polling loop has finished, back to idle.
+const (
+	stateIdle serviceState = iota
+	stateRunning
+	stateStopping
+)
+
 // Compile-time check to ensure ParserServiceImpl implements ethparser.Parser
 var _ ethparser.Parser = (*ParserServiceImpl)(nil)
 
+// ErrChainIDMismatch indicates that the node serves a different chain than the configured expected chain ID.
+var ErrChainIDMismatch = errors.New("node chain ID does not match the expected chain ID")
+
+// ServiceOption configures optional dependencies of ParserServiceImpl.
+type ServiceOption func(*serviceOptions)
+
+// serviceOptions collects the optional dependencies passed to NewParserService.
+type serviceOptions struct {
+	subscribers     []EventSubscriber
+	deadLetterStore repository.DeadLetterStore
+	scanRepo        repository.ScanRepository
+	expectedChainID int64
+	metrics         Metrics
+}
+
+// WithEventSubscribers registers subscribers for the events published on the service's EventBus.
+func WithEventSubscribers(subscribers ...EventSubscriber) ServiceOption {
+	return func(o *serviceOptions) {
+		o.subscribers = append(o.subscribers, subscribers...)
+	}
+}
+
+// WithDeadLetterStore sets where transactions are recorded when storing them keeps failing.
+// Without it such transactions are only logged.
+func WithDeadLetterStore(store repository.DeadLetterStore) ServiceOption {
+	return func(o *serviceOptions) {
+		o.deadLetterStore = store
+	}
+}
+
+// WithScanRepository makes the scan store the matched transactions of each block and advance the last scanned
+// block through a single StoreBlockResults call, which a transactional storage backend applies atomically.
+// The repository must write to the same storage as the state and transaction repositories of the service.
+// Without it transactions are stored one by one and the progress is saved once per scan iteration.
+func WithScanRepository(repo repository.ScanRepository) ServiceOption {
+	return func(o *serviceOptions) {
+		o.scanRepo = repo
+	}
+}
+
+// WithExpectedChainID makes Start refuse to run against a node serving a chain with a different ID.
+// Zero disables the check; the chain ID is then only logged.
+func WithExpectedChainID(chainID int64) ServiceOption {
+	return func(o *serviceOptions) {
+		o.expectedChainID = chainID
+	}
+}
+
 // NewParserService creates a new instance of ParserServiceImpl.
 func NewParserService(
 	stateRepo repository.ParserStateRepository,
@@ -41,6 +162,7 @@ func NewParserService(
 	ethClient client.EthereumClient,
 	appLogger logger.AppLogger,
 	appCfg config.ApplicationServiceConfig,
+	opts ...ServiceOption,
 ) (*ParserServiceImpl, error) {
 	if appLogger == nil {
 		return nil, errors.New("NewParserService: appLogger is nil")
@@ -58,14 +180,62 @@ func NewParserService(
 		return nil, errors.New("NewParserService: ethClient is nil")
 	}
 
+	matcher, err := NewTransactionMatcher(appCfg.MatchStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("NewParserService: %w", err)
+	}
+
+	options := serviceOptions{metrics: noopMetrics{}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	txFeed := newTransactionFeed(appLogger)
+	events := NewEventBus(appLogger, append([]EventSubscriber{txFeed}, options.subscribers...)...)
+
 	sInstance := &ParserServiceImpl{
-		stateRepo:       stateRepo,
-		addressRepo:     addressRepo,
-		txRepo:          txRepo,
-		ethClient:       ethClient,
-		logger:          appLogger,
-		pollingInterval: time.Duration(appCfg.PollingIntervalSeconds) * time.Second,
+		stateRepo:         stateRepo,
+		addressRepo:       addressRepo,
+		txRepo:            txRepo,
+		ethClient:         ethClient,
+		logger:            appLogger,
+		txFeed:            txFeed,
+		events:            events,
+		deadLetters:       options.deadLetterStore,
+		scanRepo:          options.scanRepo,
+		metrics:           options.metrics,
+		expectedChainID:   options.expectedChainID,
+		matcher:           matcher,
+		pollingInterval:   time.Duration(appCfg.PollingIntervalSeconds) * time.Second,
+		pollingJitter:     float64(appCfg.PollingJitterPercent) / 100,
+		catchupInterval:   time.Duration(appCfg.CatchupPollingSeconds) * time.Second,
+		scanTimeout:       time.Duration(appCfg.ScanTimeoutSeconds) * time.Second,
+		maxBlocksPerScan:  appCfg.MaxBlocksPerScan,
+		rescanTailBlocks:  appCfg.RescanTailBlocks,
+		startOnNodeError:  appCfg.StartOnNodeError,
+		headBlockTag:      appCfg.HeadBlockTag,
+		skipProcessed:     appCfg.SkipProcessedBlocks,
+		backfillGaps:      appCfg.BackfillGaps,
+		fetchReceipts:     appCfg.FetchReceipts,
+		streamBlocks:      appCfg.StreamBlocks,
+		storeAttempts:     appCfg.StoreRetryAttempts,
+		storeRetryDelay:   time.Duration(appCfg.StoreRetryBackoffMillis) * time.Millisecond,
+		retentionBlocks:   appCfg.RetentionBlocks,
+		pruneInterval:     time.Duration(appCfg.PruneIntervalSeconds) * time.Second,
+		backfill:          appCfg.Mode == config.AppServiceModeBackfill,
+		backfillFrom:      appCfg.BackfillFromBlock,
+		backfillTo:        appCfg.BackfillToBlock,
+		timestampCheck:    appCfg.TimestampCheck,
+		maxTimestampDrift: time.Duration(appCfg.MaxTimestampDriftSecs) * time.Second,
+
+		idempotentSubscribe: appCfg.IdempotentSubscribe,
+		maxSubscriptions:    appCfg.MaxSubscriptions,
+		done:                make(chan struct{}),
+		now:                 time.Now,
+		scanRequests:        make(chan chan error),
+		randFloat:           rand.Float64,
 	}
+	sInstance.warnOnShortScanBudget()
 
 	return sInstance, nil
 }
@@ -79,20 +249,124 @@ func (s *ParserServiceImpl) GetCurrentBlock(ctx context.Context) (blockNumber in
 	return domainBlockNumber.Value(), nil
 }
 
-// Subscribe adds a new address to be monitored by the parser.
-func (s *ParserServiceImpl) Subscribe(ctx context.Context, addressString string) (err error) {
+// Subscribe adds a new address to be monitored by the parser, indexing only transactions in the given direction.
+// Subscribing an address that is already monitored returns ethparser.ErrAddressAlreadySubscribed, unless
+// idempotent subscriptions are enabled, in which case it succeeds and the existing subscription is kept unchanged.
+// A new address is rejected with ethparser.ErrSubscriptionLimitReached once the configured maximum number of
+// subscriptions is reached.
+func (s *ParserServiceImpl) Subscribe(ctx context.Context, addressString string, directionString string) (err error) {
 	address, err := domain.NewAddress(addressString)
 	if err != nil {
 		return fmt.Errorf("address validation failed: %w", err)
 	}
+	direction, err := domain.NewSubscriptionDirection(directionString)
+	if err != nil {
+		return fmt.Errorf("direction validation failed: %w", err)
+	}
+
+	s.subscribeMu.Lock()
+	defer s.subscribeMu.Unlock()
 
 	loggerWithAddress := s.logger.With("address", address.String())
-	if err := s.addressRepo.Add(ctx, address); err != nil {
+	exists, err := s.addressRepo.Exists(ctx, address)
+	if err != nil {
+		loggerWithAddress.Error("Failed to check subscription in repository", "error", err)
+		return fmt.Errorf("failed to check subscription in repository: %w", err)
+	}
+	if exists {
+		if s.idempotentSubscribe {
+			loggerWithAddress.Info("Address already subscribed, keeping existing subscription")
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ethparser.ErrAddressAlreadySubscribed, address.String())
+	}
+	if err := s.checkSubscriptionLimit(ctx); err != nil {
+		loggerWithAddress.Warn("Rejected subscription", "error", err)
+		return err
+	}
+
+	if err := s.addressRepo.Add(ctx, address, direction); err != nil {
 		loggerWithAddress.Error("Failed to subscribe address in repository", "error", err)
 		return fmt.Errorf("failed to subscribe address in repository: %w", err)
 	}
 
-	s.logger.Info("Successfully subscribed address", "address", address.String())
+	s.logger.Info("Successfully subscribed address", "address", address.String(), "direction", direction.String())
+	return nil
+}
+
+// SubscribeMany adds several addresses to be monitored with the same direction,
+// reporting address validation failures per address.
+func (s *ParserServiceImpl) SubscribeMany(
+	ctx context.Context,
+	addressStrings []string,
+	directionString string,
+) ([]ethparser.SubscribeResult, error) {
+	direction, err := domain.NewSubscriptionDirection(directionString)
+	if err != nil {
+		return nil, fmt.Errorf("direction validation failed: %w", err)
+	}
+
+	s.subscribeMu.Lock()
+	defer s.subscribeMu.Unlock()
+
+	results := make([]ethparser.SubscribeResult, 0, len(addressStrings))
+	for _, addressString := range addressStrings {
+		address, err := domain.NewAddress(addressString)
+		if err != nil {
+			results = append(results, ethparser.SubscribeResult{
+				Address: addressString,
+				Error:   fmt.Sprintf("address validation failed: %v", err),
+			})
+			continue
+		}
+
+		exists, err := s.addressRepo.Exists(ctx, address)
+		if err != nil {
+			s.logger.Error("Failed to check subscription in repository", "address", address.String(), "error", err)
+			return results, fmt.Errorf("failed to check subscription of %s in repository: %w", address.String(), err)
+		}
+		if exists {
+			result := ethparser.SubscribeResult{Address: address.String(), Success: s.idempotentSubscribe}
+			if !s.idempotentSubscribe {
+				result.Error = ethparser.ErrAddressAlreadySubscribed.Error()
+			}
+			results = append(results, result)
+			continue
+		}
+		if err := s.checkSubscriptionLimit(ctx); err != nil {
+			if !errors.Is(err, ethparser.ErrSubscriptionLimitReached) {
+				return results, err
+			}
+			results = append(results, ethparser.SubscribeResult{Address: address.String(), Error: err.Error()})
+			continue
+		}
+
+		if err := s.addressRepo.Add(ctx, address, direction); err != nil {
+			s.logger.Error("Failed to subscribe address in repository", "address", address.String(), "error", err)
+			return results, fmt.Errorf("failed to subscribe address %s in repository: %w", address.String(), err)
+		}
+		results = append(results, ethparser.SubscribeResult{Address: address.String(), Success: true})
+	}
+
+	s.logger.Info("Processed bulk subscription", "requested", len(addressStrings))
+	return results, nil
+}
+
+// checkSubscriptionLimit returns ethparser.ErrSubscriptionLimitReached if one more address would exceed the
+// configured maximum number of subscriptions. The caller must hold subscribeMu.
+func (s *ParserServiceImpl) checkSubscriptionLimit(ctx context.Context) error {
+	if s.maxSubscriptions <= 0 {
+		return nil
+	}
+	count, err := s.addressRepo.Count(ctx)
+	if err != nil {
+		s.logger.Error("Failed to count subscriptions in repository", "error", err)
+		return fmt.Errorf("failed to count subscriptions in repository: %w", err)
+	}
+	if count >= s.maxSubscriptions {
+		return fmt.Errorf("%w: at most %d addresses can be subscribed",
+			ethparser.ErrSubscriptionLimitReached, s.maxSubscriptions)
+	}
 	return nil
 }
 
@@ -106,6 +380,10 @@ func (s *ParserServiceImpl) GetTransactions(
 		return nil, fmt.Errorf("address validation failed: %w", err)
 	}
 
+	if err := s.ensureSubscribed(ctx, address); err != nil {
+		return nil, err
+	}
+
 	loggerWithAddress := s.logger.With("address", address.String())
 	domainTxs, err := s.txRepo.FindByAddress(ctx, address)
 	if err != nil {
@@ -115,74 +393,611 @@ func (s *ParserServiceImpl) GetTransactions(
 
 	apiTxs := make([]ethparser.Transaction, 0, len(domainTxs))
 	for _, domainTx := range domainTxs {
-		apiTxs = append(apiTxs, mapDomainToAPITransaction(domainTx))
+		apiTxs = append(apiTxs, mapDomainToAPITransaction(domainTx, address))
+	}
+
+	return apiTxs, nil
+}
+
+// GetTransactionsForAddresses retrieves the stored transactions of several monitored addresses, keyed by
+// the canonical form of each address. Every address must be valid and subscribed; an address given more
+// than once, in any letter case, is looked up only once.
+func (s *ParserServiceImpl) GetTransactionsForAddresses(
+	ctx context.Context,
+	addressStrings []string,
+) (map[string][]ethparser.Transaction, error) {
+	addresses := make([]domain.Address, 0, len(addressStrings))
+	seen := make(map[string]bool, len(addressStrings))
+	for _, addressString := range addressStrings {
+		address, err := domain.NewAddress(addressString)
+		if err != nil {
+			return nil, fmt.Errorf("address validation failed for %q: %w", addressString, err)
+		}
+		if seen[address.String()] {
+			continue
+		}
+		seen[address.String()] = true
+		addresses = append(addresses, address)
+	}
+
+	result := make(map[string][]ethparser.Transaction, len(addresses))
+	for _, address := range addresses {
+		if err := s.ensureSubscribed(ctx, address); err != nil {
+			return nil, err
+		}
+
+		domainTxs, err := s.txRepo.FindByAddress(ctx, address)
+		if err != nil {
+			s.logger.Error("Error fetching transactions for address", "address", address.String(), "error", err)
+			return nil, fmt.Errorf("failed to get transactions of %s from repository: %w", address.String(), err)
+		}
+
+		apiTxs := make([]ethparser.Transaction, 0, len(domainTxs))
+		for _, domainTx := range domainTxs {
+			apiTxs = append(apiTxs, mapDomainToAPITransaction(domainTx, address))
+		}
+		result[address.String()] = apiTxs
+	}
+
+	return result, nil
+}
+
+// GetTransactionsInRange retrieves stored transactions for a monitored address included in blocks from..to (inclusive).
+func (s *ParserServiceImpl) GetTransactionsInRange(
+	ctx context.Context,
+	addressString string,
+	from, to int64,
+) ([]ethparser.Transaction, error) {
+	address, err := domain.NewAddress(addressString)
+	if err != nil {
+		return nil, fmt.Errorf("address validation failed: %w", err)
+	}
+	fromBlock, err := domain.NewBlockNumber(from)
+	if err != nil {
+		return nil, fmt.Errorf("from block validation failed: %w", err)
+	}
+	toBlock, err := domain.NewBlockNumber(to)
+	if err != nil {
+		return nil, fmt.Errorf("to block validation failed: %w", err)
+	}
+	if from > to {
+		return nil, fmt.Errorf("%w: from %d is greater than to %d", ethparser.ErrInvalidBlockRange, from, to)
+	}
+
+	if err := s.ensureSubscribed(ctx, address); err != nil {
+		return nil, err
+	}
+
+	loggerWithAddress := s.logger.With("address", address.String(), "fromBlock", from, "toBlock", to)
+	domainTxs, err := s.txRepo.FindByAddressInBlockRange(ctx, address, fromBlock, toBlock)
+	if err != nil {
+		loggerWithAddress.Error("Error fetching transactions in block range for address", "error", err)
+		return nil, fmt.Errorf("failed to get transactions in block range from repository: %w", err)
+	}
+
+	apiTxs := make([]ethparser.Transaction, 0, len(domainTxs))
+	for _, domainTx := range domainTxs {
+		apiTxs = append(apiTxs, mapDomainToAPITransaction(domainTx, address))
+	}
+
+	return apiTxs, nil
+}
+
+// QueryTransactions retrieves stored transactions for a monitored address that match the query.
+// The value threshold and block bounds are applied by the repository; the result is sorted here.
+func (s *ParserServiceImpl) QueryTransactions(
+	ctx context.Context,
+	addressString string,
+	query ethparser.TransactionQuery,
+) ([]ethparser.Transaction, error) {
+	address, err := domain.NewAddress(addressString)
+	if err != nil {
+		return nil, fmt.Errorf("address validation failed: %w", err)
+	}
+	filter, err := newTransactionFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	compare, err := transactionOrder(query.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureSubscribed(ctx, address); err != nil {
+		return nil, err
+	}
+
+	domainTxs, err := s.txRepo.FindByAddressFiltered(ctx, address, filter)
+	if err != nil {
+		s.logger.Error("Error querying transactions for address", "address", address.String(), "error", err)
+		return nil, fmt.Errorf("failed to query transactions from repository: %w", err)
+	}
+	if compare != nil {
+		slices.SortStableFunc(domainTxs, compare)
+	}
+
+	apiTxs := make([]ethparser.Transaction, 0, len(domainTxs))
+	for _, domainTx := range domainTxs {
+		apiTxs = append(apiTxs, mapDomainToAPITransaction(domainTx, address))
+	}
+	return apiTxs, nil
+}
+
+// newTransactionFilter validates the block bounds and value threshold of a query.
+func newTransactionFilter(query ethparser.TransactionQuery) (repository.TransactionFilter, error) {
+	fromBlock, err := domain.NewBlockNumber(query.FromBlock)
+	if err != nil {
+		return repository.TransactionFilter{}, fmt.Errorf("from block validation failed: %w", err)
+	}
+	toBlock, err := domain.NewBlockNumber(query.ToBlock)
+	if err != nil {
+		return repository.TransactionFilter{}, fmt.Errorf("to block validation failed: %w", err)
+	}
+	if query.FromBlock > query.ToBlock {
+		return repository.TransactionFilter{}, fmt.Errorf("%w: from %d is greater than to %d",
+			ethparser.ErrInvalidBlockRange, query.FromBlock, query.ToBlock)
+	}
+
+	filter := repository.TransactionFilter{FromBlock: fromBlock, ToBlock: toBlock}
+	if query.MinValue != "" {
+		if filter.MinValue, err = domain.NewWeiValue(query.MinValue); err != nil {
+			return repository.TransactionFilter{}, fmt.Errorf("min value validation failed: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+// transactionOrder returns the comparison implementing a sort order, or nil to keep the repository's chain order.
+// Ties in value are broken by block number, newest first.
+func transactionOrder(sort string) (func(a, b domain.Transaction) int, error) {
+	byBlockDesc := func(a, b domain.Transaction) int {
+		return cmp.Compare(b.BlockNumber.Value(), a.BlockNumber.Value())
+	}
+	switch sort {
+	case "":
+		return nil, nil
+	case ethparser.SortValueDesc:
+		return func(a, b domain.Transaction) int {
+			return cmp.Or(b.Value.Cmp(a.Value), byBlockDesc(a, b))
+		}, nil
+	case ethparser.SortValueAsc:
+		return func(a, b domain.Transaction) int {
+			return cmp.Or(a.Value.Cmp(b.Value), byBlockDesc(a, b))
+		}, nil
+	case ethparser.SortBlockDesc:
+		return byBlockDesc, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'; must be one of: %s, %s, %s", ethparser.ErrInvalidSortOrder, sort,
+			ethparser.SortValueDesc, ethparser.SortValueAsc, ethparser.SortBlockDesc)
+	}
+}
+
+// GetTransactionCount returns the number of stored transactions associated with a given monitored address.
+func (s *ParserServiceImpl) GetTransactionCount(ctx context.Context, addressString string) (int, error) {
+	address, err := domain.NewAddress(addressString)
+	if err != nil {
+		return 0, fmt.Errorf("address validation failed: %w", err)
+	}
+
+	if err := s.ensureSubscribed(ctx, address); err != nil {
+		return 0, err
+	}
+
+	count, err := s.txRepo.CountByAddress(ctx, address)
+	if err != nil {
+		s.logger.Error("Error counting transactions for address", "address", address.String(), "error", err)
+		return 0, fmt.Errorf("failed to count transactions in repository: %w", err)
+	}
+
+	return count, nil
+}
+
+// LastActivity returns the block number and timestamp of the most recent stored transaction of a monitored
+// address, or zeros if none is stored yet. The genesis block has no transactions, so zero is unambiguous.
+func (s *ParserServiceImpl) LastActivity(ctx context.Context, addressString string) (int64, uint64, error) {
+	address, err := domain.NewAddress(addressString)
+	if err != nil {
+		return 0, 0, fmt.Errorf("address validation failed: %w", err)
+	}
+
+	if err := s.ensureSubscribed(ctx, address); err != nil {
+		return 0, 0, err
+	}
+
+	tx, err := s.txRepo.FindLatestByAddress(ctx, address)
+	if err != nil {
+		if errors.Is(err, repository.ErrTransactionNotFound) {
+			return 0, 0, nil
+		}
+		s.logger.Error("Error finding latest transaction for address", "address", address.String(), "error", err)
+		return 0, 0, fmt.Errorf("failed to find latest transaction in repository: %w", err)
+	}
+
+	return tx.BlockNumber.Value(), tx.Timestamp, nil
+}
+
+// ListSubscriptions returns a page of the subscribed addresses, in lexicographic order, and their total number.
+func (s *ParserServiceImpl) ListSubscriptions(ctx context.Context, limit, offset int) ([]string, int, error) {
+	if limit <= 0 || offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit %d, offset %d", ethparser.ErrInvalidPagination, limit, offset)
+	}
+
+	total, err := s.addressRepo.Count(ctx)
+	if err != nil {
+		s.logger.Error("Error counting subscribed addresses", "error", err)
+		return nil, 0, fmt.Errorf("failed to count addresses in repository: %w", err)
+	}
+	addresses, err := s.addressRepo.FindPage(ctx, limit, offset)
+	if err != nil {
+		s.logger.Error("Error finding subscribed addresses", "limit", limit, "offset", offset, "error", err)
+		return nil, 0, fmt.Errorf("failed to find addresses in repository: %w", err)
+	}
+
+	result := make([]string, len(addresses))
+	for i, address := range addresses {
+		result[i] = address.String()
+	}
+	return result, total, nil
+}
+
+// ListAddressesWithTransactions returns the addresses that have stored transactions, in lexicographic order.
+func (s *ParserServiceImpl) ListAddressesWithTransactions(ctx context.Context) ([]string, error) {
+	addresses, err := s.txRepo.FindAddressesWithTransactions(ctx)
+	if err != nil {
+		s.logger.Error("Error finding addresses with transactions", "error", err)
+		return nil, fmt.Errorf("failed to find addresses with transactions in repository: %w", err)
+	}
+
+	result := make([]string, len(addresses))
+	for i, address := range addresses {
+		result[i] = address.String()
+	}
+	return result, nil
+}
+
+// ExportTransactions calls fn for every stored transaction, or only for those of a monitored address.
+// Transactions exported for an address carry their direction relative to it; all others have none.
+func (s *ParserServiceImpl) ExportTransactions(
+	ctx context.Context,
+	addressString string,
+	fn func(ethparser.Transaction) error,
+) error {
+	if addressString == "" {
+		err := s.txRepo.Each(ctx, func(tx domain.Transaction) error {
+			return fn(mapDomainToAPITransaction(tx, domain.Address{}))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export transactions: %w", err)
+		}
+		return nil
+	}
+
+	address, err := domain.NewAddress(addressString)
+	if err != nil {
+		return fmt.Errorf("address validation failed: %w", err)
+	}
+
+	if err := s.ensureSubscribed(ctx, address); err != nil {
+		return err
+	}
+
+	domainTxs, err := s.txRepo.FindByAddress(ctx, address)
+	if err != nil {
+		s.logger.Error("Error fetching transactions for export", "address", address.String(), "error", err)
+		return fmt.Errorf("failed to get transactions from repository: %w", err)
+	}
+
+	for _, domainTx := range domainTxs {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("failed to export transactions: %w", err)
+		}
+		if err := fn(mapDomainToAPITransaction(domainTx, address)); err != nil {
+			return fmt.Errorf("failed to export transactions: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetTransactionByHash retrieves a stored transaction by its hash.
+// The transaction is returned without a direction, as it is not looked up for a specific address.
+func (s *ParserServiceImpl) GetTransactionByHash(ctx context.Context, hashString string) (*ethparser.Transaction, error) {
+	hash, err := domain.NewTransactionHash(hashString)
+	if err != nil {
+		return nil, fmt.Errorf("transaction hash validation failed: %w", err)
+	}
+
+	tx, err := s.txRepo.FindByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, repository.ErrTransactionNotFound) {
+			return nil, fmt.Errorf("%w: %s", ethparser.ErrTransactionNotFound, hash.String())
+		}
+		s.logger.Error("Error finding transaction by hash", "txHash", hash.String(), "error", err)
+		return nil, fmt.Errorf("failed to find transaction in repository: %w", err)
+	}
+
+	apiTx := mapDomainToAPITransaction(tx, domain.Address{})
+	return &apiTx, nil
+}
+
+// GetNodeTransaction fetches a transaction by its hash from the node, bypassing the stored transactions.
+// It returns ethparser.ErrTransactionNotFound if the node does not know the hash.
+func (s *ParserServiceImpl) GetNodeTransaction(ctx context.Context, hashString string) (*ethparser.Transaction, error) {
+	hash, err := domain.NewTransactionHash(hashString)
+	if err != nil {
+		return nil, fmt.Errorf("transaction hash validation failed: %w", err)
+	}
+
+	tx, err := s.ethClient.GetTransactionByHash(ctx, hash)
+	if err != nil {
+		s.logger.Error("Error fetching transaction from node", "txHash", hash.String(), "error", err)
+		return nil, fmt.Errorf("failed to get transaction %s from node: %w", hash.String(), err)
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("%w: %s", ethparser.ErrTransactionNotFound, hash.String())
 	}
 
+	apiTx := mapDomainToAPITransaction(*tx, domain.Address{})
+	return &apiTx, nil
+}
+
+// GetTransactionsInBlock retrieves the stored transactions included in a block, ordered by hash.
+// Direction is not set, as the transactions are not returned for a specific address.
+func (s *ParserServiceImpl) GetTransactionsInBlock(ctx context.Context, number int64) ([]ethparser.Transaction, error) {
+	blockNumber, err := domain.NewBlockNumber(number)
+	if err != nil {
+		return nil, fmt.Errorf("block number validation failed: %w", err)
+	}
+
+	domainTxs, err := s.txRepo.FindByBlock(ctx, blockNumber)
+	if err != nil {
+		s.logger.Error("Error finding transactions by block", "blockNumber", number, "error", err)
+		return nil, fmt.Errorf("failed to find transactions in block from repository: %w", err)
+	}
+
+	apiTxs := make([]ethparser.Transaction, 0, len(domainTxs))
+	for _, domainTx := range domainTxs {
+		apiTxs = append(apiTxs, mapDomainToAPITransaction(domainTx, domain.Address{}))
+	}
 	return apiTxs, nil
 }
 
+// GetBlock fetches a block by number from the Ethereum node.
+func (s *ParserServiceImpl) GetBlock(ctx context.Context, number int64) (*ethparser.Block, error) {
+	blockNumber, err := domain.NewBlockNumber(number)
+	if err != nil {
+		return nil, fmt.Errorf("block number validation failed: %w", err)
+	}
+
+	block, err := s.ethClient.GetBlockWithTransactions(ctx, blockNumber)
+	if err != nil {
+		s.logger.Error("Error fetching block from node", "blockNumber", number, "error", err)
+		return nil, fmt.Errorf("failed to get block %d from node: %w", number, err)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("%w: %d", ethparser.ErrBlockNotFound, number)
+	}
+
+	return mapDomainToAPIBlock(block), nil
+}
+
+// GetBlockByHash fetches a block by hash from the node, including all of its transactions.
+func (s *ParserServiceImpl) GetBlockByHash(ctx context.Context, hashString string) (*ethparser.Block, error) {
+	hash, err := domain.NewBlockHash(hashString)
+	if err != nil {
+		return nil, fmt.Errorf("block hash validation failed: %w", err)
+	}
+
+	block, err := s.ethClient.GetBlockByHash(ctx, hash)
+	if err != nil {
+		s.logger.Error("Error fetching block by hash from node", "blockHash", hash.String(), "error", err)
+		return nil, fmt.Errorf("failed to get block %s from node: %w", hash.String(), err)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s", ethparser.ErrBlockNotFound, hash.String())
+	}
+
+	return mapDomainToAPIBlock(block), nil
+}
+
+// WatchTransactions returns a channel receiving transactions newly stored for the given address.
+// The channel is closed once ctx is done.
+func (s *ParserServiceImpl) WatchTransactions(
+	ctx context.Context,
+	addressString string,
+) (<-chan ethparser.Transaction, error) {
+	address, err := domain.NewAddress(addressString)
+	if err != nil {
+		return nil, fmt.Errorf("address validation failed: %w", err)
+	}
+
+	if err := s.ensureSubscribed(ctx, address); err != nil {
+		return nil, err
+	}
+
+	ch, id := s.txFeed.register(address)
+	s.logger.Debug("Transaction watcher registered", "address", address.String(), "listenerID", id)
+
+	go func() {
+		<-ctx.Done()
+		s.txFeed.unregister(address, id)
+		s.logger.Debug("Transaction watcher unregistered", "address", address.String(), "listenerID", id)
+	}()
+
+	return ch, nil
+}
+
+// ensureSubscribed returns ethparser.ErrAddressNotSubscribed if the address is not being monitored.
+func (s *ParserServiceImpl) ensureSubscribed(ctx context.Context, address domain.Address) error {
+	exists, err := s.addressRepo.Exists(ctx, address)
+	if err != nil {
+		s.logger.Error("Failed to check subscription in repository", "address", address.String(), "error", err)
+		return fmt.Errorf("failed to check subscription in repository: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ethparser.ErrAddressNotSubscribed, address.String())
+	}
+	return nil
+}
+
 // Start initiates the background blockchain polling process.
+// In backfill mode scanning starts at the configured first block and Done is closed once the range is scanned.
+// Start fails with ethparser.ErrParserAlreadyRunning or ethparser.ErrParserStopping unless the service is idle.
 func (s *ParserServiceImpl) Start(ctx context.Context) (err error) {
-	s.logger.Info("Attempting to fetch latest block from network to determine starting point...")
-	latestNetBlock, errNet := s.ethClient.GetLatestBlockNumber(ctx)
-	if errNet != nil {
-		s.logger.Error("Failed to fetch latest block number from network", "error", errNet, "defaultingToBlock", 0)
-		s.lastKnownBlock, _ = domain.NewBlockNumber(0)
-	} else {
-		s.lastKnownBlock = latestNetBlock
-		s.logger.Info("Starting scan from latest network block", "blockNumber", s.lastKnownBlock.Value())
+	s.lifecycleMu.Lock()
+	defer s.lifecycleMu.Unlock()
+
+	if s.state == stateRunning && s.pollLoopExited() {
+		s.state = stateIdle
+	}
+	switch s.state {
+	case stateRunning:
+		s.logger.Info("Parser service is already running.")
+		return ethparser.ErrParserAlreadyRunning
+	case stateStopping:
+		s.logger.Info("Parser service is still stopping.")
+		return ethparser.ErrParserStopping
 	}
 
-	if errSet := s.stateRepo.SetCurrentBlock(ctx, s.lastKnownBlock); errSet != nil {
-		s.logger.Error("Failed to set initial parser state in repository",
-			"error", errSet,
-			"blockNumber", s.lastKnownBlock.Value())
-	} else {
-		s.logger.Info("Initial parser state set in repository", "blockNumber", s.lastKnownBlock.Value())
+	if err := s.verifyChainID(ctx); err != nil {
+		return err
 	}
 
-	if s.pollCtx != nil && s.pollCtx.Err() == nil {
-		s.logger.Info("Parser service is already running or was not properly stopped.")
-		return fmt.Errorf("service already running or not properly stopped")
+	if s.backfill {
+		startBlock, errBlock := domain.NewBlockNumber(s.backfillFrom - 1)
+		if errBlock != nil {
+			return fmt.Errorf("invalid backfill starting block: %w", errBlock)
+		}
+		s.setLastKnownBlock(startBlock)
+		s.logger.Info("Starting backfill of fixed block range", "fromBlock", s.backfillFrom, "toBlock", s.backfillTo)
+		s.storeInitialBlock(ctx)
+	} else if errStart := s.startFromHead(ctx); errStart != nil {
+		return errStart
 	}
 
 	s.pollCtx = ctx
 	s.stopChan = make(chan struct{})
+	s.state = stateRunning
 
+	s.startedAtNanos.Store(s.now().UnixNano())
 	go s.pollBlocks()
 	s.logger.Info("Parser service started polling...")
 	return nil
 }
 
-// Stop signals the background polling process to shut down gracefully and waits for it to complete.
-func (s *ParserServiceImpl) Stop(ctx context.Context) (err error) {
-	if s.pollCtx == nil {
-		s.logger.Info("Parser service was not started or already stopped.")
-		return nil
+// verifyChainID logs the chain ID reported by the node and checks it against the expected chain ID.
+// If the chain ID cannot be fetched, startup only fails when a check is configured and
+// startOnNodeError is not set.
+func (s *ParserServiceImpl) verifyChainID(ctx context.Context) error {
+	chainID, err := s.ethClient.GetChainID(ctx)
+	if err != nil {
+		if s.expectedChainID == 0 || s.startOnNodeError {
+			s.logger.Warn("Failed to fetch chain ID from network, skipping chain check", "error", err)
+			return nil
+		}
+		s.logger.Error("Failed to fetch chain ID from network, refusing to start", "error", err)
+		return fmt.Errorf("failed to fetch chain ID at startup: %w", err)
 	}
 
-	if s.pollCtx.Err() != nil {
-		s.logger.Info("Parser service polling context already done.")
-		if s.stopChan != nil {
-			s.logger.Info("Waiting for pollBlocks to confirm stop due to already done context...")
-			select {
-			case <-s.stopChan:
-				s.logger.Info("pollBlocks confirmed stop.")
-			case <-ctx.Done():
-				s.logger.Error("Parser service stop timed out while waiting for pollBlocks confirmation.", "error", ctx.Err())
-				return ctx.Err()
-			}
+	s.logger.Info("Connected to Ethereum node", "chainId", chainID)
+	if s.expectedChainID != 0 && chainID != s.expectedChainID {
+		s.logger.Error("Node serves an unexpected chain, refusing to start",
+			"chainId", chainID,
+			"expectedChainId", s.expectedChainID)
+		return fmt.Errorf("%w: node reports %d, expected %d", ErrChainIDMismatch, chainID, s.expectedChainID)
+	}
+	return nil
+}
+
+// pollLoopExited reports whether the polling loop of the last Start has returned on its own,
+// after its context was cancelled or a backfill completed.
+func (s *ParserServiceImpl) pollLoopExited() bool {
+	select {
+	case <-s.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// startFromHead uses the current network head as the starting point, deferring it to the first successful
+// poll if the node is unavailable and startOnNodeError is set.
+func (s *ParserServiceImpl) startFromHead(ctx context.Context) error {
+	s.logger.Info("Attempting to fetch latest block from network to determine starting point...")
+	latestNetBlock, errNet := s.fetchHeadBlockNumber(ctx)
+	if errNet != nil {
+		if !s.startOnNodeError {
+			s.logger.Error("Failed to fetch latest block number from network, refusing to start", "error", errNet)
+			return fmt.Errorf("failed to fetch latest block number at startup: %w", errNet)
 		}
+		s.logger.Warn("Failed to fetch latest block number from network, deferring starting point to first successful poll",
+			"error", errNet)
+		s.startBlockPending = true
+		return nil
+	}
+
+	s.setLastKnownBlock(latestNetBlock)
+	s.logger.Info("Starting scan from latest network block", "blockNumber", s.lastKnownBlock.Value())
+	s.storeInitialBlock(ctx)
+	return nil
+}
+
+// Done returns a channel that is closed once a backfill has scanned its whole block range.
+// In follow mode the channel is never closed.
+func (s *ParserServiceImpl) Done() <-chan struct{} {
+	return s.done
+}
+
+// setLastKnownBlock records the last block the scanner itself persisted as the parser state.
+func (s *ParserServiceImpl) setLastKnownBlock(blockNumber domain.BlockNumber) {
+	s.lastKnownBlock = blockNumber
+	s.lastKnownBlockSet = true
+}
+
+// storeInitialBlock persists lastKnownBlock as the initial parser state.
+func (s *ParserServiceImpl) storeInitialBlock(ctx context.Context) {
+	if errSet := s.stateRepo.SetCurrentBlock(ctx, s.lastKnownBlock); errSet != nil {
+		s.logger.Error("Failed to set initial parser state in repository",
+			"error", errSet,
+			"blockNumber", s.lastKnownBlock.Value())
+	} else {
+		s.logger.Info("Initial parser state set in repository", "blockNumber", s.lastKnownBlock.Value())
+	}
+}
+
+// Stop waits for the background polling process to shut down after its context is cancelled.
+// Stopping an idle service is a no-op; a concurrent second Stop fails with ethparser.ErrParserStopping.
+// If ctx expires first, the service stays running and Stop may be called again.
+func (s *ParserServiceImpl) Stop(ctx context.Context) (err error) {
+	s.lifecycleMu.Lock()
+	switch s.state {
+	case stateIdle:
+		s.lifecycleMu.Unlock()
+		s.logger.Info("Parser service was not started or already stopped.")
 		return nil
+	case stateStopping:
+		s.lifecycleMu.Unlock()
+		return ethparser.ErrParserStopping
 	}
+	s.state = stateStopping
+	stopChan := s.stopChan
+	s.lifecycleMu.Unlock()
 
-	s.logger.Info("Stopping parser service (external Stop call)...")
+	s.logger.Info("Stopping parser service, waiting for the polling loop to finish...")
 	select {
-	case <-s.stopChan:
-		s.logger.Info("Parser service stopped gracefully (via external Stop call).")
+	case <-stopChan:
+		s.setState(stateIdle)
+		s.logger.Info("Parser service stopped gracefully.")
 		return nil
 	case <-ctx.Done():
-		s.logger.Error("Parser service stop timed out (via external Stop call).", "error", ctx.Err())
+		s.setState(stateRunning)
+		s.logger.Error("Parser service stop timed out.", "error", ctx.Err())
 		return ctx.Err()
 	}
 }
+
+// setState moves the service to the given lifecycle state.
+func (s *ParserServiceImpl) setState(state serviceState) {
+	s.lifecycleMu.Lock()
+	defer s.lifecycleMu.Unlock()
+	s.state = state
+}
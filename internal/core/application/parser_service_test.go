@@ -3,18 +3,29 @@ package application_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"math"
+	"sync"
 	"testing"
+	"time"
 
+	"trust_wallet_homework/internal/adapters/storage/memory/address"
+	"trust_wallet_homework/internal/adapters/storage/memory/parser_state"
+	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
 	"trust_wallet_homework/internal/config"
 	"trust_wallet_homework/internal/core/application"
 	"trust_wallet_homework/internal/core/application/mocks/mock_client"
 	"trust_wallet_homework/internal/core/application/mocks/mock_repository"
 	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
 	applogger "trust_wallet_homework/internal/logger"
+	"trust_wallet_homework/pkg/ethparser"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParserServiceImpl_GetCurrentBlock(t *testing.T) {
@@ -54,9 +65,10 @@ func TestParserServiceImpl_Subscribe(t *testing.T) {
 	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
 	domainAddr, _ := domain.NewAddress(validAddrStr)
 
-	mockAddrRepo.On("Add", ctx, domainAddr).Return(nil)
+	mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+	mockAddrRepo.On("Add", ctx, domainAddr, domain.SubscriptionDirectionBoth).Return(nil)
 
-	err := service.Subscribe(ctx, validAddrStr)
+	err := service.Subscribe(ctx, validAddrStr, "")
 	assert.NoError(t, err)
 
 	mockAddrRepo.AssertExpectations(t)
@@ -68,7 +80,7 @@ func TestParserServiceImpl_Subscribe_InvalidAddress(t *testing.T) {
 	ctx := context.Background()
 	invalidAddrStr := "0xinvalid"
 
-	err := service.Subscribe(ctx, invalidAddrStr)
+	err := service.Subscribe(ctx, invalidAddrStr, "")
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, domain.ErrInvalidAddressFormat), "Error should wrap domain.ErrInvalidAddressFormat")
 }
@@ -81,19 +93,993 @@ func TestParserServiceImpl_Subscribe_RepoError(t *testing.T) {
 	domainAddr, _ := domain.NewAddress(validAddrStr)
 	wantErr := errors.New("repo error")
 
-	mockAddrRepo.On("Add", ctx, domainAddr).Return(wantErr)
+	mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+	mockAddrRepo.On("Add", ctx, domainAddr, domain.SubscriptionDirectionBoth).Return(wantErr)
 
-	err := service.Subscribe(ctx, validAddrStr)
+	err := service.Subscribe(ctx, validAddrStr, "")
 	assert.Error(t, err)
 
 	mockAddrRepo.AssertExpectations(t)
 }
 
+func TestParserServiceImpl_Subscribe_AlreadySubscribed(t *testing.T) {
+	service, _, mockAddrRepo := setupBasicService(t)
+
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	mockAddrRepo.On("Exists", ctx, domainAddr).Return(true, nil)
+
+	err := service.Subscribe(ctx, validAddrStr, "")
+	assert.ErrorIs(t, err, ethparser.ErrAddressAlreadySubscribed)
+	mockAddrRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParserServiceImpl_Subscribe_Idempotent(t *testing.T) {
+	cfg := config.ApplicationServiceConfig{PollingIntervalSeconds: 1, IdempotentSubscribe: true}
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	t.Run("New address is added", func(t *testing.T) {
+		service, _, mockAddrRepo := setupBasicServiceWithConfig(t, cfg)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+		mockAddrRepo.On("Add", ctx, domainAddr, domain.SubscriptionDirectionBoth).Return(nil)
+
+		require.NoError(t, service.Subscribe(ctx, validAddrStr, ""))
+		mockAddrRepo.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate address succeeds without changing the subscription", func(t *testing.T) {
+		service, _, mockAddrRepo := setupBasicServiceWithConfig(t, cfg)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(true, nil)
+
+		require.NoError(t, service.Subscribe(ctx, validAddrStr, "in"))
+		mockAddrRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Duplicate address in a bulk request is reported as success", func(t *testing.T) {
+		service, _, mockAddrRepo := setupBasicServiceWithConfig(t, cfg)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(true, nil)
+
+		results, err := service.SubscribeMany(ctx, []string{validAddrStr}, "")
+		require.NoError(t, err)
+		assert.Equal(t, []ethparser.SubscribeResult{{Address: validAddrStr, Success: true}}, results)
+		mockAddrRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestParserServiceImpl_Subscribe_SubscriptionLimit(t *testing.T) {
+	cfg := config.ApplicationServiceConfig{PollingIntervalSeconds: 1, MaxSubscriptions: 2}
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	t.Run("Below the limit", func(t *testing.T) {
+		service, _, mockAddrRepo := setupBasicServiceWithConfig(t, cfg)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+		mockAddrRepo.On("Count", ctx).Return(1, nil)
+		mockAddrRepo.On("Add", ctx, domainAddr, domain.SubscriptionDirectionBoth).Return(nil)
+
+		require.NoError(t, service.Subscribe(ctx, validAddrStr, ""))
+		mockAddrRepo.AssertExpectations(t)
+	})
+
+	t.Run("At the limit", func(t *testing.T) {
+		service, _, mockAddrRepo := setupBasicServiceWithConfig(t, cfg)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+		mockAddrRepo.On("Count", ctx).Return(2, nil)
+
+		err := service.Subscribe(ctx, validAddrStr, "")
+		assert.ErrorIs(t, err, ethparser.ErrSubscriptionLimitReached)
+		mockAddrRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Existing subscription at the limit is still reported as a duplicate", func(t *testing.T) {
+		service, _, mockAddrRepo := setupBasicServiceWithConfig(t, cfg)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(true, nil)
+
+		err := service.Subscribe(ctx, validAddrStr, "")
+		assert.ErrorIs(t, err, ethparser.ErrAddressAlreadySubscribed)
+		mockAddrRepo.AssertNotCalled(t, "Count", mock.Anything)
+	})
+
+	t.Run("Count error", func(t *testing.T) {
+		service, _, mockAddrRepo := setupBasicServiceWithConfig(t, cfg)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+		mockAddrRepo.On("Count", ctx).Return(0, errors.New("repo error"))
+
+		err := service.Subscribe(ctx, validAddrStr, "")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ethparser.ErrSubscriptionLimitReached)
+	})
+
+	t.Run("Bulk request stops adding at the limit", func(t *testing.T) {
+		service, _, mockAddrRepo := setupBasicServiceWithConfig(t, cfg)
+		otherAddrStr := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		otherAddr, _ := domain.NewAddress(otherAddrStr)
+		mockAddrRepo.On("Exists", ctx, mock.Anything).Return(false, nil)
+		mockAddrRepo.On("Count", ctx).Return(1, nil).Once()
+		mockAddrRepo.On("Count", ctx).Return(2, nil).Once()
+		mockAddrRepo.On("Add", ctx, domainAddr, domain.SubscriptionDirectionBoth).Return(nil).Once()
+
+		results, err := service.SubscribeMany(ctx, []string{validAddrStr, otherAddrStr}, "")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.True(t, results[0].Success)
+		assert.False(t, results[1].Success)
+		assert.Equal(t, otherAddr.String(), results[1].Address)
+		assert.Contains(t, results[1].Error, ethparser.ErrSubscriptionLimitReached.Error())
+	})
+}
+
+func TestParserServiceImpl_Subscribe_WithDirection(t *testing.T) {
+	service, _, mockAddrRepo := setupBasicService(t)
+
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+	mockAddrRepo.On("Add", ctx, domainAddr, domain.SubscriptionDirectionIn).Return(nil)
+
+	err := service.Subscribe(ctx, validAddrStr, "in")
+	assert.NoError(t, err)
+
+	mockAddrRepo.AssertExpectations(t)
+}
+
+func TestParserServiceImpl_Subscribe_InvalidDirection(t *testing.T) {
+	service, _, mockAddrRepo := setupBasicService(t)
+
+	err := service.Subscribe(context.Background(), "0x71c7656ec7ab88b098defb751b7401b5f6d8976f", "sideways")
+	assert.ErrorIs(t, err, domain.ErrInvalidSubscriptionDirection)
+	mockAddrRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParserServiceImpl_SubscribeMany_MixedInput(t *testing.T) {
+	service, _, mockAddrRepo := setupBasicService(t)
+
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	dupAddrStr := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	dupAddr, _ := domain.NewAddress(dupAddrStr)
+
+	mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil).Once()
+	mockAddrRepo.On("Exists", ctx, dupAddr).Return(true, nil).Once()
+	mockAddrRepo.On("Add", ctx, domainAddr, domain.SubscriptionDirectionBoth).Return(nil).Once()
+
+	results, err := service.SubscribeMany(ctx, []string{validAddrStr, "0xinvalid", dupAddrStr}, "")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.True(t, results[0].Success)
+	assert.Equal(t, validAddrStr, results[0].Address)
+	assert.False(t, results[1].Success)
+	assert.Equal(t, "0xinvalid", results[1].Address)
+	assert.NotEmpty(t, results[1].Error)
+	assert.False(t, results[2].Success)
+	assert.Equal(t, ethparser.ErrAddressAlreadySubscribed.Error(), results[2].Error)
+}
+
+func TestParserServiceImpl_SubscribeMany_RepoError(t *testing.T) {
+	service, _, mockAddrRepo := setupBasicService(t)
+
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+	mockAddrRepo.On("Add", ctx, domainAddr, domain.SubscriptionDirectionBoth).Return(errors.New("repo error"))
+
+	_, err := service.SubscribeMany(ctx, []string{validAddrStr}, "")
+	assert.Error(t, err)
+}
+
+func TestParserServiceImpl_LastActivity(t *testing.T) {
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	t.Run("Address with activity", func(t *testing.T) {
+		service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+		other, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+		hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+		value, _ := domain.NewWeiValue("0x1")
+		block, _ := domain.NewBlockNumber(42)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(true, nil)
+		mockTxRepo.On("FindLatestByAddress", ctx, domainAddr).
+			Return(domain.NewTransaction(hash, other, domainAddr, value, block, 1700000000), nil)
+
+		blockNumber, timestamp, err := service.LastActivity(ctx, validAddrStr)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), blockNumber)
+		assert.Equal(t, uint64(1700000000), timestamp)
+	})
+
+	t.Run("Address without activity", func(t *testing.T) {
+		service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(true, nil)
+		mockTxRepo.On("FindLatestByAddress", ctx, domainAddr).
+			Return(domain.Transaction{}, repository.ErrTransactionNotFound)
+
+		blockNumber, timestamp, err := service.LastActivity(ctx, validAddrStr)
+		require.NoError(t, err)
+		assert.Zero(t, blockNumber)
+		assert.Zero(t, timestamp)
+	})
+
+	t.Run("Address not subscribed", func(t *testing.T) {
+		service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+
+		_, _, err := service.LastActivity(ctx, validAddrStr)
+		assert.ErrorIs(t, err, ethparser.ErrAddressNotSubscribed)
+		mockTxRepo.AssertNotCalled(t, "FindLatestByAddress", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+		mockAddrRepo.On("Exists", ctx, domainAddr).Return(true, nil)
+		mockTxRepo.On("FindLatestByAddress", ctx, domainAddr).Return(domain.Transaction{}, errors.New("repo error"))
+
+		_, _, err := service.LastActivity(ctx, validAddrStr)
+		assert.Error(t, err)
+	})
+}
+
+func TestParserServiceImpl_GetTransactionCount(t *testing.T) {
+	service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	mockAddrRepo.On("Exists", ctx, domainAddr).Return(true, nil)
+	mockTxRepo.On("CountByAddress", ctx, domainAddr).Return(7, nil)
+
+	got, err := service.GetTransactionCount(ctx, validAddrStr)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, got)
+}
+
+func TestParserServiceImpl_GetTransactionCount_InvalidAddress(t *testing.T) {
+	service, _, _ := setupServiceWithTxRepo(t)
+
+	_, err := service.GetTransactionCount(context.Background(), "0xinvalid")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrInvalidAddressFormat), "Error should wrap domain.ErrInvalidAddressFormat")
+}
+
+func TestParserServiceImpl_GetTransactionByHash(t *testing.T) {
+	const hashStr = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	hash, _ := domain.NewTransactionHash(hashStr)
+	from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	to, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(10)
+	tx := domain.NewTransaction(hash, from, to, value, blockNum, 1000)
+	toStr := to.String()
+
+	t.Run("Found", func(t *testing.T) {
+		service, _, mockTxRepo := setupServiceWithTxRepo(t)
+		mockTxRepo.On("FindByHash", mock.Anything, hash).Return(tx, nil)
+
+		got, err := service.GetTransactionByHash(context.Background(), hashStr)
+		require.NoError(t, err)
+		assert.Equal(t, &ethparser.Transaction{
+			Hash:        hashStr,
+			From:        from.String(),
+			To:          &toStr,
+			Value:       "0x1",
+			BlockNumber: 10,
+			Timestamp:   1000,
+		}, got)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		service, _, mockTxRepo := setupServiceWithTxRepo(t)
+		mockTxRepo.On("FindByHash", mock.Anything, hash).Return(domain.Transaction{}, repository.ErrTransactionNotFound)
+
+		_, err := service.GetTransactionByHash(context.Background(), hashStr)
+		assert.ErrorIs(t, err, ethparser.ErrTransactionNotFound)
+	})
+
+	t.Run("Invalid hash", func(t *testing.T) {
+		service, _, _ := setupServiceWithTxRepo(t)
+
+		_, err := service.GetTransactionByHash(context.Background(), "0x1234")
+		assert.ErrorIs(t, err, domain.ErrInvalidTransactionHashFormat)
+	})
+}
+
+func TestParserServiceImpl_GetTransactionsInBlock(t *testing.T) {
+	service, _, mockTxRepo := setupServiceWithTxRepo(t)
+
+	ctx := context.Background()
+	from, _ := domain.NewAddress("0x71c7656ec7ab88b098defb751b7401b5f6d8976f")
+	to, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(42)
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+
+	mockTxRepo.On("FindByBlock", ctx, blockNum).
+		Return([]domain.Transaction{domain.NewTransaction(hash, from, to, value, blockNum, 1000)}, nil)
+
+	txs, err := service.GetTransactionsInBlock(ctx, 42)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, hash.String(), txs[0].Hash)
+	assert.Equal(t, int64(42), txs[0].BlockNumber)
+	assert.Empty(t, txs[0].Direction)
+
+	_, err = service.GetTransactionsInBlock(ctx, -1)
+	assert.ErrorIs(t, err, domain.ErrNegativeBlockNumber)
+}
+
+func TestParserServiceImpl_GetTransactions_Direction(t *testing.T) {
+	service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+
+	ctx := context.Background()
+	queried, _ := domain.NewAddress("0x71c7656ec7ab88b098defb751b7401b5f6d8976f")
+	other, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(1)
+	inHash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	outHash, _ := domain.NewTransactionHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	selfHash, _ := domain.NewTransactionHash("0x3333333333333333333333333333333333333333333333333333333333333333")
+
+	stored := []domain.Transaction{
+		domain.NewTransaction(inHash, other, queried, value, blockNum, 1000),
+		domain.NewTransaction(outHash, queried, other, value, blockNum, 1000),
+		domain.NewTransaction(selfHash, queried, queried, value, blockNum, 1000),
+	}
+	mockAddrRepo.On("Exists", ctx, queried).Return(true, nil)
+	mockTxRepo.On("FindByAddress", ctx, queried).Return(stored, nil)
+
+	txs, err := service.GetTransactions(ctx, queried.String())
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	assert.Equal(t, ethparser.DirectionIn, txs[0].Direction)
+	assert.Equal(t, ethparser.DirectionOut, txs[1].Direction)
+	assert.Equal(t, ethparser.DirectionSelf, txs[2].Direction)
+}
+
+func TestParserServiceImpl_GetTransactions_NotSubscribed(t *testing.T) {
+	service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+
+	ctx := context.Background()
+	validAddrStr := "0x71c7656ec7ab88b098defb751b7401b5f6d8976f"
+	domainAddr, _ := domain.NewAddress(validAddrStr)
+
+	mockAddrRepo.On("Exists", ctx, domainAddr).Return(false, nil)
+
+	_, err := service.GetTransactions(ctx, validAddrStr)
+	assert.ErrorIs(t, err, ethparser.ErrAddressNotSubscribed)
+	mockTxRepo.AssertNotCalled(t, "FindByAddress", mock.Anything, mock.Anything)
+}
+
+func TestParserServiceImpl_GetTransactionsForAddresses(t *testing.T) {
+	service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+
+	ctx := context.Background()
+	active, _ := domain.NewAddress("0x71c7656ec7ab88b098defb751b7401b5f6d8976f")
+	idle, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	value, _ := domain.NewWeiValue("0x1")
+	blockNum, _ := domain.NewBlockNumber(1)
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+
+	mockAddrRepo.On("Exists", ctx, active).Return(true, nil).Once()
+	mockAddrRepo.On("Exists", ctx, idle).Return(true, nil).Once()
+	mockTxRepo.On("FindByAddress", ctx, active).
+		Return([]domain.Transaction{domain.NewTransaction(hash, other, active, value, blockNum, 1000)}, nil).Once()
+	mockTxRepo.On("FindByAddress", ctx, idle).Return([]domain.Transaction{}, nil).Once()
+
+	got, err := service.GetTransactionsForAddresses(ctx, []string{
+		active.String(),
+		idle.String(),
+		"0x71C7656EC7AB88B098DEFB751B7401B5F6D8976F",
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Len(t, got[active.String()], 1)
+	assert.Equal(t, ethparser.DirectionIn, got[active.String()][0].Direction)
+	assert.NotNil(t, got[idle.String()])
+	assert.Empty(t, got[idle.String()])
+	mockAddrRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestParserServiceImpl_GetTransactionsForAddresses_Errors(t *testing.T) {
+	ctx := context.Background()
+	subscribed, _ := domain.NewAddress("0x71c7656ec7ab88b098defb751b7401b5f6d8976f")
+	unsubscribed, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	t.Run("Invalid address", func(t *testing.T) {
+		service, _, mockTxRepo := setupServiceWithTxRepo(t)
+
+		_, err := service.GetTransactionsForAddresses(ctx, []string{subscribed.String(), "0x1234"})
+		assert.ErrorIs(t, err, domain.ErrInvalidAddressFormat)
+		mockTxRepo.AssertNotCalled(t, "FindByAddress", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Address not subscribed", func(t *testing.T) {
+		service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+		mockAddrRepo.On("Exists", ctx, subscribed).Return(true, nil)
+		mockAddrRepo.On("Exists", ctx, unsubscribed).Return(false, nil)
+		mockTxRepo.On("FindByAddress", ctx, subscribed).Return([]domain.Transaction{}, nil)
+
+		_, err := service.GetTransactionsForAddresses(ctx, []string{subscribed.String(), unsubscribed.String()})
+		assert.ErrorIs(t, err, ethparser.ErrAddressNotSubscribed)
+	})
+}
+
+func TestParserServiceImpl_GetTransactionsInRange(t *testing.T) {
+	service, mockAddrRepo, mockTxRepo := setupServiceWithTxRepo(t)
+
+	ctx := context.Background()
+	queried, _ := domain.NewAddress("0x71c7656ec7ab88b098defb751b7401b5f6d8976f")
+	fromBlock, _ := domain.NewBlockNumber(10)
+	toBlock, _ := domain.NewBlockNumber(20)
+
+	mockAddrRepo.On("Exists", ctx, queried).Return(true, nil)
+	mockTxRepo.On("FindByAddressInBlockRange", ctx, queried, fromBlock, toBlock).Return([]domain.Transaction{}, nil)
+
+	txs, err := service.GetTransactionsInRange(ctx, queried.String(), 10, 20)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+}
+
+func TestParserServiceImpl_GetTransactionsInRange_InvalidBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int64
+		wantErr  error
+	}{
+		{name: "Negative from", from: -1, to: 10, wantErr: domain.ErrNegativeBlockNumber},
+		{name: "Negative to", from: 0, to: -5, wantErr: domain.ErrNegativeBlockNumber},
+		{name: "From greater than to", from: 20, to: 10, wantErr: ethparser.ErrInvalidBlockRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, mockTxRepo := setupServiceWithTxRepo(t)
+
+			_, err := service.GetTransactionsInRange(
+				context.Background(), "0x71c7656ec7ab88b098defb751b7401b5f6d8976f", tt.from, tt.to)
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockTxRepo.AssertNotCalled(t, "FindByAddressInBlockRange",
+				mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestParserServiceImpl_GetBlock_NotFound(t *testing.T) {
+	service, _, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+
+	ctx := context.Background()
+	blockNum, _ := domain.NewBlockNumber(10)
+	mockEthClient.On("GetBlockWithTransactions", ctx, blockNum).Return(nil, nil)
+
+	_, err := service.GetBlock(ctx, 10)
+	assert.ErrorIs(t, err, ethparser.ErrBlockNotFound)
+}
+
+func TestParserServiceImpl_GetBlock_Negative(t *testing.T) {
+	service, _, _ := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+
+	_, err := service.GetBlock(context.Background(), -1)
+	assert.ErrorIs(t, err, domain.ErrNegativeBlockNumber)
+}
+
+func TestParserServiceImpl_ListAddressesWithTransactions(t *testing.T) {
+	service, _, mockTxRepo := setupServiceWithTxRepo(t)
+	ctx := context.Background()
+
+	first, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	second, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	mockTxRepo.On("FindAddressesWithTransactions", ctx).Return([]domain.Address{first, second}, nil).Once()
+
+	addresses, err := service.ListAddressesWithTransactions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.String(), second.String()}, addresses)
+
+	repoErr := errors.New("storage unavailable")
+	mockTxRepo.On("FindAddressesWithTransactions", ctx).Return(nil, repoErr).Once()
+	_, err = service.ListAddressesWithTransactions(ctx)
+	assert.ErrorIs(t, err, repoErr)
+}
+
+func TestParserServiceImpl_ListSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	first, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	second, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+
+	t.Run("Page of addresses", func(t *testing.T) {
+		service, mockAddrRepo, _ := setupServiceWithTxRepo(t)
+		mockAddrRepo.On("Count", ctx).Return(5, nil).Once()
+		mockAddrRepo.On("FindPage", ctx, 2, 1).Return([]domain.Address{first, second}, nil).Once()
+
+		addresses, total, err := service.ListSubscriptions(ctx, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.String(), second.String()}, addresses)
+		assert.Equal(t, 5, total)
+	})
+
+	t.Run("Invalid pagination", func(t *testing.T) {
+		service, _, _ := setupServiceWithTxRepo(t)
+		_, _, err := service.ListSubscriptions(ctx, 0, 0)
+		assert.ErrorIs(t, err, ethparser.ErrInvalidPagination)
+		_, _, err = service.ListSubscriptions(ctx, 10, -1)
+		assert.ErrorIs(t, err, ethparser.ErrInvalidPagination)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		service, mockAddrRepo, _ := setupServiceWithTxRepo(t)
+		repoErr := errors.New("storage unavailable")
+		mockAddrRepo.On("Count", ctx).Return(2, nil).Once()
+		mockAddrRepo.On("FindPage", ctx, 10, 0).Return(nil, repoErr).Once()
+
+		_, _, err := service.ListSubscriptions(ctx, 10, 0)
+		assert.ErrorIs(t, err, repoErr)
+	})
+}
+
+func TestParserServiceImpl_GetBlockByHash(t *testing.T) {
+	const hashStr = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	ctx := context.Background()
+	hash, _ := domain.NewBlockHash(hashStr)
+
+	t.Run("Found", func(t *testing.T) {
+		service, _, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+		blockNum, _ := domain.NewBlockNumber(42)
+		block := domain.NewBlock(blockNum, hash, 1000, nil)
+		mockEthClient.On("GetBlockByHash", ctx, hash).Return(&block, nil)
+
+		got, err := service.GetBlockByHash(ctx, hashStr)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), got.Number)
+		assert.Equal(t, hashStr, got.Hash)
+		assert.Equal(t, uint64(1000), got.Timestamp)
+	})
+
+	t.Run("Unknown to the node", func(t *testing.T) {
+		service, _, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+		mockEthClient.On("GetBlockByHash", ctx, hash).Return(nil, nil)
+
+		_, err := service.GetBlockByHash(ctx, hashStr)
+		assert.ErrorIs(t, err, ethparser.ErrBlockNotFound)
+	})
+
+	t.Run("Invalid hash", func(t *testing.T) {
+		service, _, _ := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+
+		_, err := service.GetBlockByHash(ctx, "0x1234")
+		assert.ErrorIs(t, err, domain.ErrInvalidBlockHashFormat)
+	})
+
+	t.Run("Node error", func(t *testing.T) {
+		service, _, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+		nodeErr := errors.New("RPC call failed")
+		mockEthClient.On("GetBlockByHash", ctx, hash).Return(nil, nodeErr)
+
+		_, err := service.GetBlockByHash(ctx, hashStr)
+		assert.ErrorIs(t, err, nodeErr)
+	})
+}
+
+func TestParserServiceImpl_GetNodeTransaction(t *testing.T) {
+	const hashStr = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	ctx := context.Background()
+	hash, _ := domain.NewTransactionHash(hashStr)
+
+	t.Run("Found", func(t *testing.T) {
+		service, _, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+		from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+		value, _ := domain.NewWeiValue("0x1")
+		blockNum, _ := domain.NewBlockNumber(42)
+		tx := domain.NewTransaction(hash, from, domain.Address{}, value, blockNum, 0)
+		mockEthClient.On("GetTransactionByHash", ctx, hash).Return(&tx, nil)
+
+		got, err := service.GetNodeTransaction(ctx, hashStr)
+		require.NoError(t, err)
+		assert.Equal(t, hashStr, got.Hash)
+		assert.Equal(t, int64(42), got.BlockNumber)
+		assert.Nil(t, got.To)
+		assert.Empty(t, got.Direction)
+	})
+
+	t.Run("Unknown to the node", func(t *testing.T) {
+		service, _, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+		mockEthClient.On("GetTransactionByHash", ctx, hash).Return(nil, nil)
+
+		_, err := service.GetNodeTransaction(ctx, hashStr)
+		assert.ErrorIs(t, err, ethparser.ErrTransactionNotFound)
+	})
+
+	t.Run("Invalid hash", func(t *testing.T) {
+		service, _, _ := setupServiceWithClient(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 1})
+
+		_, err := service.GetNodeTransaction(ctx, "0x1234")
+		assert.ErrorIs(t, err, domain.ErrInvalidTransactionHashFormat)
+	})
+}
+
+func TestParserServiceImpl_Start_NodeErrorRefusesToStart(t *testing.T) {
+	service, mockStateRepo, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 1,
+		StartOnNodeError:       false,
+	})
+
+	ctx := context.Background()
+	nodeErr := errors.New("node unavailable")
+	mockEthClient.On("GetChainID", ctx).Return(int64(0), nodeErr)
+	mockEthClient.On("GetLatestBlockNumber", ctx).Return(domain.BlockNumber{}, nodeErr)
+
+	err := service.Start(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, nodeErr)
+	mockStateRepo.AssertNotCalled(t, "SetCurrentBlock", mock.Anything, mock.Anything)
+}
+
+func TestParserServiceImpl_Start_ChainIDMismatchRefusesToStart(t *testing.T) {
+	service, mockStateRepo, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 1,
+		StartOnNodeError:       true,
+	}, application.WithExpectedChainID(1))
+
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(11155111), nil)
+
+	err := service.Start(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, application.ErrChainIDMismatch)
+	mockEthClient.AssertNotCalled(t, "GetLatestBlockNumber", mock.Anything)
+	mockStateRepo.AssertNotCalled(t, "SetCurrentBlock", mock.Anything, mock.Anything)
+}
+
+func TestParserServiceImpl_Start_ChainIDUnavailableRefusesToStartWhenExpected(t *testing.T) {
+	service, mockStateRepo, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 1,
+	}, application.WithExpectedChainID(1))
+
+	nodeErr := errors.New("node unavailable")
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(0), nodeErr)
+
+	err := service.Start(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, nodeErr)
+	mockStateRepo.AssertNotCalled(t, "SetCurrentBlock", mock.Anything, mock.Anything)
+}
+
+func TestParserServiceImpl_Start_ChainIDMatchStarts(t *testing.T) {
+	service, mockStateRepo, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 60,
+	}, application.WithExpectedChainID(1))
+
+	latest, _ := domain.NewBlockNumber(50)
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(1), nil)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockStateRepo.On("SetCurrentBlock", mock.Anything, latest).Return(nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, service.Start(ctx))
+
+	cancel()
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelStop()
+	require.NoError(t, service.Stop(stopCtx))
+}
+
+func TestParserServiceImpl_Start_NodeErrorDefersStartingBlock(t *testing.T) {
+	service, mockStateRepo, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 1,
+		StartOnNodeError:       true,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	latest, _ := domain.NewBlockNumber(50)
+	stateSet := make(chan struct{})
+
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(0), errors.New("node unavailable"))
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).
+		Return(domain.BlockNumber{}, errors.New("node unavailable")).Once()
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil).Once()
+	mockStateRepo.On("SetCurrentBlock", mock.Anything, latest).
+		Run(func(mock.Arguments) { close(stateSet) }).
+		Return(nil).Once()
+
+	require.NoError(t, service.Start(ctx))
+
+	select {
+	case <-stateSet:
+	case <-time.After(2 * time.Second):
+		t.Fatal("starting block was not resolved after the node recovered")
+	}
+
+	cancel()
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelStop()
+	require.NoError(t, service.Stop(stopCtx))
+}
+
+func TestParserServiceImpl_Start_ConcurrentCallsStartOneLoop(t *testing.T) {
+	service, mockStateRepo, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 60,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	latest, _ := domain.NewBlockNumber(50)
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(1), nil)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockStateRepo.On("SetCurrentBlock", mock.Anything, latest).Return(nil).Once()
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- service.Start(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	started := 0
+	for err := range errs {
+		if err == nil {
+			started++
+			continue
+		}
+		assert.ErrorIs(t, err, ethparser.ErrParserAlreadyRunning)
+	}
+	assert.Equal(t, 1, started)
+
+	cancel()
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelStop()
+	require.NoError(t, service.Stop(stopCtx))
+	// Only the single started loop scanned: one head fetch at startup and one in its first iteration.
+	mockEthClient.AssertNumberOfCalls(t, "GetLatestBlockNumber", 2)
+}
+
+func TestParserServiceImpl_StartStopTransitions(t *testing.T) {
+	service, mockStateRepo, mockEthClient := setupServiceWithClient(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 60,
+	})
+	latest, _ := domain.NewBlockNumber(50)
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(1), nil)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil)
+	mockStateRepo.On("SetCurrentBlock", mock.Anything, latest).Return(nil)
+
+	require.NoError(t, service.Stop(context.Background()), "stopping an idle service is a no-op")
+
+	for round := 0; round < 2; round++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		require.NoError(t, service.Start(ctx), "round %d", round)
+		assert.ErrorIs(t, service.Start(ctx), ethparser.ErrParserAlreadyRunning)
+
+		shortCtx, cancelShort := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		assert.ErrorIs(t, service.Stop(shortCtx), context.DeadlineExceeded, "the loop runs until its context is cancelled")
+		cancelShort()
+
+		cancel()
+		stopCtx, cancelStop := context.WithTimeout(context.Background(), 2*time.Second)
+		require.NoError(t, service.Stop(stopCtx))
+		cancelStop()
+	}
+}
+
+func TestParserServiceImpl_QueryTransactions(t *testing.T) {
+	ctx := context.Background()
+	addrRepo := address.NewInMemoryAddressRepo()
+	txRepo := transaction.NewInMemoryTransactionRepo()
+	service, err := application.NewParserService(
+		parser_state.NewInMemoryParserStateRepo(),
+		addrRepo,
+		txRepo,
+		mock_client.NewEthereumClient(t),
+		applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		config.ApplicationServiceConfig{PollingIntervalSeconds: 1},
+	)
+	require.NoError(t, err)
+
+	const addr = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	subscribed, _ := domain.NewAddress(addr)
+	counterparty, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, addrRepo.Add(ctx, subscribed, domain.SubscriptionDirectionBoth))
+
+	// Stored in block order; the transactions in blocks 3 and 4 have equal values.
+	values := map[int64]string{1: "0x5", 2: "100", 3: "0x32", 4: "50", 5: "0x1"}
+	for block := int64(1); block <= 5; block++ {
+		hash, _ := domain.NewTransactionHash(fmt.Sprintf("0x%064x", block))
+		value, _ := domain.NewWeiValue(values[block])
+		blockNum, _ := domain.NewBlockNumber(block)
+		require.NoError(t, txRepo.Store(ctx, domain.NewTransaction(hash, subscribed, counterparty, value, blockNum, 1000)))
+	}
+
+	tests := []struct {
+		name       string
+		query      ethparser.TransactionQuery
+		wantBlocks []int64
+		wantErr    error
+	}{
+		{name: "No filter keeps storage order", query: ethparser.TransactionQuery{}, wantBlocks: []int64{1, 2, 3, 4, 5}},
+		{name: "Decimal threshold is inclusive", query: ethparser.TransactionQuery{MinValue: "50"}, wantBlocks: []int64{2, 3, 4}},
+		{name: "Hex threshold", query: ethparser.TransactionQuery{MinValue: "0x33"}, wantBlocks: []int64{2}},
+		{name: "Value descending, ties newest first", query: ethparser.TransactionQuery{Sort: ethparser.SortValueDesc}, wantBlocks: []int64{2, 4, 3, 1, 5}},
+		{name: "Value ascending, ties newest first", query: ethparser.TransactionQuery{Sort: ethparser.SortValueAsc}, wantBlocks: []int64{5, 1, 4, 3, 2}},
+		{name: "Block descending", query: ethparser.TransactionQuery{Sort: ethparser.SortBlockDesc}, wantBlocks: []int64{5, 4, 3, 2, 1}},
+		{
+			name:       "Threshold, sort and block range combined",
+			query:      ethparser.TransactionQuery{FromBlock: 2, ToBlock: 4, MinValue: "0x6", Sort: ethparser.SortValueAsc},
+			wantBlocks: []int64{4, 3, 2},
+		},
+		{name: "Invalid sort order", query: ethparser.TransactionQuery{Sort: "hash"}, wantErr: ethparser.ErrInvalidSortOrder},
+		{name: "Invalid threshold", query: ethparser.TransactionQuery{MinValue: "ten"}, wantErr: domain.ErrInvalidWeiValueFormat},
+		{name: "Negative threshold", query: ethparser.TransactionQuery{MinValue: "-1"}, wantErr: domain.ErrNegativeWeiValue},
+		{name: "Inverted block range", query: ethparser.TransactionQuery{FromBlock: 4, ToBlock: 2}, wantErr: ethparser.ErrInvalidBlockRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.query
+			if query.ToBlock == 0 && query.FromBlock == 0 {
+				query.ToBlock = math.MaxInt64
+			}
+			txs, err := service.QueryTransactions(ctx, addr, query)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			gotBlocks := make([]int64, 0, len(txs))
+			for _, tx := range txs {
+				gotBlocks = append(gotBlocks, tx.BlockNumber)
+				assert.Equal(t, ethparser.DirectionOut, tx.Direction)
+			}
+			assert.Equal(t, tt.wantBlocks, gotBlocks)
+		})
+	}
+}
+
+func TestParserServiceImpl_ExportTransactions(t *testing.T) {
+	ctx := context.Background()
+	addrRepo := address.NewInMemoryAddressRepo()
+	txRepo := transaction.NewInMemoryTransactionRepo()
+	service, err := application.NewParserService(
+		parser_state.NewInMemoryParserStateRepo(),
+		addrRepo,
+		txRepo,
+		mock_client.NewEthereumClient(t),
+		applogger.NewSlogAdapter(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		config.ApplicationServiceConfig{PollingIntervalSeconds: 1},
+	)
+	require.NoError(t, err)
+
+	const addr = "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	subscribed, _ := domain.NewAddress(addr)
+	counterparty, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	other, _ := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, addrRepo.Add(ctx, subscribed, domain.SubscriptionDirectionBoth))
+
+	value, _ := domain.NewWeiValue("0x1")
+	for block, sender := range map[int64]domain.Address{1: subscribed, 2: other} {
+		hash, _ := domain.NewTransactionHash(fmt.Sprintf("0x%064x", block))
+		blockNum, _ := domain.NewBlockNumber(block)
+		require.NoError(t, txRepo.Store(ctx, domain.NewTransaction(hash, sender, counterparty, value, blockNum, 1000)))
+	}
+
+	collect := func(address string) ([]ethparser.Transaction, error) {
+		var txs []ethparser.Transaction
+		errExport := service.ExportTransactions(ctx, address, func(tx ethparser.Transaction) error {
+			txs = append(txs, tx)
+			return nil
+		})
+		return txs, errExport
+	}
+
+	all, err := collect("")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, []int64{1, 2}, []int64{all[0].BlockNumber, all[1].BlockNumber})
+	assert.Empty(t, all[0].Direction, "a full export is not relative to any address")
+
+	forAddr, err := collect(addr)
+	require.NoError(t, err)
+	require.Len(t, forAddr, 1)
+	assert.Equal(t, ethparser.DirectionOut, forAddr[0].Direction)
+
+	_, err = collect(other.String())
+	assert.ErrorIs(t, err, ethparser.ErrAddressNotSubscribed)
+
+	stopErr := errors.New("client went away")
+	err = service.ExportTransactions(ctx, "", func(ethparser.Transaction) error { return stopErr })
+	assert.ErrorIs(t, err, stopErr)
+}
+
+// setupServiceWithTxRepo is a helper for tests that need control over the transaction repository.
+func setupServiceWithTxRepo(t *testing.T) (
+	*application.ParserServiceImpl,
+	*mock_repository.MonitoredAddressRepository,
+	*mock_repository.TransactionRepository,
+) {
+	t.Helper()
+	mockAddrRepo := mock_repository.NewMonitoredAddressRepository(t)
+	mockTxRepo := mock_repository.NewTransactionRepository(t)
+
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	testAppLogger := applogger.NewSlogAdapter(discardLogger)
+
+	service, err := application.NewParserService(
+		mock_repository.NewParserStateRepository(t),
+		mockAddrRepo,
+		mockTxRepo,
+		mock_client.NewEthereumClient(t),
+		testAppLogger,
+		config.ApplicationServiceConfig{PollingIntervalSeconds: 1},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create test service: %v", err)
+	}
+
+	return service, mockAddrRepo, mockTxRepo
+}
+
+// setupServiceWithClient is a helper for tests that also need control over the Ethereum client.
+func setupServiceWithClient(t *testing.T, cfg config.ApplicationServiceConfig, opts ...application.ServiceOption) (
+	*application.ParserServiceImpl,
+	*mock_repository.ParserStateRepository,
+	*mock_client.EthereumClient,
+) {
+	t.Helper()
+	mockStateRepo := mock_repository.NewParserStateRepository(t)
+	mockAddrRepo := mock_repository.NewMonitoredAddressRepository(t)
+	mockTxRepo := mock_repository.NewTransactionRepository(t)
+	mockEthClient := mock_client.NewEthereumClient(t)
+
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	testAppLogger := applogger.NewSlogAdapter(discardLogger)
+
+	service, err := application.NewParserService(
+		mockStateRepo,
+		mockAddrRepo,
+		mockTxRepo,
+		mockEthClient,
+		testAppLogger,
+		cfg,
+		opts...,
+	)
+	if err != nil {
+		t.Fatalf("Failed to create test service: %v", err)
+	}
+
+	return service, mockStateRepo, mockEthClient
+}
+
 // setupBasicService is a helper for tests that primarily need the service, stateRepo and addrRepo.
 func setupBasicService(t *testing.T) (
 	*application.ParserServiceImpl,
 	*mock_repository.ParserStateRepository,
 	*mock_repository.MonitoredAddressRepository,
+) {
+	t.Helper()
+	return setupBasicServiceWithConfig(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 1,
+	})
+}
+
+// setupBasicServiceWithConfig is setupBasicService with a custom application service configuration.
+func setupBasicServiceWithConfig(t *testing.T, cfg config.ApplicationServiceConfig) (
+	*application.ParserServiceImpl,
+	*mock_repository.ParserStateRepository,
+	*mock_repository.MonitoredAddressRepository,
 ) {
 	t.Helper()
 	mockStateRepo := mock_repository.NewParserStateRepository(t)
@@ -104,10 +1090,6 @@ func setupBasicService(t *testing.T) (
 	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	testAppLogger := applogger.NewSlogAdapter(discardLogger)
 
-	cfg := config.ApplicationServiceConfig{
-		PollingIntervalSeconds: 1,
-	}
-
 	service, err := application.NewParserService(
 		mockStateRepo,
 		mockAddrRepo,
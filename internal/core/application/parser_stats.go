@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// networkHeadCacheTTL is how long a fetched network head is reused by Stats before asking the node again.
+const networkHeadCacheTTL = 5 * time.Second
+
+// networkHeadCache memoizes the network head for frequent Stats calls.
+type networkHeadCache struct {
+	mu        sync.Mutex
+	head      domain.BlockNumber
+	fetchedAt time.Time
+}
+
+// Stats returns a summary of the parser state, including how far it lags behind the network head.
+// The network head is cached as in Lag; when the node cannot be reached, the last head fetched successfully
+// (zero if none was) is reported and the rest of the stats are still returned.
+func (s *ParserServiceImpl) Stats(ctx context.Context) (ethparser.ParserStats, error) {
+	var stats ethparser.ParserStats
+
+	current, err := s.stateRepo.GetCurrentBlock(ctx)
+	if err != nil && !errors.Is(err, repository.ErrStateNotInitialized) {
+		return ethparser.ParserStats{}, fmt.Errorf("failed to get current block from state: %w", err)
+	}
+	stats.LastScannedBlock = current.Value()
+
+	head, err := s.cachedNetworkHead(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ethparser.ParserStats{}, fmt.Errorf("failed to get network head: %w", err)
+		}
+		s.logger.Warn("Node unavailable, reporting the last known network head in stats", "error", err)
+		head = s.lastNetworkHead()
+	}
+	stats.NetworkHead = head.Value()
+	stats.LagBlocks = max(0, stats.NetworkHead-stats.LastScannedBlock)
+
+	stats.SubscribedAddresses, err = s.addressRepo.Count(ctx)
+	if err != nil {
+		return ethparser.ParserStats{}, fmt.Errorf("failed to count monitored addresses: %w", err)
+	}
+
+	stats.TransactionsStored, err = s.txRepo.CountAll(ctx)
+	if err != nil {
+		return ethparser.ParserStats{}, fmt.Errorf("failed to count stored transactions: %w", err)
+	}
+
+	if s.deadLetters != nil {
+		stats.DeadLetters, err = s.deadLetters.Count(ctx)
+		if err != nil {
+			return ethparser.ParserStats{}, fmt.Errorf("failed to count dead letters: %w", err)
+		}
+	}
+
+	stats.SkippedScans = s.skippedScans.Load()
+
+	if startedAt := s.startedAtNanos.Load(); startedAt != 0 {
+		stats.UptimeSeconds = int64(s.now().Sub(time.Unix(0, startedAt)).Seconds())
+	}
+
+	return stats, nil
+}
+
+// Lag returns the last parsed block, the network head and how many blocks the parser is behind.
+// The network head is fetched at most once per networkHeadCacheTTL. When the node cannot be reached,
+// the last head fetched successfully (zero if none was) is returned with an error wrapping
+// ethparser.ErrNodeUnavailable.
+func (s *ParserServiceImpl) Lag(ctx context.Context) (current, head, lag int64, err error) {
+	currentBlock, err := s.stateRepo.GetCurrentBlock(ctx)
+	if err != nil && !errors.Is(err, repository.ErrStateNotInitialized) {
+		return 0, 0, 0, fmt.Errorf("failed to get current block from state: %w", err)
+	}
+	current = currentBlock.Value()
+
+	headBlock, err := s.cachedNetworkHead(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, 0, 0, fmt.Errorf("failed to get network head: %w", err)
+		}
+		head = s.lastNetworkHead().Value()
+		return current, head, max(0, head-current), fmt.Errorf("%w: %w", ethparser.ErrNodeUnavailable, err)
+	}
+	head = headBlock.Value()
+
+	return current, head, max(0, head-current), nil
+}
+
+// cachedNetworkHead returns the network head, fetching it from the node at most once per networkHeadCacheTTL.
+func (s *ParserServiceImpl) cachedNetworkHead(ctx context.Context) (domain.BlockNumber, error) {
+	s.headCache.mu.Lock()
+	defer s.headCache.mu.Unlock()
+
+	now := s.now()
+	if !s.headCache.fetchedAt.IsZero() && now.Sub(s.headCache.fetchedAt) < networkHeadCacheTTL {
+		return s.headCache.head, nil
+	}
+
+	head, err := s.fetchHeadBlockNumber(ctx)
+	if err != nil {
+		return domain.BlockNumber{}, err
+	}
+	s.headCache.head = head
+	s.headCache.fetchedAt = now
+	return head, nil
+}
+
+// lastNetworkHead returns the last network head fetched by cachedNetworkHead, however old it is.
+func (s *ParserServiceImpl) lastNetworkHead() domain.BlockNumber {
+	s.headCache.mu.Lock()
+	defer s.headCache.mu.Unlock()
+	return s.headCache.head
+}
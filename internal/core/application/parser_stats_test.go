@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/dead_letter"
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats_AggregatesRepositoriesAndNode(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return clock }
+	service.startedAtNanos.Store(clock.Add(-90 * time.Second).UnixNano())
+	ctx := context.Background()
+
+	current, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, current))
+	from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	to, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, service.addressRepo.Add(ctx, from, domain.SubscriptionDirectionBoth))
+	require.NoError(t, service.addressRepo.Add(ctx, to, domain.SubscriptionDirectionBoth))
+	hash, _ := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	value, _ := domain.NewWeiValue("0x1")
+	require.NoError(t, service.txRepo.Store(ctx, domain.NewTransaction(hash, from, to, value, current, 1000)))
+	deadLetters := dead_letter.NewInMemoryDeadLetterStore()
+	require.NoError(t, deadLetters.Add(ctx, repository.DeadLetter{Reason: "disk full"}))
+	service.deadLetters = deadLetters
+	service.skippedScans.Store(3)
+
+	head, _ := domain.NewBlockNumber(104)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(head, nil).Once()
+
+	stats, err := service.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), stats.LastScannedBlock)
+	assert.Equal(t, int64(104), stats.NetworkHead)
+	assert.Equal(t, int64(4), stats.LagBlocks)
+	assert.Equal(t, 2, stats.SubscribedAddresses)
+	assert.Equal(t, 1, stats.TransactionsStored)
+	assert.Equal(t, 1, stats.DeadLetters)
+	assert.Equal(t, int64(3), stats.SkippedScans)
+	assert.Equal(t, int64(90), stats.UptimeSeconds)
+}
+
+func TestStats_CachesNetworkHead(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	first, _ := domain.NewBlockNumber(10)
+	second, _ := domain.NewBlockNumber(12)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(first, nil).Once()
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(second, nil).Once()
+
+	for i := 0; i < 3; i++ {
+		stats, err := service.Stats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), stats.NetworkHead)
+		clock = clock.Add(time.Second)
+	}
+
+	clock = clock.Add(networkHeadCacheTTL)
+	stats, err := service.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), stats.NetworkHead)
+	assert.Zero(t, stats.UptimeSeconds, "uptime is zero before Start")
+}
+
+func TestStats_NodeDownReportsLastKnownHead(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	current, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, current))
+	addr, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, service.addressRepo.Add(ctx, addr, domain.SubscriptionDirectionBoth))
+	head, _ := domain.NewBlockNumber(104)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(head, nil).Once()
+	_, err := service.Stats(ctx)
+	require.NoError(t, err)
+
+	clock = clock.Add(networkHeadCacheTTL)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).
+		Return(domain.BlockNumber{}, errors.New("connection refused")).Once()
+
+	stats, err := service.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(104), stats.NetworkHead)
+	assert.Equal(t, int64(4), stats.LagBlocks)
+	assert.Equal(t, 1, stats.SubscribedAddresses)
+}
+
+func TestLag(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	current, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, current))
+	head, _ := domain.NewBlockNumber(104)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(head, nil).Once()
+
+	gotCurrent, gotHead, lag, err := service.Lag(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), gotCurrent)
+	assert.Equal(t, int64(104), gotHead)
+	assert.Equal(t, int64(4), lag)
+
+	t.Run("Node down returns the last known head", func(t *testing.T) {
+		clock = clock.Add(networkHeadCacheTTL)
+		mockEthClient.On("GetLatestBlockNumber", mock.Anything).
+			Return(domain.BlockNumber{}, errors.New("connection refused")).Once()
+
+		gotCurrent, gotHead, lag, err := service.Lag(ctx)
+		require.ErrorIs(t, err, ethparser.ErrNodeUnavailable)
+		assert.Equal(t, int64(100), gotCurrent)
+		assert.Equal(t, int64(104), gotHead)
+		assert.Equal(t, int64(4), lag)
+	})
+}
+
+func TestLag_NodeDownBeforeFirstHead(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	ctx := context.Background()
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).
+		Return(domain.BlockNumber{}, errors.New("connection refused")).Once()
+
+	current, head, lag, err := service.Lag(ctx)
+	require.ErrorIs(t, err, ethparser.ErrNodeUnavailable)
+	assert.Zero(t, current)
+	assert.Zero(t, head)
+	assert.Zero(t, lag)
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextPollInterval_WithoutJitterIsFixed(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 10})
+
+	for range 20 {
+		assert.Equal(t, 10*time.Second, service.nextPollInterval())
+	}
+}
+
+func TestNextPollInterval_VariesWithinJitterBand(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 10,
+		PollingJitterPercent:   20,
+	})
+
+	low, high := 8*time.Second, 12*time.Second
+	seen := make(map[time.Duration]struct{})
+	for range 200 {
+		interval := service.nextPollInterval()
+		assert.GreaterOrEqual(t, interval, low)
+		assert.LessOrEqual(t, interval, high)
+		seen[interval] = struct{}{}
+	}
+	assert.Greater(t, len(seen), 1, "jittered intervals should vary")
+}
+
+func TestNextPollInterval_BandEdges(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 10,
+		PollingJitterPercent:   20,
+	})
+
+	service.randFloat = func() float64 { return 0 }
+	assert.Equal(t, 8*time.Second, service.nextPollInterval())
+	service.randFloat = func() float64 { return 0.5 }
+	assert.Equal(t, 10*time.Second, service.nextPollInterval())
+}
+
+func TestNextPollInterval_CatchupUntilCaughtUp(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 10,
+		CatchupPollingSeconds:  1,
+		MaxBlocksPerScan:       2,
+	})
+	service.pollCtx = context.Background()
+
+	head, _ := domain.NewBlockNumber(105)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(head, nil)
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+			return &block, nil
+		})
+
+	start, _ := domain.NewBlockNumber(100)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(context.Background(), start))
+	assert.Equal(t, 10*time.Second, service.nextPollInterval(), "regular interval before the first scan")
+
+	steps := []struct {
+		wantBlock    int64
+		wantInterval time.Duration
+	}{
+		{wantBlock: 102, wantInterval: time.Second},
+		{wantBlock: 104, wantInterval: time.Second},
+		{wantBlock: 105, wantInterval: 10 * time.Second},
+	}
+	for _, step := range steps {
+		current, err := service.stateRepo.GetCurrentBlock(context.Background())
+		require.NoError(t, err)
+		service.scanBlockRange(current)
+
+		current, err = service.stateRepo.GetCurrentBlock(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, step.wantBlock, current.Value())
+		assert.Equal(t, step.wantInterval, service.nextPollInterval(), "after scanning to block %d", step.wantBlock)
+	}
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"trust_wallet_homework/internal/core/domain"
+)
+
+// Replay publishes a TransactionReplayedEvent for every transaction stored for a monitored address in blocks
+// from since onwards, oldest first, so event subscribers added later receive its history. The transactions are
+// only read, never stored again. Slow subscribers are waited for instead of missing events, until ctx is done.
+func (s *ParserServiceImpl) Replay(ctx context.Context, addressString string, since int64) error {
+	address, err := domain.NewAddress(addressString)
+	if err != nil {
+		return fmt.Errorf("address validation failed: %w", err)
+	}
+	from, err := domain.NewBlockNumber(since)
+	if err != nil {
+		return fmt.Errorf("since block validation failed: %w", err)
+	}
+
+	if err := s.ensureSubscribed(ctx, address); err != nil {
+		return err
+	}
+
+	to, _ := domain.NewBlockNumber(math.MaxInt64)
+	domainTxs, err := s.txRepo.FindByAddressInBlockRange(ctx, address, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions from repository: %w", err)
+	}
+	slices.SortFunc(domainTxs, domain.CompareTransactions)
+
+	for i, tx := range domainTxs {
+		if err := s.events.PublishWait(ctx, TransactionReplayedEvent{Transaction: tx, Address: address}); err != nil {
+			return fmt.Errorf("replay stopped after %d of %d transactions: %w", i, len(domainTxs), err)
+		}
+	}
+
+	s.logger.Info("Replayed stored transactions",
+		"address", address.String(), "sinceBlock", since, "transactions", len(domainTxs))
+	return nil
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParserServiceImpl_Replay(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	sub := &recordingSubscriber{name: "webhook"}
+	service.events = NewEventBus(discardAppLogger(), sub)
+	ctx := context.Background()
+
+	wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, service.addressRepo.Add(ctx, wallet, domain.SubscriptionDirectionBoth))
+	value, _ := domain.NewWeiValue("0x1")
+	newTx := func(hash string, block int64, index uint64) domain.Transaction {
+		txHash, _ := domain.NewTransactionHash(hash)
+		blockNum, _ := domain.NewBlockNumber(block)
+		tx := domain.NewTransaction(txHash, wallet, other, value, blockNum, 1000)
+		tx.TransactionIndex = index
+		return tx
+	}
+	old := newTx("0x1111111111111111111111111111111111111111111111111111111111111111", 5, 0)
+	second := newTx("0x2222222222222222222222222222222222222222222222222222222222222222", 12, 3)
+	first := newTx("0x3333333333333333333333333333333333333333333333333333333333333333", 12, 1)
+	latest := newTx("0x4444444444444444444444444444444444444444444444444444444444444444", 20, 0)
+	for _, tx := range []domain.Transaction{latest, old, second, first} {
+		require.NoError(t, service.txRepo.Store(ctx, tx))
+	}
+
+	require.NoError(t, service.Replay(ctx, wallet.String(), 10))
+	service.events.Close()
+
+	assert.Equal(t, []Event{
+		TransactionReplayedEvent{Transaction: first, Address: wallet},
+		TransactionReplayedEvent{Transaction: second, Address: wallet},
+		TransactionReplayedEvent{Transaction: latest, Address: wallet},
+	}, sub.received(), "transactions from the since block onwards are replayed oldest first")
+	count, err := service.txRepo.CountAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count, "replayed transactions are not stored again")
+}
+
+func TestParserServiceImpl_Replay_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		since   int64
+		wantErr error
+	}{
+		{name: "Invalid address", address: "0x123", wantErr: domain.ErrInvalidAddressFormat},
+		{
+			name:    "Negative since block",
+			address: "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			since:   -1,
+			wantErr: domain.ErrNegativeBlockNumber,
+		},
+		{
+			name:    "Address not subscribed",
+			address: "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			wantErr: ethparser.ErrAddressNotSubscribed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+			ctx := context.Background()
+			wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+			require.NoError(t, service.addressRepo.Add(ctx, wallet, domain.SubscriptionDirectionBoth))
+
+			assert.ErrorIs(t, service.Replay(ctx, tt.address, tt.since), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"trust_wallet_homework/internal/core/domain"
+)
+
+// pruneTicks returns the channel on which the polling loop runs the retention policy and a function
+// releasing it. The channel is nil, and so never fires, if retention is disabled.
+func (s *ParserServiceImpl) pruneTicks() (<-chan time.Time, func()) {
+	if s.retentionBlocks <= 0 || s.pruneInterval <= 0 {
+		return nil, func() {}
+	}
+	ticker := time.NewTicker(s.pruneInterval)
+	return ticker.C, ticker.Stop
+}
+
+// pruneOldTransactions removes stored transactions included more than retentionBlocks blocks
+// before the current parser state. It runs on the polling loop, so it never races with a scan.
+func (s *ParserServiceImpl) pruneOldTransactions() {
+	current, err := s.stateRepo.GetCurrentBlock(s.pollCtx)
+	if err != nil {
+		if !(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			s.logger.Error("Failed to get current block for transaction pruning", "error", err)
+		}
+		return
+	}
+
+	cutoff, err := domain.NewBlockNumber(current.Value() - s.retentionBlocks + 1)
+	if err != nil || cutoff.Value() == 0 {
+		return
+	}
+
+	removed, err := s.txRepo.Prune(s.pollCtx, cutoff)
+	if err != nil {
+		if !(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			s.logger.Error("Failed to prune old transactions", "beforeBlock", cutoff.Value(), "error", err)
+		}
+		return
+	}
+	if removed > 0 {
+		s.logger.Info("Pruned old transactions", "beforeBlock", cutoff.Value(), "removed", removed)
+	}
+}
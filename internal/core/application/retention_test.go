@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneOldTransactions(t *testing.T) {
+	tests := []struct {
+		name            string
+		retentionBlocks int64
+		currentBlock    int64
+		wantBlocks      []int64
+	}{
+		{name: "Removes transactions outside the retention window", retentionBlocks: 10, currentBlock: 100, wantBlocks: []int64{91, 100}},
+		{name: "Keeps everything while the chain is shorter than the window", retentionBlocks: 200, currentBlock: 100, wantBlocks: []int64{10, 90, 91, 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newScannerTestService(t, config.ApplicationServiceConfig{
+				PollingIntervalSeconds: 5,
+				RetentionBlocks:        tt.retentionBlocks,
+				PruneIntervalSeconds:   60,
+			})
+			ctx := context.Background()
+			service.pollCtx = ctx
+
+			from, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+			to, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+			value, _ := domain.NewWeiValue("0x1")
+			for _, n := range []int64{10, 90, 91, 100} {
+				blockNum, _ := domain.NewBlockNumber(n)
+				hash, _ := domain.NewTransactionHash(fmt.Sprintf("0x%064x", n))
+				require.NoError(t, service.txRepo.Store(ctx, domain.NewTransaction(hash, from, to, value, blockNum, 1000)))
+			}
+			current, _ := domain.NewBlockNumber(tt.currentBlock)
+			require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, current))
+
+			service.pruneOldTransactions()
+
+			for _, addr := range []domain.Address{from, to} {
+				stored, err := service.txRepo.FindByAddress(ctx, addr)
+				require.NoError(t, err)
+				gotBlocks := make([]int64, 0, len(stored))
+				for _, tx := range stored {
+					gotBlocks = append(gotBlocks, tx.BlockNumber.Value())
+				}
+				assert.Equal(t, tt.wantBlocks, gotBlocks)
+			}
+			count, err := service.txRepo.CountAll(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.wantBlocks), count)
+		})
+	}
+}
+
+func TestPruneTicks_DisabledWithoutRetention(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5, PruneIntervalSeconds: 60})
+	ticks, stop := service.pruneTicks()
+	defer stop()
+	assert.Nil(t, ticks)
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// Rewind moves the parser state back (or forward) to the given block, so the scanner resumes after it
+// on its next tick. The block must not be negative nor above the current network head.
+func (s *ParserServiceImpl) Rewind(ctx context.Context, block int64) error {
+	target, err := domain.NewBlockNumber(block)
+	if err != nil {
+		return fmt.Errorf("block validation failed: %w", err)
+	}
+
+	head, err := s.fetchHeadBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get network head block: %w", err)
+	}
+	if target.Value() > head.Value() {
+		return fmt.Errorf("%w: block %d, head %d", ethparser.ErrRewindBeyondHead, target.Value(), head.Value())
+	}
+
+	if err := s.stateRepo.SetCurrentBlock(ctx, target); err != nil {
+		return fmt.Errorf("failed to set current block: %w", err)
+	}
+	s.pendingRewind.Store(&target)
+
+	s.logger.Info("Parser rewound", "blockNumber", target.Value(), "networkHead", head.Value())
+	return nil
+}
+
+// applyPendingRewind makes the scanner adopt a block requested by Rewind since the previous tick.
+// The state is written again because a scan running concurrently with Rewind may have overwritten it.
+// Blocks up to the previously known block are reprocessed even when processed blocks are skipped.
+func (s *ParserServiceImpl) applyPendingRewind() {
+	target := s.pendingRewind.Swap(nil)
+	if target == nil {
+		return
+	}
+
+	if err := s.stateRepo.SetCurrentBlock(s.pollCtx, *target); err != nil {
+		if !(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			s.logger.Error("Failed to apply rewind to parser state", "blockNumber", target.Value(), "error", err)
+		}
+		s.pendingRewind.CompareAndSwap(nil, target)
+		return
+	}
+
+	if s.lastKnownBlockSet {
+		s.reprocessThrough = max(s.reprocessThrough, s.lastKnownBlock.Value())
+	}
+	s.startBlockPending = false
+	s.setLastKnownBlock(*target)
+	s.logger.Info("Scanner resuming from rewound block", "blockNumber", target.Value())
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewind_ValidBlock(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		SkipProcessedBlocks:    true,
+	})
+	ctx := context.Background()
+	service.pollCtx = ctx
+
+	current, _ := domain.NewBlockNumber(200)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, current))
+	service.setLastKnownBlock(current)
+	processed, _ := domain.NewBlockNumber(150)
+	require.NoError(t, service.stateRepo.MarkBlockProcessed(ctx, processed))
+
+	head, _ := domain.NewBlockNumber(210)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(head, nil)
+
+	require.NoError(t, service.Rewind(ctx, 100))
+
+	got, err := service.stateRepo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), got.Value())
+
+	// A scan that was already running may persist its own progress after the rewind.
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, current))
+
+	service.applyPendingRewind()
+
+	got, err = service.stateRepo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), got.Value(), "pending rewind must win over a concurrent scan")
+	assert.Equal(t, int64(100), service.lastKnownBlock.Value())
+	assert.False(t, service.isAlreadyProcessed(ctx, processed), "rewound blocks must be reprocessed")
+	assert.Nil(t, service.pendingRewind.Load())
+}
+
+func TestRewind_AboveHead(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+	ctx := context.Background()
+
+	current, _ := domain.NewBlockNumber(200)
+	require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, current))
+	head, _ := domain.NewBlockNumber(210)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(head, nil)
+
+	err := service.Rewind(ctx, 211)
+	require.ErrorIs(t, err, ethparser.ErrRewindBeyondHead)
+
+	got, err := service.stateRepo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), got.Value())
+	assert.Nil(t, service.pendingRewind.Load())
+}
+
+func TestRewind_NegativeBlock(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 5})
+
+	err := service.Rewind(context.Background(), -1)
+	require.ErrorIs(t, err, domain.ErrNegativeBlockNumber)
+	assert.Nil(t, service.pendingRewind.Load())
+}
@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/dead_letter"
+	"trust_wallet_homework/internal/adapters/storage/memory/scan"
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/core/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingScanRepo records the StoreBlockResults calls and fails those for failBlock.
+type recordingScanRepo struct {
+	repository.ScanRepository
+	failBlock int64
+	calls     []string
+}
+
+func (r *recordingScanRepo) StoreBlockResults(
+	ctx context.Context,
+	block domain.BlockNumber,
+	txs []repository.BlockTransaction,
+	newHighWater domain.BlockNumber,
+) error {
+	r.calls = append(r.calls, fmt.Sprintf("block %d: %d txs, high water %d", block.Value(), len(txs), newHighWater.Value()))
+	if block.Value() == r.failBlock {
+		return errors.New("storage unavailable")
+	}
+	return r.ScanRepository.StoreBlockResults(ctx, block, txs, newHighWater)
+}
+
+func TestScanBlockRange_ScanRepositoryStoresResultsWithProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		failBlock int64
+		wantCalls []string
+		wantState int64
+		wantTxs   int
+	}{
+		{
+			name: "Every block stored with its progress",
+			wantCalls: []string{
+				"block 101: 1 txs, high water 101",
+				"block 102: 0 txs, high water 102",
+				"block 103: 1 txs, high water 103",
+			},
+			wantState: 103,
+			wantTxs:   2,
+		},
+		{
+			name:      "Failed block left to be scanned again",
+			failBlock: 103,
+			wantCalls: []string{
+				"block 101: 1 txs, high water 101",
+				"block 102: 0 txs, high water 102",
+				"block 103: 1 txs, high water 103",
+			},
+			wantState: 102,
+			wantTxs:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deadLetters := dead_letter.NewInMemoryDeadLetterStore()
+			service, mockEthClient := newScannerTestService(t,
+				config.ApplicationServiceConfig{PollingIntervalSeconds: 5, StoreRetryAttempts: 1},
+				WithDeadLetterStore(deadLetters),
+			)
+			scanRepo := &recordingScanRepo{
+				ScanRepository: scan.NewInMemoryScanRepo(service.txRepo, service.stateRepo),
+				failBlock:      tt.failBlock,
+			}
+			service.scanRepo = scanRepo
+			service.pollCtx = context.Background()
+			ctx := context.Background()
+
+			wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+			other, _ := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+			value, _ := domain.NewWeiValue("0x1")
+			require.NoError(t, service.addressRepo.Add(ctx, wallet, domain.SubscriptionDirectionBoth))
+			for number := int64(101); number <= 103; number++ {
+				blockNum, _ := domain.NewBlockNumber(number)
+				var txs []domain.Transaction
+				if number != 102 {
+					hash, _ := domain.NewTransactionHash(fmt.Sprintf("0x%064x", number))
+					txs = append(txs, domain.NewTransaction(hash, wallet, other, value, blockNum, 1000))
+				}
+				block := domain.NewBlock(blockNum, domain.BlockHash{}, 1000, txs)
+				mockEthClient.On("GetBlockWithTransactions", mock.Anything, blockNum).Return(&block, nil)
+			}
+			head, _ := domain.NewBlockNumber(103)
+			mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(head, nil)
+
+			start, _ := domain.NewBlockNumber(100)
+			require.NoError(t, service.stateRepo.SetCurrentBlock(ctx, start))
+			service.scanBlockRange(start)
+
+			assert.Equal(t, tt.wantCalls, scanRepo.calls)
+			got, err := service.stateRepo.GetCurrentBlock(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantState, got.Value())
+			stored, err := service.txRepo.FindByAddress(ctx, wallet)
+			require.NoError(t, err)
+			assert.Len(t, stored, tt.wantTxs)
+			letters, err := deadLetters.FindAll(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, letters, "the results of a block are never dead-lettered")
+		})
+	}
+}
+
+func TestStoreBlockResults_RetriesAndPublishes(t *testing.T) {
+	service, _ := newScannerTestService(t, config.ApplicationServiceConfig{
+		PollingIntervalSeconds: 5,
+		StoreRetryAttempts:     2,
+	})
+	flaky := &flakyTransactionRepo{TransactionRepository: service.txRepo, failures: 1}
+	service.scanRepo = scan.NewInMemoryScanRepo(flaky, service.stateRepo)
+	sub := &recordingSubscriber{name: "recorder"}
+	service.events = NewEventBus(discardAppLogger(), sub)
+	ctx := context.Background()
+
+	wallet, _ := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	token, _ := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	blockNum, _ := domain.NewBlockNumber(7)
+	tx := domain.Transaction{From: wallet, BlockNumber: blockNum}
+
+	stored, err := service.storeBlockResults(ctx, blockNum,
+		[]blockMatch{{tx: tx, addresses: []domain.Address{wallet, token}}}, service.logger)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored)
+	assert.Equal(t, int64(2), flaky.calls.Load(), "the failed unit of work is retried")
+
+	indexed, err := service.txRepo.FindByAddress(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Transaction{tx}, indexed)
+	got, err := service.stateRepo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), got.Value())
+	service.events.Close()
+	assert.Equal(t, []Event{TransactionStoredEvent{Transaction: tx, Addresses: []domain.Address{wallet, token}}},
+		sub.received())
+}
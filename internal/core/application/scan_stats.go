@@ -0,0 +1,17 @@
+package application
+
+import "time"
+
+// emptySetLogInterval bounds how often the scanner reports that no addresses are subscribed.
+const emptySetLogInterval = time.Minute
+
+// logEmptyAddressSet reports an empty monitored set at most once per emptySetLogInterval.
+func (s *ParserServiceImpl) logEmptyAddressSet() {
+	now := s.now()
+	if !s.lastEmptySetLog.IsZero() && now.Sub(s.lastEmptySetLog) < emptySetLogInterval {
+		return
+	}
+	s.lastEmptySetLog = now
+	s.logger.Info("No addresses are currently subscribed for monitoring. Skipping transaction processing until subscribed.",
+		"skippedScans", s.skippedScans.Load())
+}
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// TriggerScan runs a scan iteration right away, outside the polling schedule, and waits until it completes
+// or ctx is done. The scan is handed to the polling loop, so it never overlaps a scheduled scan and the
+// next tick keeps its time. It returns ethparser.ErrParserNotRunning unless the parser is running.
+func (s *ParserServiceImpl) TriggerScan(ctx context.Context) error {
+	s.lifecycleMu.Lock()
+	running := s.state == stateRunning && !s.pollLoopExited()
+	stopChan := s.stopChan
+	s.lifecycleMu.Unlock()
+	if !running {
+		return ethparser.ErrParserNotRunning
+	}
+
+	reply := make(chan error, 1)
+	select {
+	case s.scanRequests <- reply:
+	case <-stopChan:
+		return ethparser.ErrParserNotRunning
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// runRequestedScan performs a scan requested by TriggerScan, like a polling tick would.
+func (s *ParserServiceImpl) runRequestedScan() error {
+	s.logger.Info("Running scan requested out of band")
+	s.applyPendingRewind()
+	if s.startBlockPending {
+		s.resolveStartBlock()
+		if s.startBlockPending {
+			return fmt.Errorf("starting block still unknown: %w", ethparser.ErrNodeUnavailable)
+		}
+		return nil
+	}
+
+	current, err := s.stateRepo.GetCurrentBlock(s.pollCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block from state: %w", err)
+	}
+	s.scanBlockRange(current)
+	return nil
+}
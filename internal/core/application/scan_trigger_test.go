@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerScan_RunsScanOnDemand(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 3600})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var head atomic.Int64
+	head.Store(100)
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(1), nil)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(
+		func(context.Context) (domain.BlockNumber, error) {
+			return domain.NewBlockNumber(head.Load())
+		})
+	mockEthClient.On("GetBlockWithTransactions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, num domain.BlockNumber) (*domain.Block, error) {
+			block := domain.NewBlock(num, domain.BlockHash{}, 0, nil)
+			return &block, nil
+		})
+
+	assert.ErrorIs(t, service.TriggerScan(ctx), ethparser.ErrParserNotRunning, "not started yet")
+
+	require.NoError(t, service.Start(ctx))
+	require.Eventually(t, service.FirstScanCompleted, time.Second, 10*time.Millisecond)
+
+	head.Store(102)
+	require.NoError(t, service.TriggerScan(ctx))
+
+	current, err := service.stateRepo.GetCurrentBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(102), current.Value(), "the scan ran before the next tick, an hour away")
+	mockEthClient.AssertNumberOfCalls(t, "GetBlockWithTransactions", 2)
+
+	cancel()
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelStop()
+	require.NoError(t, service.Stop(stopCtx))
+	assert.ErrorIs(t, service.TriggerScan(context.Background()), ethparser.ErrParserNotRunning, "stopped")
+}
+
+func TestTriggerScan_ReturnsWhenContextDone(t *testing.T) {
+	service, mockEthClient := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 3600})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	latest, _ := domain.NewBlockNumber(100)
+	release := make(chan struct{})
+	defer close(release)
+	mockEthClient.On("GetChainID", mock.Anything).Return(int64(1), nil)
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(latest, nil).Twice()
+	mockEthClient.On("GetLatestBlockNumber", mock.Anything).Return(
+		func(context.Context) (domain.BlockNumber, error) {
+			<-release
+			return latest, nil
+		})
+
+	require.NoError(t, service.Start(ctx))
+	require.Eventually(t, service.FirstScanCompleted, time.Second, 10*time.Millisecond)
+
+	triggerCtx, cancelTrigger := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancelTrigger()
+	assert.ErrorIs(t, service.TriggerScan(triggerCtx), context.DeadlineExceeded)
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// storePing names a repository for the storage health check.
+type storePing struct {
+	name string
+	ping func(context.Context) error
+}
+
+// PingStorage pings every repository of the parser, including the optional dead letter and scan stores, and
+// reports all failing ones at once.
+func (s *ParserServiceImpl) PingStorage(ctx context.Context) error {
+	stores := []storePing{
+		{name: "parser state", ping: s.stateRepo.Ping},
+		{name: "addresses", ping: s.addressRepo.Ping},
+		{name: "transactions", ping: s.txRepo.Ping},
+	}
+	if s.deadLetters != nil {
+		stores = append(stores, storePing{name: "dead letters", ping: s.deadLetters.Ping})
+	}
+	if s.scanRepo != nil {
+		stores = append(stores, storePing{name: "scan results", ping: s.scanRepo.Ping})
+	}
+
+	var errs []error
+	for _, store := range stores {
+		if err := store.ping(ctx); err != nil {
+			s.logger.Warn("Storage ping failed", "store", store.name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", store.name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ethparser.ErrStorageUnavailable, errors.Join(errs...))
+	}
+	return nil
+}
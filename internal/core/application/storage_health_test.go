@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"trust_wallet_homework/internal/adapters/storage/memory/address"
+	"trust_wallet_homework/internal/adapters/storage/memory/dead_letter"
+	"trust_wallet_homework/internal/adapters/storage/memory/parser_state"
+	"trust_wallet_homework/internal/adapters/storage/memory/scan"
+	"trust_wallet_homework/internal/adapters/storage/memory/transaction"
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/core/application/mocks/mock_client"
+	"trust_wallet_homework/internal/core/domain/repository"
+	"trust_wallet_homework/pkg/ethparser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unwritableTransactionRepo fails every Ping, like a database whose disk is full.
+type unwritableTransactionRepo struct {
+	repository.TransactionRepository
+}
+
+func (r *unwritableTransactionRepo) Ping(context.Context) error {
+	return errors.New("disk full")
+}
+
+// unwritableDeadLetterStore fails every Ping.
+type unwritableDeadLetterStore struct {
+	repository.DeadLetterStore
+}
+
+func (s *unwritableDeadLetterStore) Ping(context.Context) error {
+	return errors.New("connection refused")
+}
+
+func TestPingStorage(t *testing.T) {
+	tests := []struct {
+		name         string
+		txRepo       repository.TransactionRepository
+		deadLetters  repository.DeadLetterStore
+		wantFailures []string
+	}{
+		{
+			name:   "Writable storage",
+			txRepo: transaction.NewInMemoryTransactionRepo(),
+		},
+		{
+			name:         "Unwritable transactions",
+			txRepo:       &unwritableTransactionRepo{TransactionRepository: transaction.NewInMemoryTransactionRepo()},
+			wantFailures: []string{"transactions: disk full", "scan results: disk full"},
+		},
+		{
+			name:         "Unwritable dead letters",
+			txRepo:       transaction.NewInMemoryTransactionRepo(),
+			deadLetters:  &unwritableDeadLetterStore{DeadLetterStore: dead_letter.NewInMemoryDeadLetterStore()},
+			wantFailures: []string{"dead letters: connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateRepo := parser_state.NewInMemoryParserStateRepo()
+			opts := []ServiceOption{WithScanRepository(scan.NewInMemoryScanRepo(tt.txRepo, stateRepo))}
+			if tt.deadLetters != nil {
+				opts = append(opts, WithDeadLetterStore(tt.deadLetters))
+			}
+			service, err := NewParserService(stateRepo, address.NewInMemoryAddressRepo(), tt.txRepo, mock_client.NewEthereumClient(t),
+				discardAppLogger(), config.ApplicationServiceConfig{PollingIntervalSeconds: 10}, opts...)
+			require.NoError(t, err)
+
+			err = service.PingStorage(context.Background())
+			if len(tt.wantFailures) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ethparser.ErrStorageUnavailable)
+			for _, failure := range tt.wantFailures {
+				assert.ErrorContains(t, err, failure)
+			}
+		})
+	}
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		service, _ := newScannerTestService(t, config.ApplicationServiceConfig{PollingIntervalSeconds: 10})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, service.PingStorage(ctx), context.Canceled)
+	})
+}
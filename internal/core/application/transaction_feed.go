@@ -0,0 +1,100 @@
+package application
+
+import (
+	"sync"
+
+	"trust_wallet_homework/internal/core/domain"
+	"trust_wallet_homework/internal/logger"
+	"trust_wallet_homework/pkg/ethparser"
+)
+
+// transactionFeedBufferSize is the number of pending transactions buffered per listener.
+const transactionFeedBufferSize = 16
+
+// transactionFeed fans out newly stored transactions to per-address listeners.
+type transactionFeed struct {
+	mu        sync.Mutex
+	nextID    uint64
+	listeners map[domain.Address]map[uint64]chan ethparser.Transaction
+	logger    logger.AppLogger
+}
+
+// newTransactionFeed creates an empty transaction feed.
+func newTransactionFeed(appLogger logger.AppLogger) *transactionFeed {
+	return &transactionFeed{
+		listeners: make(map[domain.Address]map[uint64]chan ethparser.Transaction),
+		logger:    appLogger,
+	}
+}
+
+// register adds a listener for the address and returns its channel and identifier.
+func (f *transactionFeed) register(address domain.Address) (<-chan ethparser.Transaction, uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	id := f.nextID
+	ch := make(chan ethparser.Transaction, transactionFeedBufferSize)
+	if f.listeners[address] == nil {
+		f.listeners[address] = make(map[uint64]chan ethparser.Transaction)
+	}
+	f.listeners[address][id] = ch
+	return ch, id
+}
+
+// unregister removes the listener and closes its channel.
+func (f *transactionFeed) unregister(address domain.Address, id uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch, ok := f.listeners[address][id]
+	if !ok {
+		return
+	}
+	delete(f.listeners[address], id)
+	if len(f.listeners[address]) == 0 {
+		delete(f.listeners, address)
+	}
+	close(ch)
+}
+
+// Name implements EventSubscriber.
+func (f *transactionFeed) Name() string { return "transaction_feed" }
+
+// HandleEvent implements EventSubscriber by forwarding stored transactions to the listeners of the
+// addresses they were indexed for. Other events are ignored.
+func (f *transactionFeed) HandleEvent(event Event) {
+	if stored, ok := event.(TransactionStoredEvent); ok {
+		f.publishTo(stored.Transaction, stored.Addresses)
+	}
+}
+
+// publishTo sends the transaction to the listeners of the given addresses.
+// Listeners that are not keeping up have the transaction dropped instead of blocking the publisher.
+func (f *transactionFeed) publishTo(tx domain.Transaction, addresses []domain.Address) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, address := range addresses {
+		f.notify(address, tx)
+	}
+}
+
+// notify sends the transaction to every listener of the address. The caller must hold the lock.
+func (f *transactionFeed) notify(address domain.Address, tx domain.Transaction) {
+	listeners := f.listeners[address]
+	if len(listeners) == 0 {
+		return
+	}
+	apiTx := mapDomainToAPITransaction(tx, address)
+	for id, ch := range listeners {
+		select {
+		case ch <- apiTx:
+		default:
+			f.logger.Warn("Transaction feed listener is full, dropping transaction",
+				"address", address.String(),
+				"listenerID", id,
+				"txHash", apiTx.Hash)
+		}
+	}
+}
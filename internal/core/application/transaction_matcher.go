@@ -0,0 +1,106 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"trust_wallet_homework/internal/core/domain"
+)
+
+// Supported address-matching strategies.
+const (
+	MatchStrategyExact = "exact"
+	MatchStrategyInput = "input"
+)
+
+// TransactionMatcher decides which monitored addresses a transaction concerns.
+type TransactionMatcher interface {
+	// Match returns the monitored addresses the transaction concerns, or nil if there are none.
+	Match(tx domain.Transaction, monitored map[domain.Address]struct{}) []domain.Address
+}
+
+// NewTransactionMatcher returns the matcher for the given strategy name. An empty name selects exact matching.
+func NewTransactionMatcher(strategy string) (TransactionMatcher, error) {
+	switch strategy {
+	case "", MatchStrategyExact:
+		return ExactMatcher{}, nil
+	case MatchStrategyInput:
+		return InputScanMatcher{}, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction match strategy %q", strategy)
+	}
+}
+
+// participantMatcher is implemented by matchers that only match the sender or recipient of a transaction.
+// Transactions whose participants are definitely not monitored can then be skipped before matching.
+type participantMatcher interface {
+	matchesParticipantsOnly()
+}
+
+// ExactMatcher matches a transaction to an address only if the address is its sender or recipient.
+type ExactMatcher struct{}
+
+// matchesParticipantsOnly marks ExactMatcher as a participantMatcher.
+func (ExactMatcher) matchesParticipantsOnly() {}
+
+// Match returns the monitored sender and recipient of the transaction.
+func (ExactMatcher) Match(tx domain.Transaction, monitored map[domain.Address]struct{}) []domain.Address {
+	if !tx.InvolvesAnyAddress(monitored) {
+		return nil
+	}
+	var matched []domain.Address
+	if _, ok := monitored[tx.From]; ok && !tx.From.IsZero() {
+		matched = append(matched, tx.From)
+	}
+	if _, ok := monitored[tx.To]; ok && !tx.IsContractCreation() && !tx.To.Equals(tx.From) {
+		matched = append(matched, tx.To)
+	}
+	return matched
+}
+
+// InputScanMatcher extends exact matching with addresses passed as ABI-encoded arguments in the call data,
+// such as the recipient of an ERC-20 transfer.
+type InputScanMatcher struct{}
+
+// abiWordHexLen is the length of a 32-byte ABI word in hex characters.
+const abiWordHexLen = 64
+
+// abiSelectorHexLen is the length of the 4-byte function selector in hex characters.
+const abiSelectorHexLen = 8
+
+// Match returns the monitored sender, recipient and addresses found in the call data arguments.
+func (InputScanMatcher) Match(tx domain.Transaction, monitored map[domain.Address]struct{}) []domain.Address {
+	matched := ExactMatcher{}.Match(tx, monitored)
+
+	args := strings.TrimPrefix(strings.ToLower(tx.Input), "0x")
+	if len(args) <= abiSelectorHexLen {
+		return matched
+	}
+	args = args[abiSelectorHexLen:]
+
+	for offset := 0; offset+abiWordHexLen <= len(args); offset += abiWordHexLen {
+		word := args[offset : offset+abiWordHexLen]
+		// An address argument is left-padded with 12 zero bytes.
+		if strings.TrimLeft(word[:24], "0") != "" {
+			continue
+		}
+		candidate, err := domain.NewAddress("0x" + word[24:])
+		if err != nil {
+			continue
+		}
+		if _, ok := monitored[candidate]; ok && !containsAddress(matched, candidate) {
+			matched = append(matched, candidate)
+		}
+	}
+	return matched
+}
+
+// containsAddress reports whether the address is present in the slice.
+func containsAddress(addresses []domain.Address, address domain.Address) bool {
+	for _, a := range addresses {
+		if a.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package application_test
+
+import (
+	"testing"
+
+	"trust_wallet_homework/internal/core/application"
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// erc20TransferInput encodes transfer(0xbbbb...bbbb, 1000) call data.
+const erc20TransferInput = "0xa9059cbb" +
+	"000000000000000000000000bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb" +
+	"00000000000000000000000000000000000000000000000000000000000003e8"
+
+func newMatcherTestTransaction(t *testing.T) (tx domain.Transaction, sender, token, recipient domain.Address) {
+	t.Helper()
+	var err error
+	sender, err = domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	token, err = domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+	recipient, err = domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+
+	tx = domain.Transaction{From: sender, To: token, Input: erc20TransferInput}
+	return tx, sender, token, recipient
+}
+
+func TestExactMatcher_IgnoresInputRecipient(t *testing.T) {
+	tx, sender, _, recipient := newMatcherTestTransaction(t)
+	matcher := application.ExactMatcher{}
+
+	assert.Empty(t, matcher.Match(tx, map[domain.Address]struct{}{recipient: {}}))
+	assert.Equal(t, []domain.Address{sender}, matcher.Match(tx, map[domain.Address]struct{}{sender: {}}))
+}
+
+func TestInputScanMatcher_MatchesInputRecipient(t *testing.T) {
+	tx, sender, token, recipient := newMatcherTestTransaction(t)
+	matcher := application.InputScanMatcher{}
+
+	assert.Equal(t, []domain.Address{recipient}, matcher.Match(tx, map[domain.Address]struct{}{recipient: {}}))
+	assert.ElementsMatch(t,
+		[]domain.Address{sender, token, recipient},
+		matcher.Match(tx, map[domain.Address]struct{}{sender: {}, token: {}, recipient: {}}))
+
+	stranger, err := domain.NewAddress("0xdddddddddddddddddddddddddddddddddddddddd")
+	require.NoError(t, err)
+	assert.Empty(t, matcher.Match(tx, map[domain.Address]struct{}{stranger: {}}))
+}
+
+func TestInputScanMatcher_IgnoresShortOrNonAddressInput(t *testing.T) {
+	tx, _, _, recipient := newMatcherTestTransaction(t)
+	matcher := application.InputScanMatcher{}
+	monitored := map[domain.Address]struct{}{recipient: {}}
+
+	for _, input := range []string{"", "0x", "0xa9059cbb", "0xa9059cbb" + "ff" + erc20TransferInput[12:]} {
+		tx.Input = input
+		assert.Empty(t, matcher.Match(tx, monitored), "input %q", input)
+	}
+}
+
+func TestNewTransactionMatcher(t *testing.T) {
+	matcher, err := application.NewTransactionMatcher("")
+	require.NoError(t, err)
+	assert.IsType(t, application.ExactMatcher{}, matcher)
+
+	matcher, err = application.NewTransactionMatcher(application.MatchStrategyInput)
+	require.NoError(t, err)
+	assert.IsType(t, application.InputScanMatcher{}, matcher)
+
+	_, err = application.NewTransactionMatcher("fuzzy")
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@
 package domain
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"regexp"
@@ -29,6 +30,23 @@ func NewAddress(addr string) (Address, error) {
 	return Address{value: cleanAddr}, nil
 }
 
+// NewAddressFromBytes creates an Address from its 20 raw bytes. Any 20 bytes form a valid address,
+// so no validation is needed; the address is held in the same lowercase form as NewAddress produces.
+func NewAddressFromBytes(b [20]byte) Address {
+	return Address{value: "0x" + hex.EncodeToString(b[:])}
+}
+
+// Bytes returns the 20 raw bytes of the address. The zero Address yields 20 zero bytes.
+func (a Address) Bytes() [20]byte {
+	var b [20]byte
+	if a.IsZero() {
+		return b
+	}
+	// The value was validated as 40 hex characters, so decoding cannot fail.
+	_, _ = hex.Decode(b[:], []byte(a.value[2:]))
+	return b
+}
+
 // String returns the string representation of the address.
 func (a Address) String() string {
 	return a.value
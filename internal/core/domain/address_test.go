@@ -77,3 +77,65 @@ func TestNewAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestAddress_BytesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantVal string
+	}{
+		{
+			name:    "Lowercase address",
+			input:   "0x71c7656ec7ab88b098defb751b7401b5f6d8976f",
+			wantVal: "0x71c7656ec7ab88b098defb751b7401b5f6d8976f",
+		},
+		{
+			name:    "Mixed case address (expect lowercase)",
+			input:   "0x71c7656Ec7aB88b098dEfb751B7401b5f6d8976f",
+			wantVal: "0x71c7656ec7ab88b098defb751b7401b5f6d8976f",
+		},
+		{
+			name:    "Zero address",
+			input:   "0x0000000000000000000000000000000000000000",
+			wantVal: "0x0000000000000000000000000000000000000000",
+		},
+		{
+			name:    "All bytes set",
+			input:   "0xffffffffffffffffffffffffffffffffffffffff",
+			wantVal: "0xffffffffffffffffffffffffffffffffffffffff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := domain.NewAddress(tt.input)
+			if err != nil {
+				t.Fatalf("NewAddress() error = %v", err)
+			}
+			got := domain.NewAddressFromBytes(addr.Bytes())
+			if got.String() != tt.wantVal {
+				t.Errorf("NewAddressFromBytes(Bytes()) got = %v, want %v", got.String(), tt.wantVal)
+			}
+			if !got.Equals(addr) {
+				t.Errorf("NewAddressFromBytes(Bytes()) = %v, not equal to %v", got, addr)
+			}
+		})
+	}
+}
+
+func TestAddress_Bytes(t *testing.T) {
+	addr, err := domain.NewAddress("0x71c7656ec7ab88b098defb751b7401b5f6d8976f")
+	if err != nil {
+		t.Fatalf("NewAddress() error = %v", err)
+	}
+	want := [20]byte{
+		0x71, 0xc7, 0x65, 0x6e, 0xc7, 0xab, 0x88, 0xb0, 0x98, 0xde,
+		0xfb, 0x75, 0x1b, 0x74, 0x01, 0xb5, 0xf6, 0xd8, 0x97, 0x6f,
+	}
+	if got := addr.Bytes(); got != want {
+		t.Errorf("Bytes() got = %x, want %x", got, want)
+	}
+	if got := (domain.Address{}).Bytes(); got != [20]byte{} {
+		t.Errorf("Bytes() of zero Address got = %x, want all zeros", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 )
 
 var (
@@ -13,6 +14,12 @@ var (
 
 	// ErrInvalidBlockHashFormat indicates that a provided string does not conform to the expected block hash.
 	ErrInvalidBlockHashFormat = errors.New("invalid block hash format")
+
+	// ErrZeroBlockTimestamp indicates that a block other than the genesis block has a zero timestamp.
+	ErrZeroBlockTimestamp = errors.New("block timestamp is zero")
+
+	// ErrFutureBlockTimestamp indicates that a block timestamp lies too far in the future.
+	ErrFutureBlockTimestamp = errors.New("block timestamp is in the future")
 )
 
 // Basic regex for Block Hash format validation (0x followed by 64 hex characters).
@@ -71,6 +78,8 @@ type Block struct {
 	Hash         BlockHash
 	Timestamp    uint64
 	Transactions []Transaction
+	// BaseFeePerGas is the EIP-1559 base fee of the block; it is zero for blocks before the London fork.
+	BaseFeePerGas WeiValue
 }
 
 // NewBlock is a simple constructor for the Block entity.
@@ -82,3 +91,33 @@ func NewBlock(number BlockNumber, hash BlockHash, timestamp uint64, transactions
 		Transactions: transactions,
 	}
 }
+
+// TransactionsInvolving returns the transactions of the block whose sender or recipient is in the given set,
+// in block order. Contract creations are matched by their sender only.
+func (b Block) TransactionsInvolving(addresses map[Address]struct{}) []Transaction {
+	var involved []Transaction
+	for _, tx := range b.Transactions {
+		if tx.InvolvesAnyAddress(addresses) {
+			involved = append(involved, tx)
+		}
+	}
+	return involved
+}
+
+// CheckTimestamp reports whether the block timestamp is plausible at the given time.
+// Only the genesis block may have a zero timestamp, and no block may be more than maxFutureDrift ahead of now,
+// which leaves room for clock skew between this host and the network.
+func (b Block) CheckTimestamp(now time.Time, maxFutureDrift time.Duration) error {
+	if b.Timestamp == 0 {
+		if b.Number.Value() == 0 {
+			return nil
+		}
+		return fmt.Errorf("%w: block %d", ErrZeroBlockTimestamp, b.Number.Value())
+	}
+	latest := now.Add(maxFutureDrift).Unix()
+	if latest < 0 || b.Timestamp > uint64(latest) {
+		return fmt.Errorf("%w: block %d has timestamp %d, now is %d",
+			ErrFutureBlockTimestamp, b.Number.Value(), b.Timestamp, now.Unix())
+	}
+	return nil
+}
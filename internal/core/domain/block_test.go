@@ -0,0 +1,88 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlock_TransactionsInvolving(t *testing.T) {
+	alice, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	bob, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	carol, err := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+	zero, err := domain.NewAddress("0x0000000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	aliceToBob := domain.Transaction{From: alice, To: bob}
+	bobToCarol := domain.Transaction{From: bob, To: carol}
+	carolCreates := domain.Transaction{From: carol}
+	carolBurns := domain.Transaction{From: carol, To: zero}
+	block := domain.NewBlock(domain.BlockNumber{}, domain.BlockHash{}, 0,
+		[]domain.Transaction{aliceToBob, bobToCarol, carolCreates, carolBurns})
+
+	tests := []struct {
+		name      string
+		addresses []domain.Address
+		want      []domain.Transaction
+	}{
+		{name: "Empty set", addresses: nil, want: nil},
+		{name: "Sender only", addresses: []domain.Address{alice}, want: []domain.Transaction{aliceToBob}},
+		{name: "Sender and recipient, block order kept", addresses: []domain.Address{bob}, want: []domain.Transaction{aliceToBob, bobToCarol}},
+		{name: "Contract creation matches its sender", addresses: []domain.Address{carol}, want: []domain.Transaction{bobToCarol, carolCreates, carolBurns}},
+		{name: "Empty address does not match contract creations", addresses: []domain.Address{{}}, want: nil},
+		{name: "Zero address is a real recipient", addresses: []domain.Address{zero}, want: []domain.Transaction{carolBurns}},
+		{name: "Several addresses", addresses: []domain.Address{alice, carol}, want: []domain.Transaction{aliceToBob, bobToCarol, carolCreates, carolBurns}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := make(map[domain.Address]struct{}, len(tt.addresses))
+			for _, addr := range tt.addresses {
+				set[addr] = struct{}{}
+			}
+			assert.Equal(t, tt.want, block.TransactionsInvolving(set))
+		})
+	}
+}
+
+func TestBlock_CheckTimestamp(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	const drift = 15 * time.Minute
+
+	tests := []struct {
+		name      string
+		number    int64
+		timestamp uint64
+		wantErr   error
+	}{
+		{name: "Normal timestamp", number: 100, timestamp: 1_699_999_988},
+		{name: "Slightly ahead within drift", number: 100, timestamp: 1_700_000_060},
+		{name: "Exactly at the drift limit", number: 100, timestamp: 1_700_000_900},
+		{name: "Zero timestamp", number: 100, timestamp: 0, wantErr: domain.ErrZeroBlockTimestamp},
+		{name: "Zero timestamp on genesis", number: 0, timestamp: 0},
+		{name: "Future timestamp", number: 100, timestamp: 1_700_000_901, wantErr: domain.ErrFutureBlockTimestamp},
+		{name: "Far future timestamp", number: 100, timestamp: 1 << 62, wantErr: domain.ErrFutureBlockTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := domain.NewBlockNumber(tt.number)
+			require.NoError(t, err)
+			block := domain.NewBlock(number, domain.BlockHash{}, tt.timestamp, nil)
+
+			err = block.CheckTimestamp(now, drift)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -9,11 +9,56 @@ import (
 	"trust_wallet_homework/internal/core/domain"
 )
 
+// Block tags accepted by GetBlockNumberByTag and GetBlockByTag.
+const (
+	BlockTagLatest    = "latest"
+	BlockTagPending   = "pending"
+	BlockTagSafe      = "safe"
+	BlockTagFinalized = "finalized"
+)
+
 // EthereumClient defines the interface for interacting with an Ethereum node.
 type EthereumClient interface {
+	// GetChainID fetches the chain ID of the network the node is connected to (e.g. 1 for Ethereum mainnet).
+	GetChainID(ctx context.Context) (int64, error)
+
 	// GetLatestBlockNumber fetches the number of the most recent block in the blockchain.
 	GetLatestBlockNumber(ctx context.Context) (domain.BlockNumber, error)
 
+	// GetBlockNumberByTag fetches the number of the block identified by a tag such as "safe" or "finalized".
+	GetBlockNumberByTag(ctx context.Context, tag string) (domain.BlockNumber, error)
+
 	// GetBlockWithTransactions fetches a block by its number, including all transaction details.
 	GetBlockWithTransactions(ctx context.Context, blockNumber domain.BlockNumber) (*domain.Block, error)
+
+	// GetBlockByHash fetches a block by its hash, including all transaction details.
+	// A nil block means the node does not know the hash.
+	GetBlockByHash(ctx context.Context, hash domain.BlockHash) (*domain.Block, error)
+
+	// GetBlockByTag fetches the block identified by a tag such as "finalized". When full is false the
+	// returned block has no transactions. A nil block means the node has no block for the tag yet.
+	GetBlockByTag(ctx context.Context, tag string, full bool) (*domain.Block, error)
+
+	// GetTransactionByHash fetches a transaction by its hash. A nil transaction means the node does not know it.
+	GetTransactionByHash(ctx context.Context, hash domain.TransactionHash) (*domain.Transaction, error)
+
+	// GetTransactionStatuses fetches the receipt status of the given transactions in batched calls.
+	// Transactions without a receipt are omitted from the result.
+	GetTransactionStatuses(
+		ctx context.Context,
+		hashes []domain.TransactionHash,
+	) (map[domain.TransactionHash]domain.TransactionStatus, error)
+}
+
+// BlockStreamer is implemented by clients that can pass the transactions of a block to a callback as they are
+// decoded, instead of returning the whole block at once.
+type BlockStreamer interface {
+	// StreamBlockTransactions fetches a block by its number and calls fn for each of its transactions.
+	// The returned block has no transactions; a nil block means the node has no such block yet.
+	// The first error returned by fn stops the stream.
+	StreamBlockTransactions(
+		ctx context.Context,
+		blockNumber domain.BlockNumber,
+		fn func(domain.Transaction) error,
+	) (*domain.Block, error)
 }
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON encoding of the value objects, so domain entities serialize to their canonical string forms.
+// Addresses and hashes encode as lowercase hex strings and their zero values as null;
+// wei values encode as hex strings ("0x..."); block numbers encode as JSON numbers.
+// Decoding validates the input like the corresponding constructor and leaves the zero value for null.
+
+var (
+	_ json.Marshaler   = Address{}
+	_ json.Unmarshaler = (*Address)(nil)
+	_ json.Marshaler   = TransactionHash{}
+	_ json.Unmarshaler = (*TransactionHash)(nil)
+	_ json.Marshaler   = BlockHash{}
+	_ json.Unmarshaler = (*BlockHash)(nil)
+	_ json.Marshaler   = WeiValue{}
+	_ json.Unmarshaler = (*WeiValue)(nil)
+	_ json.Marshaler   = BlockNumber{}
+	_ json.Unmarshaler = (*BlockNumber)(nil)
+)
+
+// isJSONNull reports whether data is the JSON null literal.
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// marshalOptionalString encodes s as a JSON string, or as null if it is empty.
+func marshalOptionalString(s string) ([]byte, error) {
+	if s == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s)
+}
+
+// unmarshalString decodes a JSON string.
+func unmarshalString(data []byte, what string) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("%s must be a JSON string: %w", what, err)
+	}
+	return s, nil
+}
+
+// MarshalJSON encodes the address as its lowercase hex string, or null for the zero Address.
+func (a Address) MarshalJSON() ([]byte, error) {
+	return marshalOptionalString(a.value)
+}
+
+// UnmarshalJSON decodes an address string, validating it like NewAddress.
+func (a *Address) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	s, err := unmarshalString(data, "address")
+	if err != nil {
+		return err
+	}
+	addr, err := NewAddress(s)
+	if err != nil {
+		return err
+	}
+	*a = addr
+	return nil
+}
+
+// MarshalJSON encodes the hash as its lowercase hex string, or null for the zero TransactionHash.
+func (th TransactionHash) MarshalJSON() ([]byte, error) {
+	return marshalOptionalString(th.value)
+}
+
+// UnmarshalJSON decodes a transaction hash string, validating it like NewTransactionHash.
+func (th *TransactionHash) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	s, err := unmarshalString(data, "transaction hash")
+	if err != nil {
+		return err
+	}
+	hash, err := NewTransactionHash(s)
+	if err != nil {
+		return err
+	}
+	*th = hash
+	return nil
+}
+
+// MarshalJSON encodes the hash as its lowercase hex string, or null for the zero BlockHash.
+func (bh BlockHash) MarshalJSON() ([]byte, error) {
+	return marshalOptionalString(bh.value)
+}
+
+// UnmarshalJSON decodes a block hash string, validating it like NewBlockHash.
+func (bh *BlockHash) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	s, err := unmarshalString(data, "block hash")
+	if err != nil {
+		return err
+	}
+	hash, err := NewBlockHash(s)
+	if err != nil {
+		return err
+	}
+	*bh = hash
+	return nil
+}
+
+// MarshalJSON encodes the value as a hex string ("0x..."); the zero WeiValue encodes as "0x0".
+func (wv WeiValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wv.String())
+}
+
+// UnmarshalJSON decodes a hex or decimal value string, validating it like NewWeiValue.
+func (wv *WeiValue) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	s, err := unmarshalString(data, "wei value")
+	if err != nil {
+		return err
+	}
+	value, err := NewWeiValue(s)
+	if err != nil {
+		return err
+	}
+	*wv = value
+	return nil
+}
+
+// MarshalJSON encodes the block number as a JSON number.
+func (bn BlockNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bn.value)
+}
+
+// UnmarshalJSON decodes a JSON number, rejecting negative block numbers like NewBlockNumber.
+func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var number int64
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("block number must be a JSON integer: %w", err)
+	}
+	blockNumber, err := NewBlockNumber(number)
+	if err != nil {
+		return err
+	}
+	*bn = blockNumber
+	return nil
+}
@@ -0,0 +1,117 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertJSONRoundTrip checks that value encodes to wantJSON and decodes back to an equal value.
+func assertJSONRoundTrip[T any](t *testing.T, value T, wantJSON string) {
+	t.Helper()
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, wantJSON, string(data))
+
+	var decoded T
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, value, decoded)
+}
+
+func TestAddress_JSON(t *testing.T) {
+	addr, err := domain.NewAddress("0x71C7656EC7ab88b098defB751B7401B5f6d8976F")
+	require.NoError(t, err)
+	assertJSONRoundTrip(t, addr, `"0x71c7656ec7ab88b098defb751b7401b5f6d8976f"`)
+	assertJSONRoundTrip(t, domain.Address{}, `null`)
+
+	var decoded domain.Address
+	require.NoError(t, json.Unmarshal([]byte(`"0x71C7656EC7AB88B098DEFB751B7401B5F6D8976F"`), &decoded))
+	assert.Equal(t, addr, decoded, "decoding canonicalizes the address")
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"0xinvalid"`), &decoded), domain.ErrInvalidAddressFormat)
+	assert.Error(t, json.Unmarshal([]byte(`42`), &decoded))
+}
+
+func TestTransactionHash_JSON(t *testing.T) {
+	hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	assertJSONRoundTrip(t, hash, `"0x1111111111111111111111111111111111111111111111111111111111111111"`)
+	assertJSONRoundTrip(t, domain.TransactionHash{}, `null`)
+
+	var decoded domain.TransactionHash
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"0xbad"`), &decoded), domain.ErrInvalidTransactionHashFormat)
+}
+
+func TestBlockHash_JSON(t *testing.T) {
+	hash, err := domain.NewBlockHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	require.NoError(t, err)
+	assertJSONRoundTrip(t, hash, `"0x2222222222222222222222222222222222222222222222222222222222222222"`)
+	assertJSONRoundTrip(t, domain.BlockHash{}, `null`)
+
+	var decoded domain.BlockHash
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"0xbad"`), &decoded), domain.ErrInvalidBlockHashFormat)
+}
+
+func TestWeiValue_JSON(t *testing.T) {
+	value, err := domain.NewWeiValue("1000000000000000000")
+	require.NoError(t, err)
+	assertJSONRoundTrip(t, value, `"0xde0b6b3a7640000"`)
+
+	data, err := json.Marshal(domain.WeiValue{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"0x0"`, string(data))
+
+	var decoded domain.WeiValue
+	require.NoError(t, json.Unmarshal([]byte(`"1000000000000000000"`), &decoded))
+	assert.True(t, value.Equals(decoded), "decimal strings are accepted")
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"-1"`), &decoded), domain.ErrNegativeWeiValue)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"0xzz"`), &decoded), domain.ErrInvalidWeiValueFormat)
+}
+
+func TestBlockNumber_JSON(t *testing.T) {
+	number, err := domain.NewBlockNumber(19000000)
+	require.NoError(t, err)
+	assertJSONRoundTrip(t, number, `19000000`)
+
+	var decoded domain.BlockNumber
+	assert.ErrorIs(t, json.Unmarshal([]byte(`-1`), &decoded), domain.ErrNegativeBlockNumber)
+	assert.Error(t, json.Unmarshal([]byte(`"0x10"`), &decoded))
+}
+
+func TestTransaction_JSON(t *testing.T) {
+	hash, err := domain.NewTransactionHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	from, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	value, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	block, err := domain.NewBlockNumber(10)
+	require.NoError(t, err)
+	tx := domain.NewTransaction(hash, from, domain.Address{}, value, block, 1000)
+	tx.Type = domain.TransactionTypeDynamicFee
+	tx.GasPrice, err = domain.NewWeiValue("0x3b9aca00")
+	require.NoError(t, err)
+	tx.MaxFeePerGas, err = domain.NewWeiValue("0x77359400")
+	require.NoError(t, err)
+	tx.MaxPriorityFeePerGas, err = domain.NewWeiValue("0x3b9aca00")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(tx)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, hash.String(), fields["Hash"])
+	assert.Equal(t, from.String(), fields["From"])
+	assert.Nil(t, fields["To"], "a contract creation has no recipient")
+	assert.Equal(t, "0x1", fields["Value"])
+	assert.Equal(t, float64(10), fields["BlockNumber"])
+	assert.Equal(t, "0x77359400", fields["MaxFeePerGas"])
+	assert.Equal(t, "0x3b9aca00", fields["MaxPriorityFeePerGas"])
+
+	var decoded domain.Transaction
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, tx, decoded)
+}
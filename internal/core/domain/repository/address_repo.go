@@ -11,12 +11,28 @@ import (
 
 // MonitoredAddressRepository defines the interface for managing the set of addresses
 type MonitoredAddressRepository interface {
-	// Add persists a new address to be monitored.
-	Add(ctx context.Context, address domain.Address) error
+	// Add persists a new address to be monitored together with the direction of transactions to index for it.
+	// Adding an address that is already monitored replaces its direction.
+	Add(ctx context.Context, address domain.Address, direction domain.SubscriptionDirection) error
 
 	// Exists checks if a given address is already being monitored.
 	Exists(ctx context.Context, address domain.Address) (bool, error)
 
 	// FindAll retrieves all addresses currently being monitored.
 	FindAll(ctx context.Context) ([]domain.Address, error)
+
+	// FindPage retrieves at most limit monitored addresses, skipping the first offset of them. Addresses are
+	// ordered by their string form, so consecutive pages neither repeat nor miss addresses while the set is
+	// unchanged. An offset past the last address yields an empty page.
+	FindPage(ctx context.Context, limit, offset int) ([]domain.Address, error)
+
+	// Count returns the number of addresses currently being monitored.
+	Count(ctx context.Context) (int, error)
+
+	// FindAllWithDirection retrieves all monitored addresses mapped to their subscription direction.
+	FindAllWithDirection(ctx context.Context) (map[domain.Address]domain.SubscriptionDirection, error)
+
+	// Ping checks that the storage is reachable and accepts writes, such as a live database connection with
+	// space left. It must be cheap, as readiness probes call it.
+	Ping(ctx context.Context) error
 }
@@ -0,0 +1,36 @@
+// Package repository defines interfaces for data storage and retrieval operations.
+//
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/mock_$GOPACKAGE/mock_$GOFILE -package=mock_$GOPACKAGE
+package repository
+
+import (
+	"context"
+	"time"
+
+	"trust_wallet_homework/internal/core/domain"
+)
+
+// DeadLetter records a matched transaction that could not be stored, so it can be reprocessed later.
+type DeadLetter struct {
+	Transaction domain.Transaction
+	// Addresses are the monitored addresses the transaction matched.
+	Addresses []domain.Address
+	Attempts  int
+	Reason    string
+	FailedAt  time.Time
+}
+
+// DeadLetterStore defines the interface for keeping transactions whose storage failed permanently.
+type DeadLetterStore interface {
+	// Add records a transaction that could not be stored.
+	Add(ctx context.Context, letter DeadLetter) error
+
+	// FindAll retrieves all recorded dead letters, oldest first.
+	FindAll(ctx context.Context) ([]DeadLetter, error)
+
+	// Count returns the number of recorded dead letters.
+	Count(ctx context.Context) (int, error)
+
+	// Ping checks that the store can still record dead letters. It must be cheap.
+	Ping(ctx context.Context) error
+}
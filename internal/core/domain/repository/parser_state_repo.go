@@ -20,4 +20,14 @@ type ParserStateRepository interface {
 
 	// SetCurrentBlock updates the number of the last successfully processed block.
 	SetCurrentBlock(ctx context.Context, blockNumber domain.BlockNumber) error
+
+	// MarkBlockProcessed records that all transactions of the block have been processed.
+	MarkBlockProcessed(ctx context.Context, blockNumber domain.BlockNumber) error
+
+	// IsBlockProcessed reports whether the block was recorded as processed.
+	// Implementations may forget old blocks, so false does not guarantee the block was never processed.
+	IsBlockProcessed(ctx context.Context, blockNumber domain.BlockNumber) (bool, error)
+
+	// Ping checks that the parser state can still be written. It must be cheap.
+	Ping(ctx context.Context) error
 }
@@ -0,0 +1,37 @@
+// Package repository defines interfaces for data storage and retrieval operations.
+//
+//go:generate mockgen -source=$GOFILE -destination=../../mocks/mock_$GOPACKAGE/mock_$GOFILE -package=mock_$GOPACKAGE
+package repository
+
+import (
+	"context"
+
+	"trust_wallet_homework/internal/core/domain"
+)
+
+// BlockTransaction is a matched transaction of a scanned block together with the addresses to index it under.
+type BlockTransaction struct {
+	Transaction domain.Transaction
+	// IndexParticipants indexes the transaction under its sender and recipient, as TransactionRepository.Store does.
+	IndexParticipants bool
+	// Addresses are further addresses to index the transaction under, as TransactionRepository.StoreForAddress does.
+	Addresses []domain.Address
+}
+
+// ScanRepository combines the repositories a scan writes to, so the results of a block and the scan progress
+// can be stored as one unit of work.
+type ScanRepository interface {
+	TransactionRepository
+	ParserStateRepository
+
+	// StoreBlockResults stores the matched transactions of block and advances the last scanned block to
+	// newHighWater, unless it is already higher. Transactional implementations apply both or neither, so a
+	// crash between them can neither skip the block nor leave its transactions stored without the progress.
+	// Storing the same results again is harmless.
+	StoreBlockResults(
+		ctx context.Context,
+		block domain.BlockNumber,
+		txs []BlockTransaction,
+		newHighWater domain.BlockNumber,
+	) error
+}
@@ -5,15 +5,83 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"trust_wallet_homework/internal/core/domain"
 )
 
+// ErrTransactionNotFound indicates that no transaction with the requested hash is stored.
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// TransactionFilter selects stored transactions of an address.
+type TransactionFilter struct {
+	// FromBlock and ToBlock bound the blocks the transactions were included in (inclusive).
+	FromBlock domain.BlockNumber
+	ToBlock   domain.BlockNumber
+	// MinValue keeps only transactions transferring at least this value; the zero value keeps all.
+	MinValue domain.WeiValue
+}
+
 // TransactionRepository defines the interface for storing and retrieving.
+// The FindByAddress methods return transactions in chain order as defined by domain.CompareTransactions,
+// regardless of the order they were stored in, so results are stable across restarts and reprocessing.
 type TransactionRepository interface {
 	// Store saves a transaction to the persistent storage.
 	Store(ctx context.Context, tx domain.Transaction) error
 
+	// StoreForAddress saves a transaction under an address that is neither its sender nor its recipient,
+	// such as a token recipient found in the call data.
+	StoreForAddress(ctx context.Context, address domain.Address, tx domain.Transaction) error
+
 	// FindByAddress retrieves all stored transactions (both inbound and outbound).
 	FindByAddress(ctx context.Context, address domain.Address) ([]domain.Transaction, error)
+
+	// FindByAddressInBlockRange retrieves stored transactions for an address included in blocks from..to (inclusive).
+	FindByAddressInBlockRange(
+		ctx context.Context,
+		address domain.Address,
+		from, to domain.BlockNumber,
+	) ([]domain.Transaction, error)
+
+	// FindByAddressFiltered retrieves stored transactions for an address that match the filter.
+	FindByAddressFiltered(
+		ctx context.Context,
+		address domain.Address,
+		filter TransactionFilter,
+	) ([]domain.Transaction, error)
+
+	// FindByHash retrieves a stored transaction by its hash.
+	// It returns ErrTransactionNotFound if no such transaction is stored.
+	FindByHash(ctx context.Context, hash domain.TransactionHash) (domain.Transaction, error)
+
+	// FindByBlock retrieves every distinct stored transaction included in the block, ordered by
+	// transaction index and then by hash.
+	FindByBlock(ctx context.Context, block domain.BlockNumber) ([]domain.Transaction, error)
+
+	// FindLatestByAddress retrieves the stored transaction of an address included in the highest block,
+	// the one with the highest transaction index if there are several.
+	// It returns ErrTransactionNotFound if no transaction is stored for the address.
+	FindLatestByAddress(ctx context.Context, address domain.Address) (domain.Transaction, error)
+
+	// FindAddressesWithTransactions returns every address with at least one stored transaction,
+	// ordered by their string form.
+	FindAddressesWithTransactions(ctx context.Context) ([]domain.Address, error)
+
+	// CountByAddress returns the number of stored transactions (both inbound and outbound) for an address.
+	CountByAddress(ctx context.Context, address domain.Address) (int, error)
+
+	// CountAll returns the number of distinct stored transactions.
+	CountAll(ctx context.Context) (int, error)
+
+	// Each calls fn for every distinct stored transaction in chain order, as defined by domain.CompareTransactions.
+	// Iteration stops at the first error returned by fn or when ctx is done, and that error is returned.
+	Each(ctx context.Context, fn func(domain.Transaction) error) error
+
+	// Prune removes every stored transaction included in a block below beforeBlock
+	// and returns the number of distinct transactions removed.
+	Prune(ctx context.Context, beforeBlock domain.BlockNumber) (int, error)
+
+	// Ping checks that transactions can still be stored, failing e.g. on a full disk or a broken database
+	// connection. It must be cheap.
+	Ping(ctx context.Context) error
 }
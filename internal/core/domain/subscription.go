@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSubscriptionDirection indicates that a subscription direction is not one of "in", "out" or "both".
+var ErrInvalidSubscriptionDirection = errors.New("invalid subscription direction")
+
+// SubscriptionDirection selects which transactions of a monitored address are indexed.
+type SubscriptionDirection string
+
+// Supported subscription directions.
+const (
+	SubscriptionDirectionBoth SubscriptionDirection = "both"
+	SubscriptionDirectionIn   SubscriptionDirection = "in"
+	SubscriptionDirectionOut  SubscriptionDirection = "out"
+)
+
+// NewSubscriptionDirection parses a direction filter; an empty string selects both directions.
+func NewSubscriptionDirection(direction string) (SubscriptionDirection, error) {
+	switch SubscriptionDirection(strings.ToLower(strings.TrimSpace(direction))) {
+	case "", SubscriptionDirectionBoth:
+		return SubscriptionDirectionBoth, nil
+	case SubscriptionDirectionIn:
+		return SubscriptionDirectionIn, nil
+	case SubscriptionDirectionOut:
+		return SubscriptionDirectionOut, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidSubscriptionDirection, direction)
+	}
+}
+
+// Accepts reports whether tx should be indexed for addr under this direction.
+// Transactions matched for addr without addr being the sender (e.g. via call data) count as inbound;
+// self-transfers are both inbound and outbound.
+func (d SubscriptionDirection) Accepts(tx Transaction, addr Address) bool {
+	switch d {
+	case SubscriptionDirectionIn:
+		return !tx.From.Equals(addr) || tx.To.Equals(addr)
+	case SubscriptionDirectionOut:
+		return tx.From.Equals(addr)
+	default:
+		return true
+	}
+}
+
+// String returns the direction as a string.
+func (d SubscriptionDirection) String() string {
+	return string(d)
+}
@@ -1,13 +1,55 @@
 package domain
 
+import (
+	"cmp"
+	"strings"
+)
+
+// TransactionStatus is the execution outcome of a transaction as reported by its receipt.
+type TransactionStatus uint8
+
+// Transaction statuses. Unknown means the receipt was not fetched or did not report a status.
+const (
+	TransactionStatusUnknown TransactionStatus = iota
+	TransactionStatusFailed
+	TransactionStatusSuccess
+)
+
+// TransactionType is the EIP-2718 type of a transaction, which determines how its fee is set.
+type TransactionType uint8
+
+// Transaction types known to the parser. Newer types are kept as reported by the node.
+const (
+	TransactionTypeLegacy     TransactionType = 0
+	TransactionTypeAccessList TransactionType = 1
+	// TransactionTypeDynamicFee is an EIP-1559 transaction with a fee cap and a priority fee instead of a gas price.
+	TransactionTypeDynamicFee TransactionType = 2
+)
+
 // Transaction represents the core information about an Ethereum transaction.
 type Transaction struct {
-	Hash        TransactionHash
-	From        Address
+	Hash TransactionHash
+	From Address
+	// To is the recipient; it is the zero Address for contract creation transactions (see IsContractCreation).
 	To          Address
 	Value       WeiValue
 	BlockNumber BlockNumber
 	Timestamp   uint64
+	// TransactionIndex is the position of the transaction within its block; it is 0 for pending transactions.
+	TransactionIndex uint64
+	// Input is the hex-encoded call data; it is empty or "0x" for plain transfers.
+	Input string
+	// Status is the receipt status; it stays unknown unless receipts are fetched.
+	Status TransactionStatus
+	// Type is the EIP-2718 transaction type; it is TransactionTypeLegacy when the node does not report one.
+	Type TransactionType
+	// GasPrice is the price per gas the sender offered. For EIP-1559 transactions nodes report the effective
+	// price paid once the transaction is mined.
+	GasPrice WeiValue
+	// MaxFeePerGas and MaxPriorityFeePerGas are the EIP-1559 fee caps of the transaction.
+	// Both are zero for transactions that predate EIP-1559.
+	MaxFeePerGas         WeiValue
+	MaxPriorityFeePerGas WeiValue
 }
 
 // NewTransaction is a simple constructor for the Transaction entity.
@@ -28,3 +70,42 @@ func NewTransaction(
 		Timestamp:   timestamp,
 	}
 }
+
+// CompareTransactions orders transactions as they appear on chain: by block number, then by position within
+// the block. The hash breaks remaining ties, such as between transactions without an index, so the order is
+// total and does not depend on when or in which order the transactions were stored.
+// It returns -1, 0 or +1 like cmp.Compare.
+func CompareTransactions(a, b Transaction) int {
+	return cmp.Or(
+		cmp.Compare(a.BlockNumber.Value(), b.BlockNumber.Value()),
+		cmp.Compare(a.TransactionIndex, b.TransactionIndex),
+		strings.Compare(a.Hash.String(), b.Hash.String()),
+	)
+}
+
+// IsContractCreation reports whether the transaction deploys a contract, i.e. it has no recipient.
+// A transaction sent to the all-zero address is a regular transfer to that address, not a creation.
+func (t Transaction) IsContractCreation() bool {
+	return t.To.IsZero()
+}
+
+// InvolvesAddress reports whether addr is the sender or the recipient of the transaction.
+// Contract creation transactions have no recipient, so only their sender can match.
+func (t Transaction) InvolvesAddress(addr Address) bool {
+	if addr.IsZero() {
+		return false
+	}
+	return t.From.Equals(addr) || (!t.IsContractCreation() && t.To.Equals(addr))
+}
+
+// InvolvesAnyAddress reports whether the sender or the recipient of the transaction is in the given set.
+func (t Transaction) InvolvesAnyAddress(addresses map[Address]struct{}) bool {
+	if _, ok := addresses[t.From]; ok && !t.From.IsZero() {
+		return true
+	}
+	if t.IsContractCreation() {
+		return false
+	}
+	_, ok := addresses[t.To]
+	return ok
+}
@@ -0,0 +1,144 @@
+package domain_test
+
+import (
+	"testing"
+
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_InvolvesAddress(t *testing.T) {
+	sender, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	recipient, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+	stranger, err := domain.NewAddress("0xcccccccccccccccccccccccccccccccccccccccc")
+	require.NoError(t, err)
+
+	transfer := domain.Transaction{From: sender, To: recipient}
+	contractCreation := domain.Transaction{From: sender}
+
+	tests := []struct {
+		name string
+		tx   domain.Transaction
+		addr domain.Address
+		want bool
+	}{
+		{name: "From match", tx: transfer, addr: sender, want: true},
+		{name: "To match", tx: transfer, addr: recipient, want: true},
+		{name: "Contract creation matches sender", tx: contractCreation, addr: sender, want: true},
+		{name: "Contract creation does not match zero address", tx: contractCreation, addr: domain.Address{}, want: false},
+		{name: "No match", tx: transfer, addr: stranger, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.tx.InvolvesAddress(tt.addr))
+			assert.Equal(t, tt.want, tt.tx.InvolvesAnyAddress(map[domain.Address]struct{}{tt.addr: {}}))
+		})
+	}
+}
+
+func TestTransaction_IsContractCreation(t *testing.T) {
+	sender, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	zero, err := domain.NewAddress("0x0000000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	assert.True(t, domain.Transaction{From: sender}.IsContractCreation())
+	assert.False(t, domain.Transaction{From: sender, To: zero}.IsContractCreation(),
+		"a transfer to the all-zero address has a recipient")
+}
+
+func TestNewSubscriptionDirection(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    domain.SubscriptionDirection
+		wantErr bool
+	}{
+		{input: "", want: domain.SubscriptionDirectionBoth},
+		{input: "both", want: domain.SubscriptionDirectionBoth},
+		{input: "in", want: domain.SubscriptionDirectionIn},
+		{input: " OUT ", want: domain.SubscriptionDirectionOut},
+		{input: "sideways", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := domain.NewSubscriptionDirection(tt.input)
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidSubscriptionDirection)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSubscriptionDirection_Accepts(t *testing.T) {
+	wallet, err := domain.NewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+	other, err := domain.NewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, err)
+
+	inbound := domain.Transaction{From: other, To: wallet}
+	outbound := domain.Transaction{From: wallet, To: other}
+	self := domain.Transaction{From: wallet, To: wallet}
+
+	tests := []struct {
+		name      string
+		direction domain.SubscriptionDirection
+		tx        domain.Transaction
+		want      bool
+	}{
+		{name: "Both accepts inbound", direction: domain.SubscriptionDirectionBoth, tx: inbound, want: true},
+		{name: "Both accepts outbound", direction: domain.SubscriptionDirectionBoth, tx: outbound, want: true},
+		{name: "In accepts inbound", direction: domain.SubscriptionDirectionIn, tx: inbound, want: true},
+		{name: "In rejects outbound", direction: domain.SubscriptionDirectionIn, tx: outbound, want: false},
+		{name: "In accepts self-transfer", direction: domain.SubscriptionDirectionIn, tx: self, want: true},
+		{name: "Out accepts outbound", direction: domain.SubscriptionDirectionOut, tx: outbound, want: true},
+		{name: "Out rejects inbound", direction: domain.SubscriptionDirectionOut, tx: inbound, want: false},
+		{name: "Out accepts self-transfer", direction: domain.SubscriptionDirectionOut, tx: self, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.direction.Accepts(tt.tx, wallet))
+		})
+	}
+}
+
+func TestCompareTransactions(t *testing.T) {
+	newTx := func(hashHex string, block int64, index uint64) domain.Transaction {
+		hash, err := domain.NewTransactionHash(hashHex)
+		require.NoError(t, err)
+		blockNum, err := domain.NewBlockNumber(block)
+		require.NoError(t, err)
+		return domain.Transaction{Hash: hash, BlockNumber: blockNum, TransactionIndex: index}
+	}
+	const (
+		hashA = "0x1111111111111111111111111111111111111111111111111111111111111111"
+		hashB = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	)
+
+	tests := []struct {
+		name string
+		a, b domain.Transaction
+		want int
+	}{
+		{name: "Lower block first", a: newTx(hashB, 1, 5), b: newTx(hashA, 2, 0), want: -1},
+		{name: "Lower index first within a block", a: newTx(hashB, 3, 1), b: newTx(hashA, 3, 2), want: -1},
+		{name: "Hash breaks ties", a: newTx(hashB, 3, 0), b: newTx(hashA, 3, 0), want: 1},
+		{name: "Same transaction", a: newTx(hashA, 3, 0), b: newTx(hashA, 3, 0), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.CompareTransactions(tt.a, tt.b))
+			assert.Equal(t, -tt.want, domain.CompareTransactions(tt.b, tt.a))
+		})
+	}
+}
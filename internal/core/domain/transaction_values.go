@@ -14,6 +14,9 @@ var (
 
 	// ErrInvalidWeiValueFormat indicates that the provided string is not a valid Wei value format.
 	ErrInvalidWeiValueFormat = errors.New("invalid wei value format")
+
+	// ErrNegativeWeiValue indicates that a wei value is negative; wei values are unsigned.
+	ErrNegativeWeiValue = errors.New("wei value cannot be negative")
 )
 
 // Basic regex for Transaction Hash format validation (0x followed by 64 hex characters).
@@ -54,6 +57,9 @@ type WeiValue struct {
 }
 
 // NewWeiValue creates a new WeiValue object from a string.
+// Hex strings ("0x...") are unsigned; a sign after the prefix is rejected as ErrInvalidWeiValueFormat.
+// Decimal strings may carry a sign, but negative values are rejected with ErrNegativeWeiValue.
+// There is no upper bound: any non-negative integer is accepted.
 func NewWeiValue(s string) (WeiValue, error) {
 	trimmedStr := strings.TrimSpace(s)
 	if trimmedStr == "" {
@@ -67,6 +73,9 @@ func NewWeiValue(s string) (WeiValue, error) {
 		if len(trimmedStr) == 2 {
 			return WeiValue{}, fmt.Errorf("%w: hex string is too short '%s'", ErrInvalidWeiValueFormat, trimmedStr)
 		}
+		if trimmedStr[2] == '-' || trimmedStr[2] == '+' {
+			return WeiValue{}, fmt.Errorf("%w: signed hex string '%s'", ErrInvalidWeiValueFormat, trimmedStr)
+		}
 		_, ok = val.SetString(trimmedStr[2:], 16)
 	} else {
 		_, ok = val.SetString(trimmedStr, 10)
@@ -75,6 +84,9 @@ func NewWeiValue(s string) (WeiValue, error) {
 	if !ok {
 		return WeiValue{}, fmt.Errorf("%w: failed to parse '%s'", ErrInvalidWeiValueFormat, trimmedStr)
 	}
+	if val.Sign() < 0 {
+		return WeiValue{}, fmt.Errorf("%w: '%s'", ErrNegativeWeiValue, trimmedStr)
+	}
 
 	return WeiValue{value: val}, nil
 }
@@ -105,6 +117,12 @@ func (wv WeiValue) IsZero() bool {
 	return wv.value.Sign() == 0
 }
 
+// Cmp compares two wei values and returns -1, 0 or +1 if wv is less than, equal to or greater than other.
+// The zero WeiValue compares as zero.
+func (wv WeiValue) Cmp(other WeiValue) int {
+	return wv.BigInt().Cmp(other.BigInt())
+}
+
 // Equals checks if two WeiValue objects are equal.
 func (wv WeiValue) Equals(other WeiValue) bool {
 	if wv.value == nil && other.value == nil {
@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"math/big"
+	"testing"
+
+	"trust_wallet_homework/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWeiValue(t *testing.T) {
+	large, ok := new(big.Int).SetString("123456789012345678901234567890123456789012345678901234567890", 10)
+	require.True(t, ok)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    *big.Int
+		wantErr error
+	}{
+		{name: "Hex zero", input: "0x0", want: big.NewInt(0)},
+		{name: "Decimal zero", input: "0", want: big.NewInt(0)},
+		{name: "Hex value", input: "0xde0b6b3a7640000", want: big.NewInt(1_000_000_000_000_000_000)},
+		{name: "Large positive decimal", input: large.String(), want: large},
+		{name: "Large positive hex", input: "0x" + large.Text(16), want: large},
+		{name: "Negative decimal", input: "-1", wantErr: domain.ErrNegativeWeiValue},
+		{name: "Negative hex", input: "0x-1", wantErr: domain.ErrInvalidWeiValueFormat},
+		{name: "Signed hex", input: "0x+1", wantErr: domain.ErrInvalidWeiValueFormat},
+		{name: "Empty", input: "", wantErr: domain.ErrInvalidWeiValueFormat},
+		{name: "Bare prefix", input: "0x", wantErr: domain.ErrInvalidWeiValueFormat},
+		{name: "Not a number", input: "abc", wantErr: domain.ErrInvalidWeiValueFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NewWeiValue(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 0, tt.want.Cmp(got.BigInt()))
+			assert.GreaterOrEqual(t, got.BigInt().Sign(), 0)
+		})
+	}
+}
+
+func TestWeiValue_Cmp(t *testing.T) {
+	one, err := domain.NewWeiValue("0x1")
+	require.NoError(t, err)
+	ten, err := domain.NewWeiValue("10")
+	require.NoError(t, err)
+	alsoTen, err := domain.NewWeiValue("0xa")
+	require.NoError(t, err)
+
+	assert.Equal(t, -1, one.Cmp(ten))
+	assert.Equal(t, 1, ten.Cmp(one))
+	assert.Equal(t, 0, ten.Cmp(alsoTen))
+	assert.Equal(t, -1, domain.WeiValue{}.Cmp(one), "the zero WeiValue compares as zero")
+	assert.Equal(t, 0, domain.WeiValue{}.Cmp(domain.WeiValue{}))
+}
@@ -4,35 +4,51 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 
 	"trust_wallet_homework/internal/config"
 )
 
 // NewAppLogger creates a new AppLogger instance with the specified level and output format.
 func NewAppLogger(cfg config.LoggerConfig) (AppLogger, error) {
+	return NewAppLoggerWithWriter(cfg, os.Stdout)
+}
+
+// NewAppLoggerWithWriter creates a new AppLogger that writes to the given output.
+// The configured static attributes are attached to every log line, in key order.
+func NewAppLoggerWithWriter(cfg config.LoggerConfig, out io.Writer) (AppLogger, error) {
 	level, err := toSlogLevel(cfg.Level)
 	if err != nil {
 		return nil, fmt.Errorf("logger setup failed: %w", err)
 	}
 
 	opts := &slog.HandlerOptions{
-		Level: level,
+		Level:     level,
+		AddSource: cfg.AddSource,
 	}
 
-	var out io.Writer = os.Stdout
-
 	handler, err := toSlogHandler(cfg.Format, out, opts)
 	if err != nil {
 		return nil, fmt.Errorf("logger setup failed: %w", err)
 	}
 
-	slogLogger := slog.New(handler)
+	slogLogger := slog.New(handler).With(staticAttrs(cfg.Attributes)...)
 	slog.SetDefault(slogLogger)
 
 	return NewSlogAdapter(slogLogger), nil
 }
 
+// staticAttrs converts the configured attributes to slog arguments, sorted by key so the output is stable.
+func staticAttrs(attributes map[string]string) []any {
+	args := make([]any, 0, len(attributes))
+	for _, key := range slices.Sorted(maps.Keys(attributes)) {
+		args = append(args, slog.String(key, attributes[key]))
+	}
+	return args
+}
+
 // toSlogLevel converts a config.LogLevel to a slog.Level.
 func toSlogLevel(level config.LogLevel) (slog.Level, error) {
 	switch level {
@@ -0,0 +1,74 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"trust_wallet_homework/internal/config"
+	"trust_wallet_homework/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logEntry logs a single line through a logger built from cfg and returns it decoded.
+func logEntry(t *testing.T, cfg config.LoggerConfig) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	l, err := logger.NewAppLoggerWithWriter(cfg, &buf)
+	require.NoError(t, err)
+
+	l.With("component", "test").Info("hello", "key", "value")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry
+}
+
+func TestNewAppLoggerWithWriter_AddSource(t *testing.T) {
+	entry := logEntry(t, config.LoggerConfig{Level: config.LogLevelInfo, Format: config.LogFormatJSON, AddSource: true})
+
+	source, ok := entry["source"].(map[string]any)
+	require.True(t, ok, "source must be present when add_source is enabled")
+	assert.Contains(t, source["file"], "logger_factory_test.go", "source must point at the caller, not the adapter")
+	assert.Contains(t, source["function"], "logEntry")
+	assert.NotZero(t, source["line"])
+}
+
+func TestNewAppLoggerWithWriter_NoSourceByDefault(t *testing.T) {
+	entry := logEntry(t, config.LoggerConfig{Level: config.LogLevelInfo, Format: config.LogFormatJSON})
+
+	assert.NotContains(t, entry, "source")
+}
+
+func TestNewAppLoggerWithWriter_StaticAttributes(t *testing.T) {
+	entry := logEntry(t, config.LoggerConfig{
+		Level:  config.LogLevelInfo,
+		Format: config.LogFormatJSON,
+		Attributes: map[string]string{
+			"service":     "ethparser",
+			"version":     "1.2.3",
+			"environment": "staging",
+		},
+	})
+
+	assert.Equal(t, "ethparser", entry["service"])
+	assert.Equal(t, "1.2.3", entry["version"])
+	assert.Equal(t, "staging", entry["environment"])
+	assert.Equal(t, "test", entry["component"])
+	assert.Equal(t, "value", entry["key"])
+	assert.Equal(t, "hello", entry["msg"])
+}
+
+func TestNewAppLoggerWithWriter_RespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := logger.NewAppLoggerWithWriter(config.LoggerConfig{Level: config.LogLevelWarn, Format: config.LogFormatJSON}, &buf)
+	require.NoError(t, err)
+
+	l.Info("dropped")
+	l.Warn("kept")
+
+	assert.NotContains(t, buf.String(), "dropped")
+	assert.Contains(t, buf.String(), "kept")
+}
@@ -1,7 +1,10 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
+	"runtime"
+	"time"
 )
 
 // slogAdapter is a concrete implementation of AppLogger using the standard slog library.
@@ -19,22 +22,22 @@ func NewSlogAdapter(slogLogger *slog.Logger) AppLogger {
 
 // Debug logs a message at DebugLevel.
 func (s *slogAdapter) Debug(msg string, args ...any) {
-	s.adaptee.Debug(msg, args...)
+	s.log(slog.LevelDebug, msg, args...)
 }
 
 // Info logs a message at InfoLevel.
 func (s *slogAdapter) Info(msg string, args ...any) {
-	s.adaptee.Info(msg, args...)
+	s.log(slog.LevelInfo, msg, args...)
 }
 
 // Warn logs a message at WarnLevel.
 func (s *slogAdapter) Warn(msg string, args ...any) {
-	s.adaptee.Warn(msg, args...)
+	s.log(slog.LevelWarn, msg, args...)
 }
 
 // Error logs a message at ErrorLevel.
 func (s *slogAdapter) Error(msg string, args ...any) {
-	s.adaptee.Error(msg, args...)
+	s.log(slog.LevelError, msg, args...)
 }
 
 // With returns a new AppLogger with the given arguments added to the context.
@@ -42,3 +45,17 @@ func (s *slogAdapter) With(args ...any) AppLogger {
 	newSlogLogger := s.adaptee.With(args...)
 	return &slogAdapter{adaptee: newSlogLogger}
 }
+
+// log records the caller of the Debug/Info/Warn/Error method as the source of the entry,
+// so that source locations point at application code rather than at this adapter.
+func (s *slogAdapter) log(level slog.Level, msg string, args ...any) {
+	ctx := context.Background()
+	if !s.adaptee.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip runtime.Callers, log and the level method
+	record := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	record.Add(args...)
+	_ = s.adaptee.Handler().Handle(ctx, record)
+}
@@ -2,28 +2,54 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
 
+var (
+	// ErrInvalidHex indicates that a string is not a well-formed unsigned hex number.
+	ErrInvalidHex = errors.New("invalid hex string")
+
+	// ErrHexOverflow indicates that a well-formed hex number does not fit the target integer type.
+	ErrHexOverflow = errors.New("hex value out of range")
+)
+
 // HexToInt64 converts a hex string (e.g., "0x1a") to int64.
+// Surrounding whitespace is ignored; signs are rejected with ErrInvalidHex and values above
+// math.MaxInt64 with ErrHexOverflow.
 func HexToInt64(hexStr string) (int64, error) {
-	cleaned := strings.TrimPrefix(strings.ToLower(hexStr), "0x")
-	if cleaned == "" {
-		return 0, fmt.Errorf("empty hex string")
+	value, err := parseHex(hexStr)
+	if err != nil {
+		return 0, err
 	}
-	return strconv.ParseInt(cleaned, 16, 64)
+	if value > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %q exceeds int64", ErrHexOverflow, hexStr)
+	}
+	return int64(value), nil
 }
 
 // HexToUint64 converts a hex string (e.g., "0x1a") to uint64.
+// It accepts the same input as HexToInt64, including "0x0" for zero.
 func HexToUint64(hexStr string) (uint64, error) {
-	cleaned := strings.TrimPrefix(strings.ToLower(hexStr), "0x")
+	return parseHex(hexStr)
+}
+
+// parseHex parses an unsigned hex number with an optional "0x" prefix.
+// An empty string or a bare "0x" prefix is rejected with ErrInvalidHex.
+func parseHex(hexStr string) (uint64, error) {
+	cleaned := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(hexStr)), "0x")
 	if cleaned == "" {
-		return 0, fmt.Errorf("empty hex string")
+		return 0, fmt.Errorf("%w: empty hex string %q", ErrInvalidHex, hexStr)
 	}
-	if cleaned == "0" {
-		return 0, nil
+	value, err := strconv.ParseUint(cleaned, 16, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("%w: %q exceeds uint64", ErrHexOverflow, hexStr)
+		}
+		return 0, fmt.Errorf("%w: %q", ErrInvalidHex, hexStr)
 	}
-	return strconv.ParseUint(cleaned, 16, 64)
+	return value, nil
 }
@@ -0,0 +1,77 @@
+package utils_test
+
+import (
+	"testing"
+
+	"trust_wallet_homework/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexToInt64(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr error
+	}{
+		{name: "Normal value", input: "0x1a", want: 26},
+		{name: "Max int64", input: "0x7fffffffffffffff", want: 9223372036854775807},
+		{name: "Leading and trailing whitespace", input: "  0x10\n", want: 16},
+		{name: "Just above max int64", input: "0x8000000000000000", wantErr: utils.ErrHexOverflow},
+		{name: "Above max uint64", input: "0x1ffffffffffffffff", wantErr: utils.ErrHexOverflow},
+		{name: "Negative-looking after prefix", input: "0x-1", wantErr: utils.ErrInvalidHex},
+		{name: "Negative-looking before prefix", input: "-0x1", wantErr: utils.ErrInvalidHex},
+		{name: "Non-hex characters", input: "0xzz", wantErr: utils.ErrInvalidHex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.HexToInt64(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHexParsers_ZeroAndEmptyConsistency(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    uint64
+		wantErr bool
+	}{
+		{name: "Empty string", input: "", wantErr: true},
+		{name: "Bare prefix", input: "0x", wantErr: true},
+		{name: "Zero", input: "0x0", want: 0},
+		{name: "Padded zero", input: "0x00", want: 0},
+		{name: "Normal value", input: "0x5f5e100", want: 100000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotInt, errInt := utils.HexToInt64(tt.input)
+			gotUint, errUint := utils.HexToUint64(tt.input)
+			if tt.wantErr {
+				assert.ErrorIs(t, errInt, utils.ErrInvalidHex)
+				assert.ErrorIs(t, errUint, utils.ErrInvalidHex)
+				return
+			}
+			require.NoError(t, errInt)
+			require.NoError(t, errUint)
+			assert.Equal(t, int64(tt.want), gotInt)
+			assert.Equal(t, tt.want, gotUint)
+		})
+	}
+}
+
+func TestHexToUint64_AboveMaxInt64(t *testing.T) {
+	got, err := utils.HexToUint64("0xffffffffffffffff")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(18446744073709551615), got)
+}
@@ -3,21 +3,138 @@ package ethparser
 
 import (
 	"context"
+	"errors"
 )
 
+var (
+	// ErrAddressNotSubscribed indicates that the requested address is not being monitored.
+	ErrAddressNotSubscribed = errors.New("address is not subscribed")
+
+	// ErrAddressAlreadySubscribed indicates that the address is already being monitored.
+	ErrAddressAlreadySubscribed = errors.New("address is already subscribed")
+
+	// ErrSubscriptionLimitReached indicates that no more addresses can be subscribed because the configured
+	// maximum number of subscriptions is reached.
+	ErrSubscriptionLimitReached = errors.New("subscription limit reached")
+
+	// ErrBlockNotFound indicates that the node has no block with the requested number.
+	ErrBlockNotFound = errors.New("block not found")
+
+	// ErrTransactionNotFound indicates that no stored transaction has the requested hash.
+	ErrTransactionNotFound = errors.New("transaction not found")
+
+	// ErrInvalidBlockRange indicates that the lower bound of a block range is greater than the upper bound.
+	ErrInvalidBlockRange = errors.New("invalid block range")
+
+	// ErrInvalidPagination indicates a non-positive page limit or a negative page offset.
+	ErrInvalidPagination = errors.New("invalid pagination")
+
+	// ErrRewindBeyondHead indicates that a rewind targeted a block above the current network head.
+	ErrRewindBeyondHead = errors.New("rewind target is above the network head")
+
+	// ErrInvalidSortOrder indicates that a transaction query asked for an unsupported sort order.
+	ErrInvalidSortOrder = errors.New("invalid sort order")
+
+	// ErrParserAlreadyRunning indicates that Start was called while the parser is running.
+	ErrParserAlreadyRunning = errors.New("parser is already running")
+
+	// ErrNodeUnavailable indicates that the Ethereum node could not be reached.
+	ErrNodeUnavailable = errors.New("ethereum node is unavailable")
+
+	// ErrParserStopping indicates that Start or Stop was called while the parser is stopping.
+	ErrParserStopping = errors.New("parser is stopping")
+
+	// ErrParserNotRunning indicates that an operation needing the polling loop was requested while it is not running.
+	ErrParserNotRunning = errors.New("parser is not running")
+
+	// ErrStorageUnavailable indicates that the storage cannot be written, so new transactions would be lost.
+	ErrStorageUnavailable = errors.New("storage is unavailable")
+)
+
+// Transaction directions relative to the queried address.
+const (
+	DirectionIn   = "in"
+	DirectionOut  = "out"
+	DirectionSelf = "self"
+	// DirectionBoth is accepted only as a subscription filter and selects inbound and outbound transactions.
+	DirectionBoth = "both"
+)
+
+// Receipt statuses of a transaction.
+const (
+	StatusFailed  = 0
+	StatusSuccess = 1
+)
+
+// Sort orders of a transaction query.
+const (
+	SortValueDesc = "value_desc"
+	SortValueAsc  = "value_asc"
+	SortBlockDesc = "block_desc"
+)
+
+// TransactionQuery selects and orders the transactions returned by QueryTransactions.
+// Transactions with equal values are ordered by block number, newest first.
+type TransactionQuery struct {
+	// FromBlock and ToBlock bound the blocks the transactions were included in (inclusive).
+	FromBlock int64
+	ToBlock   int64
+	// MinValue keeps only transactions transferring at least this many wei (hex "0x..." or decimal); empty keeps all.
+	MinValue string
+	// Sort is one of the Sort constants; empty returns transactions in chain order, oldest first.
+	Sort string
+}
+
 // Transaction represents the data structure for a transaction returned by the API.
+// Direction is set only when the transaction is returned for a specific address.
+// Status is set only when receipts are fetched; it is StatusSuccess or StatusFailed.
+// To is nil (JSON null) for contract creation transactions.
 type Transaction struct {
-	Hash        string `json:"hash"`
-	From        string `json:"from"`
-	To          string `json:"to"`
-	Value       string `json:"value"`
-	BlockNumber int64  `json:"blockNumber"`
-	Timestamp   uint64 `json:"timestamp"`
+	Hash        string  `json:"hash"`
+	From        string  `json:"from"`
+	To          *string `json:"to"`
+	Value       string  `json:"value"`
+	BlockNumber int64   `json:"blockNumber"`
+	Timestamp   uint64  `json:"timestamp"`
+	// TransactionIndex is the position of the transaction within its block.
+	TransactionIndex uint64 `json:"transactionIndex"`
+	Direction        string `json:"direction,omitempty"`
+	Status           *int   `json:"status,omitempty"`
+}
+
+// Block represents the data structure for a parsed block returned by the API.
+type Block struct {
+	Number           int64         `json:"number"`
+	Hash             string        `json:"hash"`
+	Timestamp        uint64        `json:"timestamp"`
+	TransactionCount int           `json:"transactionCount"`
+	Transactions     []Transaction `json:"transactions"`
 }
 
 // SubscribeRequestDTO represents the expected JSON body for a subscription request.
 type SubscribeRequestDTO struct {
-	Address string `json:"address" validate:"required,eth_addr"`
+	Address   string `json:"address" validate:"required,eth_addr"`
+	Direction string `json:"direction,omitempty" validate:"omitempty,oneof=in out both"`
+}
+
+// SubscribeResult describes the outcome of subscribing a single address in a bulk request.
+type SubscribeResult struct {
+	Address string `json:"address"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ParserStats summarizes the progress and size of the parser. DeadLetters counts matched transactions that
+// could not be stored; SkippedScans counts scan iterations that matched nothing because no address was subscribed.
+type ParserStats struct {
+	LastScannedBlock    int64 `json:"lastScannedBlock"`
+	NetworkHead         int64 `json:"networkHead"`
+	LagBlocks           int64 `json:"lagBlocks"`
+	SubscribedAddresses int   `json:"subscribedAddresses"`
+	TransactionsStored  int   `json:"transactionsStored"`
+	DeadLetters         int   `json:"deadLetters"`
+	SkippedScans        int64 `json:"skippedScans"`
+	UptimeSeconds       int64 `json:"uptimeSeconds"`
 }
 
 // Parser defines the public interface for the Ethereum blockchain parser service.
@@ -26,11 +143,96 @@ type Parser interface {
 	GetCurrentBlock(ctx context.Context) (blockNumber int64, err error)
 
 	// Subscribe adds an Ethereum address (in string format) to the list of monitored addresses.
-	Subscribe(ctx context.Context, address string) (err error)
+	// Direction restricts indexing to inbound ("in") or outbound ("out") transactions; empty or "both" keeps all.
+	Subscribe(ctx context.Context, address string, direction string) (err error)
+
+	// SubscribeMany subscribes several addresses at once, reporting a per-address result.
+	// Invalid addresses are reported in the results and do not fail the whole call.
+	SubscribeMany(ctx context.Context, addresses []string, direction string) (results []SubscribeResult, err error)
+
+	// ListSubscriptions returns at most limit subscribed addresses, in lexicographic order, skipping the first
+	// offset of them, together with the total number of subscribed addresses. It returns ErrInvalidPagination
+	// for a non-positive limit or a negative offset.
+	ListSubscriptions(ctx context.Context, limit, offset int) (addresses []string, total int, err error)
 
 	// GetTransactions retrieves all stored transactions (both inbound and outbound)
 	GetTransactions(ctx context.Context, address string) (transactions []Transaction, err error)
 
+	// GetTransactionsForAddresses retrieves the stored transactions of several addresses in one call,
+	// keyed by the lowercase form of each address. Every address must be valid and subscribed.
+	GetTransactionsForAddresses(ctx context.Context, addresses []string) (transactions map[string][]Transaction, err error)
+
+	// GetTransactionsInRange retrieves stored transactions for an address included in blocks from..to (inclusive).
+	GetTransactionsInRange(ctx context.Context, address string, from, to int64) (transactions []Transaction, err error)
+
+	// QueryTransactions retrieves stored transactions for an address that match the query, in the requested order.
+	QueryTransactions(ctx context.Context, address string, query TransactionQuery) (transactions []Transaction, err error)
+
+	// GetTransactionCount returns the number of stored transactions (both inbound and outbound) for an address.
+	GetTransactionCount(ctx context.Context, address string) (count int, err error)
+
+	// LastActivity returns the block number and timestamp of the most recent stored transaction of an address.
+	// Both are zero when no transaction is stored for the address yet.
+	LastActivity(ctx context.Context, address string) (blockNumber int64, timestamp uint64, err error)
+
+	// ListAddressesWithTransactions returns the addresses that have stored transactions, in lexicographic order.
+	// Unlike subscriptions, it includes only addresses for which something was indexed.
+	ListAddressesWithTransactions(ctx context.Context) (addresses []string, err error)
+
+	// GetTransactionsInBlock retrieves the stored transactions included in a block, across all monitored addresses.
+	// Each transaction is returned once, even when it was stored for several addresses.
+	GetTransactionsInBlock(ctx context.Context, number int64) (transactions []Transaction, err error)
+
+	// GetTransactionByHash retrieves a stored transaction by its hash, regardless of the address it was stored for.
+	GetTransactionByHash(ctx context.Context, hash string) (transaction *Transaction, err error)
+
+	// GetNodeTransaction fetches a transaction by its hash from the node, whether or not it is stored.
+	// The transaction has no timestamp or direction; a pending transaction also has no block number.
+	GetNodeTransaction(ctx context.Context, hash string) (transaction *Transaction, err error)
+
+	// ExportTransactions calls fn for every stored transaction, or only for those of the address when it is not empty.
+	// Transactions are passed one at a time so callers can stream them; the first error returned by fn stops the export.
+	ExportTransactions(ctx context.Context, address string, fn func(Transaction) error) (err error)
+
+	// WatchTransactions streams transactions newly stored for the address until ctx is done.
+	WatchTransactions(ctx context.Context, address string) (transactions <-chan Transaction, err error)
+
+	// GetBlock fetches a block by number from the node, including all of its transactions.
+	GetBlock(ctx context.Context, number int64) (block *Block, err error)
+
+	// GetBlockByHash fetches a block by hash from the node, including all of its transactions.
+	// It returns ErrBlockNotFound if the node does not know the hash, e.g. because the block was reorged out.
+	GetBlockByHash(ctx context.Context, hash string) (block *Block, err error)
+
+	// Stats returns a summary of the parser state, including how far it lags behind the network head.
+	// When the node cannot be reached, the last known head is reported instead.
+	Stats(ctx context.Context) (stats ParserStats, err error)
+
+	// FirstScanCompleted reports whether the parser has caught up with the network head at least once since it
+	// was started. Until then stored data may be incomplete.
+	FirstScanCompleted() bool
+
+	// PingStorage checks that every store of the parser is reachable and writable. It returns an error wrapping
+	// ErrStorageUnavailable that names each failing store otherwise.
+	PingStorage(ctx context.Context) (err error)
+
+	// Lag returns the last processed block, the network head and how many blocks the former is behind the latter.
+	// When the node cannot be reached, it returns the last known head with an error wrapping ErrNodeUnavailable.
+	Lag(ctx context.Context) (current, head, lag int64, err error)
+
+	// Rewind resets the last processed block to the given block, so parsing resumes after it on the next tick.
+	// Negative blocks and blocks above the network head are rejected.
+	Rewind(ctx context.Context, block int64) (err error)
+
+	// TriggerScan runs a scan iteration immediately instead of waiting for the next tick, and returns once it
+	// completes or ctx is done. It fails with ErrParserNotRunning unless the parser is running.
+	TriggerScan(ctx context.Context) (err error)
+
+	// Replay re-publishes the transactions stored for a subscribed address in blocks from since onwards to the
+	// parser's event subscribers, oldest first, without storing them again.
+	// It fails with ErrAddressNotSubscribed if the address is not monitored.
+	Replay(ctx context.Context, address string, since int64) (err error)
+
 	// Start initiates the background process of polling for new blocks and parsing transactions.
 	Start(ctx context.Context) (err error)
 